@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	gohttp "net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/yama6a/bolan-compare/internal/app/api"
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/alandsbanken"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/avanza"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/bluestep"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/borgo"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/danskebank"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/ekobanken"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/handelsbanken"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/hypoteket"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/hypotekspension"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/icabanken"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/ikanobank"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/jak"
@@ -21,19 +32,64 @@ import (
 	"github.com/yama6a/bolan-compare/internal/app/crawler/nordax"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/nordea"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/nordnet"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/olandsbank"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/riksbank"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/sbab"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/scb"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/seb"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/skandia"
+	"github.com/yama6a/bolan-compare/internal/app/crawler/sparbanker"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/stabelo"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/svea"
 	"github.com/yama6a/bolan-compare/internal/app/crawler/swedbank"
+	"github.com/yama6a/bolan-compare/internal/pkg/archive"
+	"github.com/yama6a/bolan-compare/internal/pkg/calc"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
 	"github.com/yama6a/bolan-compare/internal/pkg/store"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+const usage = `Usage: crawler [mode] [flags]
+
+Modes:
+  crawl     crawl all banks and reference rates once and exit (default)
+  serve     serve the HTTP API and crawl all banks and reference rates periodically
+  backfill  load the complete published average rate history of all banks and exit
+  reparse   parse the archived documents again with the current parsers and report, or -apply, corrections
+
+Rates are kept in memory unless a PostgreSQL database is configured with -db or DATABASE_URL. Every fetched document
+is archived if an archive directory is configured with -archive or ARCHIVE_DIR. Pending reviews can only be approved
+or rejected over the API with the admin token configured with -admin-token or ADMIN_TOKEN.
+`
+
 func main() {
+	mode := "crawl"
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		mode, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet(mode, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	addr := flags.String("addr", ":8080", "listen address of the HTTP API (serve mode)")
+	adminToken := flags.String("admin-token", os.Getenv("ADMIN_TOKEN"),
+		"bearer token required to approve or reject pending reviews over the API, unset disables them (serve mode)")
+	interval := flags.Duration("interval", 6*time.Hour, "time between crawls (serve mode)")
+	dbURL := flags.String("db", os.Getenv("DATABASE_URL"), "PostgreSQL connection string")
+	archiveDir := flags.String("archive", os.Getenv("ARCHIVE_DIR"), "directory to archive every fetched document in")
+	archiveRetention := flags.Duration("archive-retention", 90*24*time.Hour,
+		"how long archived documents are kept, the latest copy of each source is always kept (0 keeps all)")
+	bank := flags.String("bank", "", "only reparse rates of this bank (reparse mode)")
+	from := flags.String("from", "", "only reparse documents fetched on or after this date, YYYY-MM-DD (reparse mode)")
+	to := flags.String("to", "", "only reparse documents fetched before this date, YYYY-MM-DD (reparse mode)")
+	apply := flags.Bool("apply", false, "store the corrected rates instead of only reporting them (reparse mode)")
+	noErr(flags.Parse(args))
+
 	loggerConfig := zap.NewDevelopmentConfig()
 	loggerConfig.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	loggerConfig.DisableStacktrace = true
@@ -50,6 +106,14 @@ func main() {
 	}
 	httpClient := http.NewClient(baseHTTPClient, httpTimeout)
 
+	var sourceArchive *archive.Archive
+	if *archiveDir != "" {
+		blobs, err := archive.NewDirStore(*archiveDir)
+		noErr(err)
+		sourceArchive = archive.New(blobs, time.Now)
+		httpClient = archive.NewClient(httpClient, sourceArchive, logger.Named("Archive"))
+	}
+
 	crawlers := []crawler.SiteCrawler{
 		danskebank.NewDanskeBankCrawler(httpClient, logger.Named("danske-bank-crawler")),
 		seb.NewSebBankCrawler(httpClient, logger.Named("seb-crawler")),
@@ -72,12 +136,138 @@ func main() {
 		svea.NewSveaCrawler(httpClient, logger.Named("svea-crawler")),
 		avanza.NewAvanzaCrawler(httpClient, logger.Named("avanza-crawler")),
 		marginalen.NewMarginalenCrawler(httpClient, logger.Named("marginalen-crawler")),
+		ekobanken.NewEkobankenCrawler(httpClient, logger.Named("ekobanken-crawler")),
+		hypotekspension.NewHypotekspensionCrawler(httpClient, logger.Named("hypotekspension-crawler")),
+		olandsbank.NewOlandsBankCrawler(httpClient, logger.Named("olands-bank-crawler")),
+		scb.NewSCBCrawler(httpClient, logger.Named("scb-crawler")),
 	}
+	crawlers = append(crawlers,
+		sparbanker.NewSparbankCrawlers(sparbanker.DefaultSparbanker(), httpClient, logger.Named("sparbank-crawler"))...)
+	crawlers = append(crawlers,
+		borgo.NewBorgoCrawlers(borgo.DefaultDistributors(), httpClient, logger.Named("borgo-crawler"))...)
+
+	referenceCrawlers := []crawler.ReferenceRateCrawler{
+		riksbank.NewRiksbankCrawler(httpClient, logger.Named("riksbank-crawler")),
+	}
+
+	var st store.Store
+	if *dbURL != "" {
+		st = connectPostgres(*dbURL, logger.Named("Store"))
+	} else {
+		logger.Warn("no database configured, rates are lost on exit")
+		st = store.NewMemoryStore(nil, logger.Named("Store"))
+	}
+
+	validator := crawler.NewValidator(model.BankProfiles(), crawler.DefaultRateRanges(), time.Now)
+	detector := crawler.NewAnomalyDetector(crawler.DefaultAnomalyConfig())
+	checker := crawler.NewConsistencyChecker(1)
+	svc := crawler.NewService(st, crawlers, validator, detector, checker, logger.Named("Crawler Svc"))
+	references := crawler.NewReferenceCollector(st, referenceCrawlers, logger.Named("References"))
+	crawl := func() {
+		svc.Crawl()
+		references.Collect()
+		if sourceArchive != nil {
+			pruneArchive(sourceArchive, archive.RetentionPolicy{MaxAge: *archiveRetention, KeepLatest: 1}, logger)
+		}
+	}
+
+	switch mode {
+	case "crawl":
+		crawl()
+	case "backfill":
+		historicValidator := crawler.NewValidator(model.BankProfiles(), crawler.HistoricRateRanges(), time.Now)
+		crawler.NewBackfiller(st, crawlers, historicValidator, logger.Named("Backfill")).Backfill()
+	case "reparse":
+		if sourceArchive == nil {
+			noErr(errors.New("reparse requires an archive configured with -archive or ARCHIVE_DIR"))
+		}
+		opts := crawler.ReparseOptions{
+			Bank:  model.Bank(*bank),
+			From:  parseDate(*from),
+			To:    parseDate(*to),
+			Apply: *apply,
+		}
+		_, err := crawler.NewReparser(st, sourceArchive, crawlers, validator, logger.Named("Reparse")).Reparse(opts)
+		noErr(err)
+	case "serve":
+		server := api.NewServer(st, calc.DefaultRules(), *adminToken, logger.Named("API"))
+		noErr(serve(crawl, server, *addr, *interval, logger))
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+}
 
-	pgStore := store.NewMemoryStore(nil, logger.Named("Store"))
-	svc := crawler.NewService(pgStore, crawlers, logger.Named("Crawler Svc"))
+// connectPostgres connects to the database and applies all pending migrations.
+func connectPostgres(url string, logger *zap.Logger) *store.PostgresStore {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	svc.Crawl()
+	pool, err := pgxpool.Connect(ctx, url)
+	noErr(err)
+
+	pgStore := store.NewPostgresStore(pool, logger)
+	noErr(pgStore.Migrate(ctx))
+	return pgStore
+}
+
+// parseDate parses a YYYY-MM-DD flag value as midnight UTC. An empty value is the zero time.
+func parseDate(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	noErr(err)
+	return date
+}
+
+// pruneArchive removes the archived documents the policy no longer keeps. A failure only delays the cleanup.
+func pruneArchive(sourceArchive *archive.Archive, policy archive.RetentionPolicy, logger *zap.Logger) {
+	result, err := sourceArchive.Prune(policy)
+	if err != nil {
+		logger.Error("failed to prune source archive", zap.Error(err))
+		return
+	}
+	logger.Info("pruned source archive", zap.Int("records", result.Records), zap.Int("documents", result.Documents))
+}
+
+// serve crawls periodically in the background and serves the API until the process is interrupted.
+func serve(crawl func(), server *api.Server, addr string, interval time.Duration, logger *zap.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			crawl()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	httpServer := &gohttp.Server{
+		Addr:              addr,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("failed to shut down API server", zap.Error(err))
+		}
+	}()
+
+	logger.Info("serving API", zap.String("addr", addr), zap.Duration("crawlInterval", interval))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, gohttp.ErrServerClosed) {
+		return fmt.Errorf("failed to serve API: %w", err)
+	}
+	return nil
 }
 
 func noErr(err error) {
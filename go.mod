@@ -3,6 +3,7 @@ module github.com/yama6a/bolan-compare
 go 1.25
 
 require (
+	github.com/jackc/pgconn v1.14.3
 	github.com/jackc/pgx/v4 v4.18.3
 	go.uber.org/zap v1.27.1
 	golang.org/x/net v0.47.0
@@ -10,7 +11,6 @@ require (
 
 require (
 	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
-	github.com/jackc/pgconn v1.14.3 // indirect
 	github.com/jackc/pgio v1.0.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgproto3/v2 v2.3.3 // indirect
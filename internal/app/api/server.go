@@ -0,0 +1,439 @@
+// Package api serves the stored interest rates and calculations over HTTP.
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	gohttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/calc"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	store      store.Store
+	rules      calc.Rules
+	groups     map[model.Bank]model.BankGroup
+	adminToken string
+	logger     *zap.Logger
+}
+
+// NewServer creates a server for the rates in s. Requests that change data, like approving a pending review, must send
+// adminToken as a bearer token; they are refused if adminToken is empty.
+func NewServer(s store.Store, rules calc.Rules, adminToken string, logger *zap.Logger) *Server {
+	return &Server{
+		store:      s,
+		rules:      rules,
+		groups:     model.BankGroups(model.BankProfiles()),
+		adminToken: adminToken,
+		logger:     logger,
+	}
+}
+
+// Handler returns the HTTP handler with all API routes registered.
+func (s *Server) Handler() gohttp.Handler {
+	mux := gohttp.NewServeMux()
+	mux.HandleFunc("GET /rates", s.handleRates)
+	mux.HandleFunc("GET /calculate", s.handleCalculate)
+	mux.HandleFunc("GET /rank", s.handleRank)
+	mux.HandleFunc("GET /spreads", s.handleSpreads)
+	mux.HandleFunc("GET /benchmark", s.handleBenchmark)
+	mux.HandleFunc("GET /reviews", s.handleListReviews)
+	mux.HandleFunc("POST /reviews/{id}/approve", s.requireAdmin(s.handleResolveReview(true)))
+	mux.HandleFunc("POST /reviews/{id}/reject", s.requireAdmin(s.handleResolveReview(false)))
+	return mux
+}
+
+// handleRates lists the stored rates with the source each was parsed from, to check a rate against the bank's page.
+//
+// Query parameters: bank, term and collapse (optional). bank and term limit the list to one bank or term. collapse
+// "group" keeps only the lowest rate of each bank group for the same product, like for /rank.
+func (s *Server) handleRates(w gohttp.ResponseWriter, r *gohttp.Request) {
+	q := r.URL.Query()
+	collapse, err := parseCollapse(r)
+	if err != nil {
+		s.writeError(w, gohttp.StatusBadRequest, err)
+		return
+	}
+	var term model.Term
+	if value := q.Get("term"); value != "" {
+		if term, err = model.ParseTerm(value); err != nil {
+			s.writeError(w, gohttp.StatusBadRequest, err)
+			return
+		}
+	}
+
+	sets, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load interest rates"))
+		return
+	}
+
+	rates := calc.ListRates(sets, model.Bank(q.Get("bank")), term)
+	if collapse {
+		rates = calc.CollapseRates(rates, s.groups)
+	}
+	s.writeJSON(w, gohttp.StatusOK, rates)
+}
+
+// handleCalculate computes the monthly and total cost of a loan at one bank.
+//
+// Query parameters: bank, term, loanAmount, propertyValue (required) and income, union, energyClass (optional). union
+// is the union organisation the borrower is a member of, like "Saco".
+func (s *Server) handleCalculate(w gohttp.ResponseWriter, r *gohttp.Request) {
+	req, err := parseCalculateRequest(r)
+	if err != nil {
+		s.writeError(w, gohttp.StatusBadRequest, err)
+		return
+	}
+
+	sets, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load interest rates"))
+		return
+	}
+
+	result, err := calc.Calculate(sets, req, s.rules)
+	if err != nil {
+		s.writeCalcError(w, err)
+		return
+	}
+
+	s.writeJSON(w, gohttp.StatusOK, result)
+}
+
+// handleRank ranks all banks by the best rate they offer the borrower for each term.
+//
+// Query parameters: loanAmount, propertyValue (required) and income, union, energyClass, terms, collapse (optional).
+// terms is a comma separated list like "3m,1y,5y"; all offered terms are ranked if it is omitted. collapse "group"
+// keeps only the best offer of each bank group and lists the group's other offers as its alternatives.
+func (s *Server) handleRank(w gohttp.ResponseWriter, r *gohttp.Request) {
+	borrower, err := parseBorrower(r)
+	if err != nil {
+		s.writeError(w, gohttp.StatusBadRequest, err)
+		return
+	}
+	collapse, err := parseCollapse(r)
+	if err != nil {
+		s.writeError(w, gohttp.StatusBadRequest, err)
+		return
+	}
+
+	var terms []model.Term
+	if value := r.URL.Query().Get("terms"); value != "" {
+		for _, value := range strings.Split(value, ",") {
+			term, err := model.ParseTerm(strings.TrimSpace(value))
+			if err != nil {
+				s.writeError(w, gohttp.StatusBadRequest, err)
+				return
+			}
+			terms = append(terms, term)
+		}
+	}
+
+	sets, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load interest rates"))
+		return
+	}
+
+	ranking, err := calc.Rank(sets, borrower, terms, s.rules)
+	if err != nil {
+		s.writeCalcError(w, err)
+		return
+	}
+	if collapse {
+		ranking = calc.CollapseRanking(ranking, s.groups)
+	}
+
+	s.writeJSON(w, gohttp.StatusOK, ranking)
+}
+
+// handleSpreads shows how far each bank's list rate lies above a market reference rate, to judge whether it is fair.
+//
+// Query parameters: term, reference and collapse (optional). term defaults to "3m" and reference to "policyRate", the
+// other references are "stibor3m" and "swestr". The latest stored value of the reference is used. collapse "group"
+// keeps only the smallest spread of each bank group, like for /rank.
+func (s *Server) handleSpreads(w gohttp.ResponseWriter, r *gohttp.Request) {
+	q := r.URL.Query()
+	collapse, err := parseCollapse(r)
+	if err != nil {
+		s.writeError(w, gohttp.StatusBadRequest, err)
+		return
+	}
+	term := model.Term3months
+	if value := q.Get("term"); value != "" {
+		if term, err = model.ParseTerm(value); err != nil {
+			s.writeError(w, gohttp.StatusBadRequest, err)
+			return
+		}
+	}
+	series := model.ReferencePolicyRate
+	if value := q.Get("reference"); value != "" {
+		series = model.ReferenceSeries(value)
+		if !series.Valid() {
+			s.writeError(w, gohttp.StatusBadRequest, fmt.Errorf("unknown reference %q", value))
+			return
+		}
+	}
+
+	references, err := s.store.GetReferenceRates(series)
+	if err != nil {
+		s.logger.Error("failed to get reference rates", zap.String("series", string(series)), zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load reference rates"))
+		return
+	}
+	reference, err := calc.LatestReferenceRate(references)
+	if err != nil {
+		s.writeCalcError(w, err)
+		return
+	}
+
+	sets, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load interest rates"))
+		return
+	}
+
+	report, err := calc.Spreads(sets, reference, term)
+	if err != nil {
+		s.writeCalcError(w, err)
+		return
+	}
+	if collapse {
+		report = calc.CollapseSpreads(report, s.groups)
+	}
+
+	s.writeJSON(w, gohttp.StatusOK, report)
+}
+
+// handleBenchmark compares the average rates every bank charged with the market average published by SCB.
+//
+// Query parameters: term, months and format (optional). term defaults to "3m", months limits the report to the latest
+// months and defaults to 12. format "csv" exports one row per bank and month instead of JSON.
+func (s *Server) handleBenchmark(w gohttp.ResponseWriter, r *gohttp.Request) {
+	q := r.URL.Query()
+	term := model.Term3months
+	if value := q.Get("term"); value != "" {
+		var err error
+		if term, err = model.ParseTerm(value); err != nil {
+			s.writeError(w, gohttp.StatusBadRequest, err)
+			return
+		}
+	}
+	months := 12
+	if value := q.Get("months"); value != "" {
+		var err error
+		if months, err = strconv.Atoi(value); err != nil || months < 1 {
+			s.writeError(w, gohttp.StatusBadRequest, errors.New("query parameter months must be a positive number"))
+			return
+		}
+	}
+	format := q.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		s.writeError(w, gohttp.StatusBadRequest, fmt.Errorf("unknown format %q", format))
+		return
+	}
+
+	sets, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load interest rates"))
+		return
+	}
+
+	report, err := calc.CompareWithBenchmark(sets, term)
+	if err != nil {
+		s.writeCalcError(w, err)
+		return
+	}
+	if len(report.Months) > months {
+		report.Months = report.Months[:months]
+	}
+
+	if format == "csv" {
+		s.writeBenchmarkCSV(w, report)
+		return
+	}
+	s.writeJSON(w, gohttp.StatusOK, report)
+}
+
+// handleListReviews lists the crawled rates held back as suspicious, which are not served until they are approved.
+func (s *Server) handleListReviews(w gohttp.ResponseWriter, _ *gohttp.Request) {
+	reviews, err := s.store.GetPendingReviews()
+	if err != nil {
+		s.logger.Error("failed to get pending reviews", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to load pending reviews"))
+		return
+	}
+
+	s.writeJSON(w, gohttp.StatusOK, reviews)
+}
+
+// handleResolveReview approves or rejects a pending review by its URL-escaped ID. An approved rate is stored like a
+// crawled one, a rejected one is discarded and held back again if a later crawl finds it again.
+func (s *Server) handleResolveReview(approve bool) gohttp.HandlerFunc {
+	return func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		id := r.PathValue("id")
+		err := s.store.ResolvePendingReview(id, approve)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			s.writeError(w, gohttp.StatusNotFound, fmt.Errorf("no pending review %q", id))
+		case err != nil:
+			s.logger.Error("failed to resolve pending review", zap.String("id", id), zap.Bool("approve", approve), zap.Error(err))
+			s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to resolve pending review"))
+		default:
+			w.WriteHeader(gohttp.StatusNoContent)
+		}
+	}
+}
+
+// writeBenchmarkCSV writes one row per bank and month, for spreadsheets.
+func (s *Server) writeBenchmarkCSV(w gohttp.ResponseWriter, report calc.BenchmarkReport) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(gohttp.StatusOK)
+
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"month", "term", "bank", "lender", "segment", "averageRate", "marketTerm", "marketRate", "diffBps"})
+	for _, month := range report.Months {
+		for _, rate := range month.Banks {
+			_ = out.Write([]string{
+				fmt.Sprintf("%d-%02d", month.Month.Year, month.Month.Month),
+				report.Term.String(),
+				string(rate.Bank),
+				string(rate.Lender),
+				rate.Segment,
+				rate.AverageRate.String(),
+				report.MarketTerm.String(),
+				month.Market.String(),
+				strconv.FormatFloat(rate.DiffBps, 'f', 0, 64),
+			})
+		}
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		s.logger.Error("failed to write response", zap.Error(err))
+	}
+}
+
+func (s *Server) writeCalcError(w gohttp.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, calc.ErrInvalidRequest):
+		s.writeError(w, gohttp.StatusBadRequest, err)
+	case errors.Is(err, calc.ErrNoRate):
+		s.writeError(w, gohttp.StatusNotFound, err)
+	default:
+		s.logger.Error("failed to calculate", zap.Error(err))
+		s.writeError(w, gohttp.StatusInternalServerError, errors.New("failed to calculate"))
+	}
+}
+
+func parseCalculateRequest(r *gohttp.Request) (calc.Request, error) {
+	q := r.URL.Query()
+	req := calc.Request{Bank: model.Bank(q.Get("bank"))}
+	if req.Bank == "" || q.Get("term") == "" {
+		return calc.Request{}, errors.New("query parameters bank and term are required")
+	}
+
+	var err error
+	if req.Term, err = model.ParseTerm(q.Get("term")); err != nil {
+		return calc.Request{}, err //nolint:wrapcheck // model error names the term
+	}
+	if req.Borrower, err = parseBorrower(r); err != nil {
+		return calc.Request{}, err
+	}
+	return req, nil
+}
+
+func parseBorrower(r *gohttp.Request) (calc.Borrower, error) {
+	q := r.URL.Query()
+	borrower := calc.Borrower{
+		Union:       strings.TrimSpace(q.Get("union")),
+		EnergyClass: model.EnergyClass(strings.ToUpper(q.Get("energyClass"))),
+	}
+
+	var err error
+	if borrower.LoanAmount, err = parseAmount(q.Get("loanAmount"), "loanAmount", true); err != nil {
+		return calc.Borrower{}, err
+	}
+	if borrower.PropertyValue, err = parseAmount(q.Get("propertyValue"), "propertyValue", true); err != nil {
+		return calc.Borrower{}, err
+	}
+	if borrower.AnnualIncome, err = parseAmount(q.Get("income"), "income", false); err != nil {
+		return calc.Borrower{}, err
+	}
+
+	return borrower, nil
+}
+
+// parseCollapse reports whether offers are to be collapsed by bank group, which is the only supported collapse.
+func parseCollapse(r *gohttp.Request) (bool, error) {
+	switch value := r.URL.Query().Get("collapse"); value {
+	case "":
+		return false, nil
+	case "group":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown collapse %q", value)
+	}
+}
+
+func parseAmount(value, name string, required bool) (float64, error) {
+	if value == "" {
+		if required {
+			return 0, fmt.Errorf("query parameter %s is required", name)
+		}
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 {
+		return 0, fmt.Errorf("query parameter %s must be a non-negative number", name)
+	}
+	return amount, nil
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// requireAdmin only passes requests to next that authenticate with the admin token as "Authorization: Bearer <token>".
+func (s *Server) requireAdmin(next gohttp.HandlerFunc) gohttp.HandlerFunc {
+	return func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		if s.adminToken == "" {
+			s.writeError(w, gohttp.StatusForbidden, errors.New("no admin token configured"))
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			s.writeError(w, gohttp.StatusUnauthorized, errors.New("missing or invalid admin token"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) writeError(w gohttp.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func (s *Server) writeJSON(w gohttp.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,704 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	gohttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/calc"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"github.com/yama6a/bolan-compare/internal/pkg/store/storemock"
+	"go.uber.org/zap"
+)
+
+const testAdminToken = "test-admin-token"
+
+func newTestServer(sets []model.InterestSet, err error) *Server {
+	mockStore := &storemock.StoreMock{
+		GetInterestSetsFunc: func() ([]model.InterestSet, error) {
+			return sets, err
+		},
+	}
+	return NewServer(mockStore, calc.DefaultRules(), "", zap.NewNop())
+}
+
+func TestServer_handleCalculate(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{
+			Bank: "SEB", Type: model.TypeUnionDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.7),
+			UnionDiscount: true, UnionOrganisations: []string{"Saco", "TCO"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		storeErr   error
+		wantStatus int
+		wantRate   float64
+	}{
+		{
+			name:       "successful calculation",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=4000000&income=900000",
+			wantStatus: gohttp.StatusOK,
+			wantRate:   4.0,
+		},
+		{
+			name:       "missing bank",
+			query:      "?term=3m&loanAmount=3000000&propertyValue=4000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "unknown term",
+			query:      "?bank=SEB&term=forever&loanAmount=3000000&propertyValue=4000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "non-numeric loan amount",
+			query:      "?bank=SEB&term=3m&loanAmount=lots&propertyValue=4000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "NaN loan amount",
+			query:      "?bank=SEB&term=3m&loanAmount=NaN&propertyValue=4000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "infinite property value",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=Inf",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "infinite income",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=4000000&income=%2BInf",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "union member",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=4000000&union=Saco",
+			wantStatus: gohttp.StatusOK,
+			wantRate:   3.7,
+		},
+		{
+			name:       "member of another union",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=4000000&union=LO",
+			wantStatus: gohttp.StatusOK,
+			wantRate:   4.0,
+		},
+		{
+			name:       "LTV above bolånetak",
+			query:      "?bank=SEB&term=3m&loanAmount=3900000&propertyValue=4000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "no rate for term",
+			query:      "?bank=SEB&term=5y&loanAmount=3000000&propertyValue=4000000",
+			wantStatus: gohttp.StatusNotFound,
+		},
+		{
+			name:       "store error",
+			query:      "?bank=SEB&term=3m&loanAmount=3000000&propertyValue=4000000",
+			storeErr:   errors.New("boom"),
+			wantStatus: gohttp.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newTestServer(sets, tt.storeErr)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/calculate"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != gohttp.StatusOK {
+				return
+			}
+
+			var result calc.Result
+			if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if result.NominalRate != model.RateFromPercent(tt.wantRate) {
+				t.Errorf("NominalRate = %v, want %v", result.NominalRate, tt.wantRate)
+			}
+			if result.MonthlyPayment <= 0 {
+				t.Errorf("MonthlyPayment = %v, want positive", result.MonthlyPayment)
+			}
+		})
+	}
+}
+
+func TestServer_handleCalculate_Source(t *testing.T) {
+	t.Parallel()
+
+	source := model.SourceRef{
+		URL:    "https://www.landshypotek.se/lana/bolanerantor/",
+		Kind:   model.SourceKindHTML,
+		Method: "extractListRates",
+		Hash:   "9f86d081",
+	}
+	sets := []model.InterestSet{
+		{
+			Bank: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.6),
+			Source: &source,
+		},
+	}
+
+	srv := newTestServer(sets, nil)
+	rec := httptest.NewRecorder()
+	query := "?bank=Landshypotek&term=3m&loanAmount=3000000&propertyValue=4000000"
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/calculate"+query, nil))
+	if rec.Code != gohttp.StatusOK {
+		t.Fatalf("status = %d, want %d (body: %s)", rec.Code, gohttp.StatusOK, rec.Body.String())
+	}
+
+	var result calc.Result
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.AppliedSet.Source == nil || *result.AppliedSet.Source != source {
+		t.Errorf("AppliedSet.Source = %+v, want %+v", result.AppliedSet.Source, source)
+	}
+}
+
+func TestServer_handleRates(t *testing.T) {
+	t.Parallel()
+
+	source := model.SourceRef{
+		URL:    "https://www.landshypotek.se/lana/bolanerantor/",
+		Kind:   model.SourceKindHTML,
+		Method: "extractListRates",
+		Hash:   "9f86d081",
+	}
+	sets := []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.6)},
+		{
+			Bank: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.6),
+			Source: &source,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		storeErr   error
+		wantStatus int
+		wantRates  []string
+	}{
+		{
+			name:       "all rates",
+			wantStatus: gohttp.StatusOK,
+			wantRates:  []string{"Landshypotek 3m 3.6", "SEB 3m 4", "SEB 1y 3.6"},
+		},
+		{
+			name:       "one bank",
+			query:      "?bank=SEB",
+			wantStatus: gohttp.StatusOK,
+			wantRates:  []string{"SEB 3m 4", "SEB 1y 3.6"},
+		},
+		{
+			name:       "one term",
+			query:      "?term=1y",
+			wantStatus: gohttp.StatusOK,
+			wantRates:  []string{"SEB 1y 3.6"},
+		},
+		{
+			name:       "unknown term",
+			query:      "?term=forever",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "unknown collapse",
+			query:      "?collapse=bank",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "store error",
+			storeErr:   errors.New("db down"),
+			wantStatus: gohttp.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newTestServer(sets, tt.storeErr)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/rates"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != gohttp.StatusOK {
+				return
+			}
+
+			var rates []calc.ListedRate
+			if err := json.NewDecoder(rec.Body).Decode(&rates); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			got := make([]string, 0, len(rates))
+			for _, rate := range rates {
+				got = append(got, fmt.Sprintf("%s %s %s", rate.Bank, rate.Term, rate.NominalRate))
+				if rate.Bank == "Landshypotek" && (rate.Source == nil || *rate.Source != source) {
+					t.Errorf("Source = %+v, want %+v", rate.Source, source)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantRates) {
+				t.Errorf("rates = %v, want %v", got, tt.wantRates)
+			}
+		})
+	}
+}
+
+func TestServer_handleRates_CollapseGroup(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "Nordax Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.2)},
+		{Bank: "Svensk Hypotekspension", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.0)},
+		{Bank: "Nordax Bank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(5.4)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+	}
+	srv := newTestServer(sets, nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/rates?collapse=group", nil))
+
+	if rec.Code != gohttp.StatusOK {
+		t.Fatalf("status = %d, want %d (body: %s)", rec.Code, gohttp.StatusOK, rec.Body.String())
+	}
+	var rates []calc.ListedRate
+	if err := json.NewDecoder(rec.Body).Decode(&rates); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	got := make([]string, 0, len(rates))
+	for _, rate := range rates {
+		var alternatives []string
+		for _, alternative := range rate.Alternatives {
+			alternatives = append(alternatives, string(alternative.Bank))
+		}
+		got = append(got, fmt.Sprintf("%s %s (%s) %v", rate.Bank, rate.Term, rate.Group, alternatives))
+	}
+	want := []string{
+		"SEB 3m (SEB) []",
+		"Svensk Hypotekspension 3m (NOBA Bank Group) [Nordax Bank]",
+		"Nordax Bank 1y (NOBA Bank Group) []",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rates = %v, want %v", got, want)
+	}
+}
+
+func TestServer_Handler_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(nil, nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodPost, "/calculate", nil))
+
+	if rec.Code != gohttp.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, gohttp.StatusMethodNotAllowed)
+	}
+}
+
+func TestServer_handleRank(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{
+			Bank: "SEB", Type: model.TypeRatioDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.5),
+			RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+		},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.9)},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.7), MaxEnergyClass: model.EnergyClassB},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		storeErr   error
+		wantStatus int
+		wantBanks  map[model.Term][]model.Bank
+	}{
+		{
+			name:       "all terms",
+			query:      "?loanAmount=2000000&propertyValue=4000000",
+			wantStatus: gohttp.StatusOK,
+			wantBanks:  map[model.Term][]model.Bank{model.Term3months: {"SEB", "Nordea"}, model.Term1year: {}},
+		},
+		{
+			name:       "selected terms with green property",
+			query:      "?loanAmount=3000000&propertyValue=4000000&terms=3m,1y&energyClass=a",
+			wantStatus: gohttp.StatusOK,
+			wantBanks:  map[model.Term][]model.Bank{model.Term3months: {"Nordea", "SEB"}, model.Term1year: {"Nordea"}},
+		},
+		{
+			name:       "missing property value",
+			query:      "?loanAmount=2000000",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "invalid energy class",
+			query:      "?loanAmount=2000000&propertyValue=4000000&energyClass=Z",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "unknown term",
+			query:      "?loanAmount=2000000&propertyValue=4000000&terms=3m,forever",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "unknown collapse",
+			query:      "?loanAmount=2000000&propertyValue=4000000&collapse=bank",
+			wantStatus: gohttp.StatusBadRequest,
+		},
+		{
+			name:       "store error",
+			query:      "?loanAmount=2000000&propertyValue=4000000",
+			storeErr:   errors.New("boom"),
+			wantStatus: gohttp.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newTestServer(sets, tt.storeErr)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/rank"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != gohttp.StatusOK {
+				return
+			}
+
+			var ranking calc.Ranking
+			if err := json.NewDecoder(rec.Body).Decode(&ranking); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(ranking.Terms) != len(tt.wantBanks) {
+				t.Fatalf("got %d terms, want %d", len(ranking.Terms), len(tt.wantBanks))
+			}
+			for _, term := range ranking.Terms {
+				want := tt.wantBanks[term.Term]
+				if len(term.Offers) != len(want) {
+					t.Fatalf("term %q has %d offers, want %d", term.Term, len(term.Offers), len(want))
+				}
+				for i, offer := range term.Offers {
+					if offer.Bank != want[i] {
+						t.Errorf("term %q rank %d = %s, want %s", term.Term, i+1, offer.Bank, want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestServer_handleRank_CollapseGroup(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "Nordax Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.2)},
+		{Bank: "Svensk Hypotekspension", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.0)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+	}
+	srv := newTestServer(sets, nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/rank?loanAmount=2000000&propertyValue=4000000&collapse=group", nil))
+
+	if rec.Code != gohttp.StatusOK {
+		t.Fatalf("status = %d, want %d (body: %s)", rec.Code, gohttp.StatusOK, rec.Body.String())
+	}
+	var ranking calc.Ranking
+	if err := json.NewDecoder(rec.Body).Decode(&ranking); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(ranking.Terms) != 1 || len(ranking.Terms[0].Offers) != 2 {
+		t.Fatalf("ranking = %+v, want one term with two offers", ranking)
+	}
+	best := ranking.Terms[0].Offers[1]
+	if best.Bank != "Svensk Hypotekspension" || best.Rank != 2 || best.Group != "NOBA Bank Group" {
+		t.Errorf("second offer = #%d %s (%s), want #2 Svensk Hypotekspension (NOBA Bank Group)", best.Rank, best.Bank, best.Group)
+	}
+	if len(best.Alternatives) != 1 || best.Alternatives[0].Bank != "Nordax Bank" {
+		t.Errorf("alternatives = %+v, want Nordax Bank", best.Alternatives)
+	}
+}
+
+func TestServer_handleSpreads(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{
+			Bank: "SEB", Type: model.TypeRatioDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.5),
+			RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+		},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.9)},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.7)},
+	}
+	day := func(d int) time.Time { return time.Date(2025, time.October, d, 0, 0, 0, 0, time.UTC) }
+	references := map[model.ReferenceSeries][]model.ReferenceRate{
+		model.ReferencePolicyRate: {
+			{Series: model.ReferencePolicyRate, Date: day(1), Rate: model.RateFromPercent(1.75)},
+			{Series: model.ReferencePolicyRate, Date: day(2), Rate: model.RateFromPercent(2)},
+		},
+		model.ReferenceSTIBOR3M: {{Series: model.ReferenceSTIBOR3M, Date: day(2), Rate: model.RateFromPercent(1.9)}},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		referencesErr  error
+		wantStatus     int
+		wantReference  model.Rate
+		wantSpreadsBps map[model.Bank]float64
+	}{
+		{
+			name:           "3m list rates over the policy rate by default",
+			wantStatus:     gohttp.StatusOK,
+			wantReference:  model.RateFromPercent(2),
+			wantSpreadsBps: map[model.Bank]float64{"Nordea": 190, "SEB": 200},
+		},
+		{
+			name:           "other term and reference",
+			query:          "?term=1y&reference=stibor3m",
+			wantStatus:     gohttp.StatusOK,
+			wantReference:  model.RateFromPercent(1.9),
+			wantSpreadsBps: map[model.Bank]float64{"Nordea": 180},
+		},
+		{name: "no reference rate stored", query: "?reference=swestr", wantStatus: gohttp.StatusNotFound},
+		{name: "unknown reference", query: "?reference=euribor", wantStatus: gohttp.StatusBadRequest},
+		{name: "invalid term", query: "?term=forever", wantStatus: gohttp.StatusBadRequest},
+		{name: "unknown collapse", query: "?collapse=bank", wantStatus: gohttp.StatusBadRequest},
+		{name: "store error", referencesErr: errors.New("boom"), wantStatus: gohttp.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStore := &storemock.StoreMock{
+				GetInterestSetsFunc: func() ([]model.InterestSet, error) {
+					return sets, nil
+				},
+				GetReferenceRatesFunc: func(series model.ReferenceSeries) ([]model.ReferenceRate, error) {
+					return references[series], tt.referencesErr
+				},
+			}
+			srv := NewServer(mockStore, calc.DefaultRules(), "", zap.NewNop())
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/spreads"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != gohttp.StatusOK {
+				return
+			}
+
+			var report calc.SpreadReport
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if report.Reference.Rate != tt.wantReference {
+				t.Errorf("reference rate = %s, want %s", report.Reference.Rate, tt.wantReference)
+			}
+			got := map[model.Bank]float64{}
+			for _, spread := range report.Spreads {
+				got[spread.Bank] = spread.SpreadBps
+			}
+			if !reflect.DeepEqual(got, tt.wantSpreadsBps) {
+				t.Errorf("spreads = %v, want %v", got, tt.wantSpreadsBps)
+			}
+		})
+	}
+}
+
+func TestServer_handleBenchmark(t *testing.T) {
+	t.Parallel()
+
+	avg := func(bank model.Bank, month time.Month, percent float64) model.InterestSet {
+		return model.InterestSet{
+			Bank: bank, Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(percent),
+			AverageReferenceMonth: &model.AvgMonth{Year: 2025, Month: month},
+		}
+	}
+	sets := []model.InterestSet{
+		avg(model.BenchmarkBank, time.August, 2.9),
+		avg(model.BenchmarkBank, time.September, 2.83),
+		avg("SEB", time.August, 2.95),
+		avg("SEB", time.September, 2.8),
+		avg("Nordea", time.September, 2.88),
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		storeErr   error
+		wantStatus int
+		wantMonths int
+		wantBody   string // only for csv
+	}{
+		{name: "3m by default, latest months", wantStatus: gohttp.StatusOK, wantMonths: 2},
+		{name: "limited months", query: "?months=1", wantStatus: gohttp.StatusOK, wantMonths: 1},
+		{
+			name:       "csv export",
+			query:      "?term=3m&months=1&format=csv",
+			wantStatus: gohttp.StatusOK,
+			wantBody: "month,term,bank,lender,segment,averageRate,marketTerm,marketRate,diffBps\n" +
+				"2025-09,3m,SEB,,,2.8,3m,2.83,-3\n" +
+				"2025-09,3m,Nordea,,,2.88,3m,2.83,5\n",
+		},
+		{name: "no market average for term", query: "?term=5y", wantStatus: gohttp.StatusNotFound},
+		{name: "invalid term", query: "?term=forever", wantStatus: gohttp.StatusBadRequest},
+		{name: "invalid months", query: "?months=0", wantStatus: gohttp.StatusBadRequest},
+		{name: "unknown format", query: "?format=xml", wantStatus: gohttp.StatusBadRequest},
+		{name: "store error", storeErr: errors.New("boom"), wantStatus: gohttp.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newTestServer(sets, tt.storeErr)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(gohttp.MethodGet, "/benchmark"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != gohttp.StatusOK {
+				return
+			}
+
+			if tt.wantBody != "" {
+				if got := rec.Body.String(); got != tt.wantBody {
+					t.Errorf("body = %q, want %q", got, tt.wantBody)
+				}
+				return
+			}
+			var report calc.BenchmarkReport
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(report.Months) != tt.wantMonths {
+				t.Errorf("months = %d, want %d", len(report.Months), tt.wantMonths)
+			}
+			if report.Months[0].Month.Month != time.September || report.Months[0].Market != model.RateFromPercent(2.83) {
+				t.Errorf("latest month = %+v, want September 2025 with market 2.83", report.Months[0])
+			}
+		})
+	}
+}
+
+func TestServer_Reviews_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	st := store.NewMemoryStore(nil, zap.NewNop())
+	approved := model.InterestSet{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.5)}
+	rejected := model.InterestSet{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(9.9)}
+	for _, set := range []model.InterestSet{approved, rejected} {
+		if err := st.AddPendingReview(model.PendingReview{ID: set.Key(), Set: set, Reasons: []string{"jump"}}); err != nil {
+			t.Fatalf("AddPendingReview() error = %v", err)
+		}
+	}
+	handler := NewServer(st, calc.DefaultRules(), testAdminToken, zap.NewNop()).Handler()
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(gohttp.MethodGet, "/reviews")
+	var reviews []model.PendingReview
+	if err := json.NewDecoder(rec.Body).Decode(&reviews); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rec.Code != gohttp.StatusOK || len(reviews) != 2 {
+		t.Fatalf("GET /reviews = %d with %d reviews, want 200 with 2", rec.Code, len(reviews))
+	}
+
+	if rec := do(gohttp.MethodPost, "/reviews/"+url.PathEscape(approved.Key())+"/approve"); rec.Code != gohttp.StatusNoContent {
+		t.Errorf("approve status = %d, want %d (body: %s)", rec.Code, gohttp.StatusNoContent, rec.Body.String())
+	}
+	if rec := do(gohttp.MethodPost, "/reviews/"+url.PathEscape(rejected.Key())+"/reject"); rec.Code != gohttp.StatusNoContent {
+		t.Errorf("reject status = %d, want %d (body: %s)", rec.Code, gohttp.StatusNoContent, rec.Body.String())
+	}
+	if rec := do(gohttp.MethodPost, "/reviews/"+url.PathEscape(rejected.Key())+"/approve"); rec.Code != gohttp.StatusNotFound {
+		t.Errorf("status for resolved review = %d, want %d", rec.Code, gohttp.StatusNotFound)
+	}
+
+	pending, _ := st.GetPendingReviews()
+	sets, _ := st.GetInterestSets()
+	if len(pending) != 0 || len(sets) != 1 || sets[0].Key() != approved.Key() {
+		t.Errorf("after resolving: pending = %v, stored = %v; want none pending and only the approved rate stored", pending, sets)
+	}
+}
+
+func TestServer_Reviews_RequireAdminToken(t *testing.T) {
+	t.Parallel()
+
+	set := model.InterestSet{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.5)}
+	path := "/reviews/" + url.PathEscape(set.Key()) + "/approve"
+
+	tests := []struct {
+		name          string
+		serverToken   string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "no token sent", serverToken: testAdminToken, wantStatus: gohttp.StatusUnauthorized},
+		{name: "wrong token", serverToken: testAdminToken, authorization: "Bearer wrong", wantStatus: gohttp.StatusUnauthorized},
+		{name: "token without bearer scheme", serverToken: testAdminToken, authorization: testAdminToken, wantStatus: gohttp.StatusUnauthorized},
+		{name: "no token configured", authorization: "Bearer ", wantStatus: gohttp.StatusForbidden},
+		{name: "valid token", serverToken: testAdminToken, authorization: "Bearer " + testAdminToken, wantStatus: gohttp.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			st := store.NewMemoryStore(nil, zap.NewNop())
+			if err := st.AddPendingReview(model.PendingReview{ID: set.Key(), Set: set, Reasons: []string{"jump"}}); err != nil {
+				t.Fatalf("AddPendingReview() error = %v", err)
+			}
+			req := httptest.NewRequest(gohttp.MethodPost, path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			NewServer(st, calc.DefaultRules(), tt.serverToken, zap.NewNop()).Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			pending, _ := st.GetPendingReviews()
+			if wantPending := tt.wantStatus != gohttp.StatusNoContent; (len(pending) == 1) != wantPending {
+				t.Errorf("pending reviews = %d, want the review resolved only with a valid token", len(pending))
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package alandsbanken
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,43 +19,34 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &AlandsbankCrawler{}
+	_ crawler.SiteCrawler    = &AlandsbankCrawler{}
+	_ crawler.Fingerprinter  = &AlandsbankCrawler{}
+	_ crawler.DocumentParser = &AlandsbankCrawler{}
 
 	// Ålandsbanken date format in list rates: "2025.10.03".
 	alandsbankListDateRegex = regexp.MustCompile(`^\d{4}\.\d{2}\.\d{2}$`)
 )
 
-// alandsbankSwedishMonthToTime converts Swedish month names to time.Month.
-func alandsbankSwedishMonthToTime(monthName string) (time.Month, bool) {
-	months := map[string]time.Month{
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-	}
-	m, ok := months[strings.ToLower(monthName)]
-	return m, ok
-}
-
 type AlandsbankCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewAlandsbankCrawler(httpClient http.Client, logger *zap.Logger) *AlandsbankCrawler {
-	return &AlandsbankCrawler{httpClient: httpClient, logger: logger}
+	return &AlandsbankCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(alandsbankBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *AlandsbankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *AlandsbankCrawler) Crawl(channel chan<- model.InterestSet) {
-	interestSets := []model.InterestSet{}
 	crawlTime := time.Now().UTC()
 
 	// Fetch rates page (contains both list and average rates)
@@ -66,6 +56,25 @@ func (c *AlandsbankCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	interestSets := c.parseRatesPage(html, crawlTime)
+	crawler.SetSource(interestSets, alandsbankRatesURL, []byte(html))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates page again.
+func (c *AlandsbankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != alandsbankRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseRatesPage(string(content), fetchedAt), nil
+}
+
+// parseRatesPage extracts the list and average rates from the rates page.
+func (c *AlandsbankCrawler) parseRatesPage(html string, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
+
 	// Extract list rates
 	listRates, err := c.extractListRates(html, crawlTime)
 	if err != nil {
@@ -82,9 +91,7 @@ func (c *AlandsbankCrawler) Crawl(channel chan<- model.InterestSet) {
 		interestSets = append(interestSets, avgRates...)
 	}
 
-	for _, set := range interestSets {
-		channel <- set
-	}
+	return interestSets
 }
 
 func (c *AlandsbankCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
@@ -98,6 +105,7 @@ func (c *AlandsbankCrawler) extractListRates(rawHTML string, crawlTime time.Time
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse list rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(alandsbankRatesURL, "list rates", table.Header)
 
 	// Table structure: Bindningstid | Räntesats % | Senaste ränteförändring | Förändring %
 	interestSets := []model.InterestSet{}
@@ -140,6 +148,7 @@ func (c *AlandsbankCrawler) extractListRates(rawHTML string, crawlTime time.Time
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
 	return interestSets, nil
 }
 
@@ -154,6 +163,7 @@ func (c *AlandsbankCrawler) extractAverageRates(rawHTML string, crawlTime time.T
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse average rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(alandsbankRatesURL, "average rates", table.Header)
 
 	// Table structure: Bindningstid | Genomsnittlig bolåneränta | Månad
 	// Note: Ålandsbanken only publishes average rates for 3 mån
@@ -191,25 +201,12 @@ func (c *AlandsbankCrawler) extractAverageRates(rawHTML string, crawlTime time.T
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
 	return interestSets, nil
 }
 
-func parseAlandsbankRate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.TrimSpace(str)
-
-	if str == "" || str == "-" {
-		return 0, fmt.Errorf("empty or invalid rate: %q", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseAlandsbankRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 func parseAlandsbankListDate(dateStr string) (time.Time, error) {
@@ -221,41 +218,15 @@ func parseAlandsbankListDate(dateStr string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("date %q does not match expected format 'YYYY.MM.DD'", dateStr)
 	}
 
-	parts := strings.Split(str, ".")
-	if len(parts) != 3 {
-		return time.Time{}, fmt.Errorf("date %q does not have expected format 'YYYY.MM.DD'", dateStr)
-	}
-
-	year, _ := strconv.Atoi(parts[0])
-	month, _ := strconv.Atoi(parts[1])
-	day, _ := strconv.Atoi(parts[2])
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(str)
 }
 
 func parseAlandsbankAvgMonth(monthStr string) (*model.AvgMonth, error) {
-	str := utils.NormalizeSpaces(monthStr)
-	str = strings.TrimSpace(str)
-
 	// Format: "Oktober 2025"
-	parts := strings.Split(str, " ")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("month %q does not match expected format 'Month YYYY'", monthStr)
-	}
-
-	monthName := parts[0]
-	year, err := strconv.Atoi(parts[1])
+	month, err := utils.ParseMonthYear(monthStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse year from %q: %w", monthStr, err)
-	}
-
-	month, ok := alandsbankSwedishMonthToTime(monthName)
-	if !ok {
-		return nil, fmt.Errorf("unknown Swedish month: %q", monthName)
+		return nil, err
 	}
 
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: month,
-	}, nil
+	return &month, nil
 }
@@ -23,7 +23,7 @@ func assertAlandsbankListRateFields(t *testing.T, r model.InterestSet, crawlTime
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -44,7 +44,7 @@ func assertAlandsbankAverageRateFields(t *testing.T, r model.InterestSet, crawlT
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
@@ -148,7 +148,7 @@ func TestAlandsbankCrawler_extractListRates(t *testing.T) {
 	}
 
 	// Validate specific known values from golden file
-	expectedRates := map[model.Term]float32{
+	expectedRates := map[model.Term]float64{
 		model.Term3months: 3.85,
 		model.Term1year:   3.45,
 		model.Term2years:  3.60,
@@ -165,8 +165,8 @@ func TestAlandsbankCrawler_extractListRates(t *testing.T) {
 			t.Errorf("unexpected term in results: %q", r.Term)
 			continue
 		}
-		if r.NominalRate != expectedRate {
-			t.Errorf("rate for term %q = %f, want %f", r.Term, r.NominalRate, expectedRate)
+		if r.NominalRate != model.RateFromPercent(expectedRate) {
+			t.Errorf("rate for term %q = %v, want %v", r.Term, r.NominalRate, expectedRate)
 		}
 	}
 
@@ -218,8 +218,8 @@ func validateOktoberRate(t *testing.T, rates []model.InterestSet) {
 		if r.AverageReferenceMonth != nil &&
 			r.AverageReferenceMonth.Year == 2025 &&
 			r.AverageReferenceMonth.Month == time.October {
-			if r.NominalRate != 2.59 {
-				t.Errorf("Oktober 2025 rate = %f, want 2.59", r.NominalRate)
+			if r.NominalRate != model.RateFromPercent(2.59) {
+				t.Errorf("Oktober 2025 rate = %v, want 2.59", r.NominalRate)
 			}
 			return
 		}
@@ -233,7 +233,7 @@ func TestParseAlandsbankRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -277,7 +277,7 @@ func TestParseAlandsbankRate(t *testing.T) {
 				t.Errorf("parseAlandsbankRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseAlandsbankRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -0,0 +1,192 @@
+package crawler
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// AnomalyConfig configures when a rate change between crawls is considered suspicious.
+type AnomalyConfig struct {
+	// MaxJumpBps is the largest change (in basis points) against the stored rate that is accepted without review.
+	MaxJumpBps float32
+	// MinPeersForDirection is the number of other banks that must all have moved the same term in the same
+	// direction before a bank moving the opposite way is considered suspicious.
+	MinPeersForDirection int
+}
+
+// Anomaly describes why a crawled InterestSet was held back for review.
+type Anomaly struct {
+	Set          model.InterestSet
+	PreviousRate *model.Rate
+	Reasons      []string
+}
+
+// AnomalyDetector compares freshly crawled rates with the stored history.
+type AnomalyDetector struct {
+	cfg AnomalyConfig
+}
+
+func DefaultAnomalyConfig() AnomalyConfig {
+	return AnomalyConfig{
+		MaxJumpBps:           75,
+		MinPeersForDirection: 3,
+	}
+}
+
+func NewAnomalyDetector(cfg AnomalyConfig) *AnomalyDetector {
+	return &AnomalyDetector{cfg: cfg}
+}
+
+// rateMove is the change of one crawled InterestSet against its stored predecessor.
+type rateMove struct {
+	set      model.InterestSet
+	previous model.Rate
+	delta    model.Rate
+}
+
+// Detect returns the anomalies among the crawled sets, keyed by review ID.
+func (d *AnomalyDetector) Detect(crawled, history []model.InterestSet) map[string]Anomaly {
+	anomalies := make(map[string]Anomaly)
+	addReason := func(set model.InterestSet, previous model.Rate, reason string) {
+		id := ReviewID(set)
+		a, ok := anomalies[id]
+		if !ok {
+			a = Anomaly{Set: set, PreviousRate: &previous}
+		}
+		a.Reasons = append(a.Reasons, reason)
+		anomalies[id] = a
+	}
+
+	moves := make([]rateMove, 0, len(crawled))
+	for _, set := range crawled {
+		previous, ok := findPrevious(set, history)
+		if !ok {
+			continue
+		}
+		moves = append(moves, rateMove{
+			set:      set,
+			previous: previous.NominalRate,
+			delta:    set.NominalRate - previous.NominalRate,
+		})
+	}
+
+	maxJump := model.RateFromBasisPoints(float64(d.cfg.MaxJumpBps))
+	for _, m := range moves {
+		if m.delta.Abs() > maxJump {
+			addReason(m.set, m.previous, fmt.Sprintf("rate moved %+.0f bps from %s%% (max %.0f bps)",
+				m.delta.BasisPoints(), m.previous, d.cfg.MaxJumpBps))
+		}
+	}
+
+	for _, m := range d.contrarianMoves(moves) {
+		addReason(m.set, m.previous, fmt.Sprintf("rate moved %+.0f bps while all other banks moved %s %s the opposite way",
+			m.delta.BasisPoints(), m.set.Type, m.set.Term))
+	}
+
+	return anomalies
+}
+
+// contrarianMoves returns list rate moves that go against the direction every other bank moved the same term in.
+func (d *AnomalyDetector) contrarianMoves(moves []rateMove) []rateMove {
+	type termKey struct {
+		typ  model.Type
+		term model.Term
+	}
+	byTerm := make(map[termKey][]rateMove)
+	for _, m := range moves {
+		if m.set.Type != model.TypeListRate || m.delta == 0 {
+			continue
+		}
+		key := termKey{typ: m.set.Type, term: m.set.Term}
+		byTerm[key] = append(byTerm[key], m)
+	}
+
+	var contrarian []rateMove
+	for _, termMoves := range byTerm {
+		for i, m := range termMoves {
+			ups, downs := 0, 0
+			for j, peer := range termMoves {
+				if i == j || peer.set.Bank == m.set.Bank {
+					continue
+				}
+				if peer.delta > 0 {
+					ups++
+				} else {
+					downs++
+				}
+			}
+
+			if m.delta > 0 && ups == 0 && downs >= d.cfg.MinPeersForDirection {
+				contrarian = append(contrarian, m)
+			}
+			if m.delta < 0 && downs == 0 && ups >= d.cfg.MinPeersForDirection {
+				contrarian = append(contrarian, m)
+			}
+		}
+	}
+
+	return contrarian
+}
+
+// findPrevious returns the stored InterestSet a crawled set should be compared against. For average rates without a
+// stored value for the same month, the previous month is used.
+func findPrevious(set model.InterestSet, history []model.InterestSet) (model.InterestSet, bool) {
+	var prevMonth *model.AvgMonth
+	if set.Type == model.TypeAverageRate && set.AverageReferenceMonth != nil {
+		m := previousAvgMonth(*set.AverageReferenceMonth)
+		prevMonth = &m
+	}
+
+	var fallback *model.InterestSet
+	for i, h := range history {
+		if h.Bank != set.Bank || h.Lender != set.Lender || h.Type != set.Type || h.Term != set.Term || !sameBoundaries(h, set) {
+			continue
+		}
+		if set.Type != model.TypeAverageRate {
+			return h, true
+		}
+		if h.AverageReferenceMonth == nil || set.AverageReferenceMonth == nil {
+			continue
+		}
+		if *h.AverageReferenceMonth == *set.AverageReferenceMonth {
+			return h, true
+		}
+		if prevMonth != nil && *h.AverageReferenceMonth == *prevMonth {
+			fallback = &history[i]
+		}
+	}
+
+	if fallback != nil {
+		return *fallback, true
+	}
+	return model.InterestSet{}, false
+}
+
+func sameBoundaries(a, b model.InterestSet) bool {
+	return equalPtr(a.RatioDiscountBoundaries, b.RatioDiscountBoundaries) && equalPtr(a.LoanAmountBoundaries, b.LoanAmountBoundaries) &&
+		a.MaxEnergyClass == b.MaxEnergyClass && slices.Equal(a.UnionOrganisations, b.UnionOrganisations) &&
+		equalPtr(a.AverageSegment, b.AverageSegment)
+}
+
+// equalPtr reports whether both pointers are nil or point to equal values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func previousAvgMonth(m model.AvgMonth) model.AvgMonth {
+	if m.Month == time.January {
+		return model.AvgMonth{Month: time.December, Year: m.Year - 1}
+	}
+	return model.AvgMonth{Month: m.Month - 1, Year: m.Year}
+}
+
+// ReviewID returns a stable identifier for an InterestSet, so that re-crawling a held back rate replaces its review.
+func ReviewID(set model.InterestSet) string {
+	return set.Key()
+}
@@ -0,0 +1,169 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func listRate(bank model.Bank, term model.Term, rate float64) model.InterestSet {
+	return model.InterestSet{Bank: bank, Type: model.TypeListRate, Term: term, NominalRate: model.RateFromPercent(rate)}
+}
+
+func avgRate(bank model.Bank, term model.Term, rate float64, month time.Month, year uint) model.InterestSet {
+	return model.InterestSet{
+		Bank: bank, Type: model.TypeAverageRate, Term: term, NominalRate: model.RateFromPercent(rate),
+		AverageReferenceMonth: &model.AvgMonth{Month: month, Year: year},
+	}
+}
+
+func segmentedAvgRate(bank model.Bank, label string, term model.Term, rate float64, month time.Month, year uint) model.InterestSet {
+	set := avgRate(bank, term, rate, month, year)
+	set.AverageSegment = &model.AverageSegment{Label: label}
+	return set
+}
+
+func TestAnomalyDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		crawled []model.InterestSet
+		history []model.InterestSet
+		wantIDs []string
+	}{
+		{
+			name:    "no history means nothing to compare",
+			crawled: []model.InterestSet{listRate("SEB", model.Term3months, 3.5)},
+			history: nil,
+			wantIDs: nil,
+		},
+		{
+			name:    "small move is accepted",
+			crawled: []model.InterestSet{listRate("SEB", model.Term3months, 3.55)},
+			history: []model.InterestSet{listRate("SEB", model.Term3months, 3.5)},
+			wantIDs: nil,
+		},
+		{
+			name:    "jump above threshold is flagged",
+			crawled: []model.InterestSet{listRate("SEB", model.Term3months, 4.5)},
+			history: []model.InterestSet{listRate("SEB", model.Term3months, 3.5)},
+			wantIDs: []string{"SEB|listRate|3m"},
+		},
+		{
+			name:    "drop above threshold is flagged",
+			crawled: []model.InterestSet{listRate("SEB", model.Term3months, 2.5)},
+			history: []model.InterestSet{listRate("SEB", model.Term3months, 3.5)},
+			wantIDs: []string{"SEB|listRate|3m"},
+		},
+		{
+			name:    "average rate compared against previous month",
+			crawled: []model.InterestSet{avgRate("SEB", model.Term1year, 4.1, time.January, 2025)},
+			history: []model.InterestSet{avgRate("SEB", model.Term1year, 3.0, time.December, 2024)},
+			wantIDs: []string{"SEB|averageRate|1y|2025-01"},
+		},
+		{
+			name:    "average rate compared against same month when re-crawled",
+			crawled: []model.InterestSet{avgRate("SEB", model.Term1year, 3.05, time.January, 2025)},
+			history: []model.InterestSet{
+				avgRate("SEB", model.Term1year, 1.0, time.December, 2024),
+				avgRate("SEB", model.Term1year, 3.0, time.January, 2025),
+			},
+			wantIDs: nil,
+		},
+		{
+			name:    "average rate series are only compared with themselves",
+			crawled: []model.InterestSet{segmentedAvgRate("Bluestep", "Seniorlån", model.Term1year, 7.4, time.January, 2025)},
+			history: []model.InterestSet{
+				segmentedAvgRate("Bluestep", "Bolån", model.Term1year, 6.1, time.January, 2025),
+				segmentedAvgRate("Bluestep", "Seniorlån", model.Term1year, 7.3, time.January, 2025),
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "move opposite to all other banks is flagged",
+			crawled: []model.InterestSet{
+				listRate("SEB", model.Term3months, 3.3),
+				listRate("Nordea", model.Term3months, 3.3),
+				listRate("Swedbank", model.Term3months, 3.3),
+				listRate("SBAB", model.Term3months, 3.6),
+			},
+			history: []model.InterestSet{
+				listRate("SEB", model.Term3months, 3.5),
+				listRate("Nordea", model.Term3months, 3.5),
+				listRate("Swedbank", model.Term3months, 3.5),
+				listRate("SBAB", model.Term3months, 3.5),
+			},
+			wantIDs: []string{"SBAB|listRate|3m"},
+		},
+		{
+			name: "opposite move with too few peers is accepted",
+			crawled: []model.InterestSet{
+				listRate("SEB", model.Term3months, 3.3),
+				listRate("Nordea", model.Term3months, 3.3),
+				listRate("SBAB", model.Term3months, 3.6),
+			},
+			history: []model.InterestSet{
+				listRate("SEB", model.Term3months, 3.5),
+				listRate("Nordea", model.Term3months, 3.5),
+				listRate("SBAB", model.Term3months, 3.5),
+			},
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewAnomalyDetector(DefaultAnomalyConfig())
+			got := d.Detect(tt.crawled, tt.history)
+
+			if len(got) != len(tt.wantIDs) {
+				t.Errorf("Detect() returned %d anomalies, want %d: %v", len(got), len(tt.wantIDs), got)
+			}
+			for _, id := range tt.wantIDs {
+				a, ok := got[id]
+				if !ok {
+					t.Errorf("missing anomaly %q", id)
+					continue
+				}
+				if len(a.Reasons) == 0 {
+					t.Errorf("anomaly %q has no reasons", id)
+				}
+				if a.PreviousRate == nil {
+					t.Errorf("anomaly %q has no previous rate", id)
+				}
+			}
+		})
+	}
+}
+
+func TestReviewID(t *testing.T) {
+	t.Parallel()
+
+	tier := listRate("Landshypotek", model.Term1year, 3.2)
+	tier.Type = model.TypeRatioDiscounted
+	tier.RatioDiscountBoundaries = &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6}
+
+	tests := []struct {
+		name string
+		set  model.InterestSet
+		want string
+	}{
+		{name: "list rate", set: listRate("SEB", model.Term3months, 3.5), want: "SEB|listRate|3m"},
+		{name: "average rate", set: avgRate("SEB", model.Term1year, 3.5, time.March, 2025), want: "SEB|averageRate|1y|2025-03"},
+		{name: "ratio discounted rate", set: tier, want: "Landshypotek|ratioDiscountedRate|1y|0.00-0.60"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ReviewID(tt.set); got != tt.want {
+				t.Errorf("ReviewID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
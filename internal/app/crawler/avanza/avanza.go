@@ -3,21 +3,31 @@ package avanza
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
 const (
 	avanzaBankName        model.Bank = "Avanza"
+	stabeloBankName       model.Bank = "Stabelo"
+	landshypotekBankName  model.Bank = "Landshypotek"
 	avanzaStabeloRatesURL string     = "https://www.avanza.se/_api/external-mortgage-stabelo/interest-table"
 	avanzaLHBRatesURL     string     = "https://www.avanza.se/_api/external-mortgage-lhb/interest-table"
 )
 
-var _ crawler.SiteCrawler = &AvanzaCrawler{}
+var (
+	_ crawler.SiteCrawler    = &AvanzaCrawler{}
+	_ crawler.Fingerprinter  = &AvanzaCrawler{}
+	_ crawler.DocumentParser = &AvanzaCrawler{}
+
+	avanzaBindingPeriodRegex = regexp.MustCompile(`^([A-Z]+)_(MONTHS?|YEARS?)$`)
+)
 
 // AvanzaCrawler crawls Avanza's mortgage rate APIs.
 // Avanza offers mortgages via two partners: Stabelo and Landshypotek (LHB).
@@ -25,8 +35,9 @@ var _ crawler.SiteCrawler = &AvanzaCrawler{}
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type AvanzaCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 // avanzaRatesResponse represents the JSON response from Avanza's rate APIs.
@@ -41,25 +52,34 @@ type avanzaRateRow struct {
 }
 
 type avanzaInterestRate struct {
-	BindingPeriod string  `json:"bindingPeriod"` // THREE_MONTHS, ONE_YEAR, etc.
-	Effective     float64 `json:"effective"`
-	Nominal       float64 `json:"nominal"`
+	BindingPeriod string     `json:"bindingPeriod"` // THREE_MONTHS, ONE_YEAR, etc.
+	Effective     float64    `json:"effective"`
+	Nominal       model.Rate `json:"nominal"`
 }
 
 func NewAvanzaCrawler(httpClient http.Client, logger *zap.Logger) *AvanzaCrawler {
-	return &AvanzaCrawler{httpClient: httpClient, logger: logger}
+	return &AvanzaCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(avanzaBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *AvanzaCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *AvanzaCrawler) Crawl(channel chan<- model.InterestSet) {
 	crawlTime := time.Now().UTC()
 
 	// Fetch rates from both partners
-	stabeloRates, err := c.fetchRates(avanzaStabeloRatesURL, "Stabelo", crawlTime)
+	stabeloRates, err := c.fetchRates(avanzaStabeloRatesURL, stabeloBankName, crawlTime)
 	if err != nil {
 		c.logger.Error("failed fetching Avanza Stabelo rates", zap.Error(err))
 	}
 
-	lhbRates, err := c.fetchRates(avanzaLHBRatesURL, "Landshypotek", crawlTime)
+	lhbRates, err := c.fetchRates(avanzaLHBRatesURL, landshypotekBankName, crawlTime)
 	if err != nil {
 		c.logger.Error("failed fetching Avanza Landshypotek rates", zap.Error(err))
 	}
@@ -69,16 +89,41 @@ func (c *AvanzaCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
-func (c *AvanzaCrawler) fetchRates(url, partner string, crawlTime time.Time) ([]model.InterestSet, error) {
+func (c *AvanzaCrawler) fetchRates(url string, partner model.Bank, crawlTime time.Time) ([]model.InterestSet, error) {
 	rawJSON, err := c.httpClient.Fetch(url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading Avanza %s rates API: %w", partner, err)
 	}
 
+	interestSets, err := c.parseRates(url, partner, rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, url, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// ParseDocument parses an archived copy of one of the partner rate APIs again.
+func (c *AvanzaCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case avanzaStabeloRatesURL:
+		return c.parseRates(url, stabeloBankName, string(content), fetchedAt)
+	case avanzaLHBRatesURL:
+		return c.parseRates(url, landshypotekBankName, string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
+// parseRates parses the list rates of one partner from its rate API response.
+func (c *AvanzaCrawler) parseRates(url string, partner model.Bank, rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(url, string(partner)+" rates", rawJSON)
+
 	var response avanzaRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling Avanza rates",
-			zap.String("partner", partner),
+			zap.String("partner", string(partner)),
 			zap.Error(err),
 			zap.String("rawJSON", rawJSON))
 		return nil, fmt.Errorf("failed unmarshalling Avanza %s rates: %w", partner, err)
@@ -88,7 +133,7 @@ func (c *AvanzaCrawler) fetchRates(url, partner string, crawlTime time.Time) ([]
 	// This represents the "list rate" - the worst-case rate without volume/LTV discounts
 	baseRow := c.findBaseRateRow(response.Rows)
 	if baseRow == nil {
-		c.logger.Warn("no base rate row found for Avanza partner", zap.String("partner", partner))
+		c.logger.Warn("no base rate row found for Avanza partner", zap.String("partner", string(partner)))
 		return nil, nil
 	}
 
@@ -97,7 +142,7 @@ func (c *AvanzaCrawler) fetchRates(url, partner string, crawlTime time.Time) ([]
 		term, err := parseAvanzaBindingPeriod(rate.BindingPeriod)
 		if err != nil {
 			c.logger.Warn("Avanza binding period not supported - skipping",
-				zap.String("partner", partner),
+				zap.String("partner", string(partner)),
 				zap.String("bindingPeriod", rate.BindingPeriod),
 				zap.Error(err))
 			continue
@@ -107,8 +152,9 @@ func (c *AvanzaCrawler) fetchRates(url, partner string, crawlTime time.Time) ([]
 			Bank:          avanzaBankName,
 			Type:          model.TypeListRate,
 			Term:          term,
-			NominalRate:   float32(rate.Nominal),
+			NominalRate:   rate.Nominal,
 			LastCrawledAt: crawlTime,
+			Lender:        partner,
 
 			ChangedOn:               nil, // Avanza API doesn't provide change dates
 			RatioDiscountBoundaries: nil,
@@ -117,6 +163,7 @@ func (c *AvanzaCrawler) fetchRates(url, partner string, crawlTime time.Time) ([]
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseRates")
 	return interestSets, nil
 }
 
@@ -131,24 +178,16 @@ func (c *AvanzaCrawler) findBaseRateRow(rows []avanzaRateRow) *avanzaRateRow {
 	return nil
 }
 
-// parseAvanzaBindingPeriod converts Avanza's binding period strings to model.Term.
+// parseAvanzaBindingPeriod converts Avanza's binding period strings like "THREE_MONTHS" or "TEN_YEARS" to model.Term.
 func parseAvanzaBindingPeriod(period string) (model.Term, error) {
-	switch period {
-	case "THREE_MONTHS":
-		return model.Term3months, nil
-	case "ONE_YEAR":
-		return model.Term1year, nil
-	case "TWO_YEARS":
-		return model.Term2years, nil
-	case "THREE_YEARS":
-		return model.Term3years, nil
-	case "FOUR_YEARS":
-		return model.Term4years, nil
-	case "FIVE_YEARS":
-		return model.Term5years, nil
-	case "TEN_YEARS":
-		return model.Term10years, nil
-	default:
-		return "", fmt.Errorf("unsupported binding period: %s", period)
+	matches := avanzaBindingPeriodRegex.FindStringSubmatch(period)
+	if matches == nil {
+		return model.Term{}, fmt.Errorf("unsupported binding period: %s", period)
+	}
+
+	count, err := utils.ParseNumberWord(matches[1])
+	if err != nil {
+		return model.Term{}, fmt.Errorf("unsupported binding period %s: %w", period, err)
 	}
+	return utils.TermFromCount(count, matches[2]) //nolint:wrapcheck // utils error names the term
 }
@@ -147,7 +147,7 @@ func TestAvanzaCrawler_fetchRates(t *testing.T) {
 
 	crawler := &AvanzaCrawler{httpClient: mockClient, logger: logger}
 
-	results, err := crawler.fetchRates(avanzaStabeloRatesURL, "Stabelo", crawlTime)
+	results, err := crawler.fetchRates(avanzaStabeloRatesURL, stabeloBankName, crawlTime)
 	if err != nil {
 		t.Fatalf("fetchRates() error = %v", err)
 	}
@@ -175,6 +175,9 @@ func TestAvanzaCrawler_fetchRates(t *testing.T) {
 			expectedTerms[r.Term] = true
 		}
 		assertAvanzaListRateFields(t, r, crawlTime)
+		if r.Lender != stabeloBankName {
+			t.Errorf("Lender = %q, want %q", r.Lender, stabeloBankName)
+		}
 	}
 
 	for term, found := range expectedTerms {
@@ -199,7 +202,7 @@ func TestAvanzaCrawler_fetchRates_LHB(t *testing.T) {
 
 	crawler := &AvanzaCrawler{httpClient: mockClient, logger: logger}
 
-	results, err := crawler.fetchRates(avanzaLHBRatesURL, "Landshypotek", crawlTime)
+	results, err := crawler.fetchRates(avanzaLHBRatesURL, landshypotekBankName, crawlTime)
 	if err != nil {
 		t.Fatalf("fetchRates() error = %v", err)
 	}
@@ -227,6 +230,9 @@ func TestAvanzaCrawler_fetchRates_LHB(t *testing.T) {
 			expectedTerms[r.Term] = true
 		}
 		assertAvanzaListRateFields(t, r, crawlTime)
+		if r.Lender != landshypotekBankName {
+			t.Errorf("Lender = %q, want %q", r.Lender, landshypotekBankName)
+		}
 	}
 
 	for term, found := range expectedTerms {
@@ -288,9 +294,10 @@ func TestParseAvanzaBindingPeriod(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "unsupported period",
+			name:    "7 years",
 			period:  "SEVEN_YEARS",
-			wantErr: true,
+			want:    model.Term7years,
+			wantErr: false,
 		},
 		{
 			name:    "empty string",
@@ -433,7 +440,7 @@ func assertAvanzaListRateFields(t *testing.T, r model.InterestSet, crawlTime tim
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	// Avanza API doesn't provide change dates
 	if r.ChangedOn != nil {
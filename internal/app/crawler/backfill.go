@@ -0,0 +1,145 @@
+package crawler
+
+import (
+	"fmt"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// BackfillReport summarizes the outcome of a backfill run.
+type BackfillReport struct {
+	// Received counts the average rates received. List rates are ignored.
+	Received uint
+	// Duplicates counts the average rates replaced by a later one for the same product and reference month.
+	Duplicates uint
+	Rejected   uint
+	Stored     uint
+	Violations []Violation
+	Banks      map[model.Bank]BankHistory
+}
+
+// BankHistory describes the stored average rate history of one bank.
+type BankHistory struct {
+	Rates    uint
+	Earliest model.AvgMonth
+	Latest   model.AvgMonth
+}
+
+// Backfiller loads the complete average rate history every bank publishes into the store.
+//
+// Unlike Service it skips list rates and anomaly detection: the history is the bank's own record, so large moves from
+// one month to the next are real and must not be held back for review.
+type Backfiller struct {
+	store     store.Store
+	crawlers  []SiteCrawler
+	validator *Validator
+	logger    *zap.Logger
+}
+
+func NewBackfiller(s store.Store, crawlers []SiteCrawler, validator *Validator, logger *zap.Logger) *Backfiller {
+	return &Backfiller{
+		store:     s,
+		crawlers:  crawlers,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (b *Backfiller) Backfill() BackfillReport {
+	report := BackfillReport{Banks: map[model.Bank]BankHistory{}}
+
+	var received []model.InterestSet
+	runCrawlers(b.crawlers, func(c <-chan model.InterestSet) {
+		for set := range c {
+			if set.Type != model.TypeAverageRate {
+				continue
+			}
+			report.Received++
+			received = append(received, set)
+		}
+	})
+
+	history := dedupByReferenceMonth(received)
+	report.Duplicates = uint(len(received) - len(history)) //nolint:gosec // dedup never grows the slice
+
+	for _, set := range history {
+		violations := b.validator.Validate(set)
+		report.Violations = append(report.Violations, violations...)
+		if HasRejection(violations) {
+			for _, v := range violations {
+				b.logger.Warn("implausible historic interestSet",
+					zap.String("id", set.Key()),
+					zap.String("rule", string(v.Rule)),
+					zap.String("message", v.Message),
+				)
+			}
+			report.Rejected++
+			continue
+		}
+
+		if err := b.store.UpsertInterestSet(set); err != nil {
+			b.logger.Error("failed to upsert historic interestSet", zap.String("id", set.Key()), zap.Error(err))
+			continue
+		}
+		report.Stored++
+		report.Banks[set.Bank] = extendHistory(report.Banks[set.Bank], *set.AverageReferenceMonth)
+	}
+
+	for bank, h := range report.Banks {
+		b.logger.Info("backfill results",
+			zap.String("bank", string(bank)),
+			zap.Uint("avgRates", h.Rates),
+			zap.String("from", formatAvgMonth(h.Earliest)),
+			zap.String("to", formatAvgMonth(h.Latest)),
+		)
+	}
+	b.logger.Info("backfill finished",
+		zap.Uint("received", report.Received),
+		zap.Uint("duplicates", report.Duplicates),
+		zap.Uint("rejected", report.Rejected),
+		zap.Uint("stored", report.Stored),
+	)
+
+	return report
+}
+
+// dedupByReferenceMonth keeps one average rate per product and reference month. The last one received wins, which
+// for sources that list every rate change in chronological order (like Nordea) is the rate at the end of the month.
+func dedupByReferenceMonth(sets []model.InterestSet) []model.InterestSet {
+	index := map[string]int{}
+	result := []model.InterestSet{}
+	for _, set := range sets {
+		key := set.Key()
+		if i, ok := index[key]; ok {
+			result[i] = set
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, set)
+	}
+	return result
+}
+
+func extendHistory(h BankHistory, month model.AvgMonth) BankHistory {
+	if h.Rates == 0 || monthBefore(month, h.Earliest) {
+		h.Earliest = month
+	}
+	if h.Rates == 0 || monthBefore(h.Latest, month) {
+		h.Latest = month
+	}
+	h.Rates++
+	return h
+}
+
+func monthBefore(a, b model.AvgMonth) bool {
+	if a.Year != b.Year {
+		return a.Year < b.Year
+	}
+	return a.Month < b.Month
+}
+
+func formatAvgMonth(m model.AvgMonth) string {
+	return fmt.Sprintf("%d-%02d", m.Year, m.Month)
+}
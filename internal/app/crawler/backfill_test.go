@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+func TestBackfiller_Backfill(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.October, 15, 12, 0, 0, 0, time.UTC)
+	validator := NewValidator(model.BankProfiles(), HistoricRateRanges(), func() time.Time { return now })
+
+	crawlers := []SiteCrawler{
+		&staticCrawler{sets: []model.InterestSet{
+			listRate("Nordea", model.Term3months, 3.5),
+			// Nordea lists every rate change, so a month can occur several times.
+			avgRate("Nordea", model.Term2years, 15.75, time.March, 1990),
+			avgRate("Nordea", model.Term2years, 15.5, time.April, 1990),
+			avgRate("Nordea", model.Term2years, 15.25, time.April, 1990),
+			avgRate("Nordea", model.Term2years, 17, time.September, 1992),
+		}},
+		&staticCrawler{sets: []model.InterestSet{
+			avgRate("SEB", model.Term3months, 2.6, time.September, 2025),
+			avgRate("SEB", model.Term3months, 2.7, time.August, 2025),
+			avgRate("SEB", model.Term3months, 2.9, time.November, 2025), // in the future
+		}},
+	}
+
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	report := NewBackfiller(memStore, crawlers, validator, zap.NewNop()).Backfill()
+
+	if report.Received != 7 || report.Duplicates != 1 || report.Rejected != 1 || report.Stored != 5 {
+		t.Errorf("report = received %d, duplicates %d, rejected %d, stored %d; want 7, 1, 1, 5",
+			report.Received, report.Duplicates, report.Rejected, report.Stored)
+	}
+
+	nordea := report.Banks["Nordea"]
+	if nordea.Rates != 3 || nordea.Earliest != (model.AvgMonth{Month: time.March, Year: 1990}) ||
+		nordea.Latest != (model.AvgMonth{Month: time.September, Year: 1992}) {
+		t.Errorf("Nordea history = %+v, want 3 rates from 1990-03 to 1992-09", nordea)
+	}
+
+	sets, err := memStore.GetInterestSets()
+	if err != nil {
+		t.Fatalf("GetInterestSets() error = %v", err)
+	}
+	for _, set := range sets {
+		if set.Type != model.TypeAverageRate {
+			t.Errorf("stored %s, want only average rates", set.Key())
+		}
+		if set.Bank == "Nordea" && set.AverageReferenceMonth.Month == time.April && set.NominalRate != model.RateFromPercent(15.25) {
+			t.Errorf("Nordea April 1990 rate = %v, want the last change of the month 15.25", set.NominalRate)
+		}
+	}
+}
@@ -22,24 +22,34 @@ const (
 )
 
 var (
-	// bluestepRateRegex matches rates like "4,45%" or "5.68%".
-	bluestepRateRegex = regexp.MustCompile(`^(\d+[,.]?\d*)\s*%?$`)
-	// bluestepMonthRegex matches "YYYY MM" format like "2025 11".
-	bluestepMonthRegex = regexp.MustCompile(`^(\d{4})\s+(\d{1,2})$`)
 	// bluestepTermRegex extracts term from header like "Rörlig 3 månader" or "Fast 3 år".
 	bluestepTermRegex = regexp.MustCompile(`(\d+)\s*(mån|år)`)
 )
 
-var _ crawler.SiteCrawler = &BluestepCrawler{}
+var (
+	_ crawler.SiteCrawler    = &BluestepCrawler{}
+	_ crawler.Fingerprinter  = &BluestepCrawler{}
+	_ crawler.DocumentParser = &BluestepCrawler{}
+)
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type BluestepCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewBluestepCrawler(httpClient http.Client, logger *zap.Logger) *BluestepCrawler {
-	return &BluestepCrawler{httpClient: httpClient, logger: logger}
+	return &BluestepCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(bluestepBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *BluestepCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *BluestepCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -55,6 +65,7 @@ func (c *BluestepCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Bluestep list rates", zap.Error(err))
 		} else {
+			crawler.SetSource(listRates, bluestepListRatesURL, []byte(listHTML))
 			interestSets = append(interestSets, listRates...)
 		}
 	}
@@ -68,6 +79,7 @@ func (c *BluestepCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Bluestep average rates", zap.Error(err))
 		} else {
+			crawler.SetSource(avgRates, bluestepAvgRatesURL, []byte(avgHTML))
 			interestSets = append(interestSets, avgRates...)
 		}
 	}
@@ -77,77 +89,46 @@ func (c *BluestepCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates page again.
+func (c *BluestepCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case bluestepListRatesURL:
+		return c.extractListRates(string(content), fetchedAt)
+	case bluestepAvgRatesURL:
+		return c.extractAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 // extractListRates parses the list rates from Bluestep's HTML page.
 // The table structure has terms in the first row (as <td><strong>...</strong></td>)
 // and rates in the second row.
 func (c *BluestepCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	table, err := c.findListRatesTable(rawHTML)
-	if err != nil {
-		return nil, err
-	}
-
-	headerRow, rateRow, err := c.getHeaderAndRateRows(table)
-	if err != nil {
-		return nil, err
-	}
-
-	return c.buildListRateInterestSets(headerRow, rateRow, crawlTime), nil
-}
-
-// findListRatesTable locates and parses the list rates table from HTML.
-func (c *BluestepCrawler) findListRatesTable(rawHTML string) (*utils.Table, error) {
-	// Find the first table after "Bolån*" heading.
-	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Bolån*")
-	if err != nil {
-		// Fallback: try finding with HTML encoded text
-		tokenizer, err = utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Bol&aring;n*")
-		if err != nil {
-			return nil, fmt.Errorf("failed to find list rates table: %w", err)
-		}
-	}
-
-	table, err := utils.ParseTable(tokenizer)
+	// The heading is "Bolån*"; ExtractTable matches on decoded text, so "Bol&aring;n*" is found as well.
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate:  utils.TableLocator{TextBefore: "Bolån*"},
+		Columns: []utils.TableColumn{{Name: "rates", Match: utils.HeaderRegexp(bluestepTermRegex), Repeated: true}},
+	})
+	c.fingerprints.RecordTable(bluestepListRatesURL, "list rates", table.Header)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse list rates table: %w", err)
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
 	}
-
-	return &table, nil
-}
-
-// getHeaderAndRateRows extracts header and rate rows from the table.
-func (c *BluestepCrawler) getHeaderAndRateRows(table *utils.Table) ([]string, []string, error) {
-	// The table uses <tbody> without <thead>, so both term and rate rows are in Rows.
-	switch {
-	case len(table.Header) > 0 && len(table.Rows) > 0:
-		// Header from <th> tags, rates from first data row
-		return table.Header, table.Rows[0], nil
-	case len(table.Rows) >= 2:
-		// Both rows are in Rows (no <th> tags used)
-		return table.Rows[0], table.Rows[1], nil
-	default:
-		return nil, nil, fmt.Errorf("list rates table has insufficient rows: header=%d, rows=%d",
-			len(table.Header), len(table.Rows))
+	if len(table.Records) == 0 {
+		return nil, fmt.Errorf("list rates table has no rate row")
 	}
-}
 
-// buildListRateInterestSets creates InterestSet objects from header and rate rows.
-func (c *BluestepCrawler) buildListRateInterestSets(headerRow, rateRow []string, crawlTime time.Time) []model.InterestSet {
 	interestSets := []model.InterestSet{}
-
-	for i, termStr := range headerRow {
-		if i >= len(rateRow) {
-			break
-		}
-
-		term, err := c.parseBluestepTerm(termStr)
+	for _, cell := range table.Records[0].Cells("rates") {
+		term, err := c.parseBluestepTerm(cell.Header)
 		if err != nil {
-			c.logger.Warn("failed to parse term", zap.String("term", termStr), zap.Error(err))
+			c.logger.Warn("failed to parse term", zap.String("term", cell.Header), zap.Error(err))
 			continue
 		}
 
-		rate, err := c.parseBluestepRate(rateRow[i])
+		rate, err := c.parseBluestepRate(cell.Text)
 		if err != nil {
-			c.logger.Warn("failed to parse rate", zap.String("rate", rateRow[i]), zap.Error(err))
+			c.logger.Warn("failed to parse rate", zap.String("rate", cell.Text), zap.Error(err))
 			continue
 		}
 
@@ -165,48 +146,66 @@ func (c *BluestepCrawler) buildListRateInterestSets(headerRow, rateRow []string,
 		})
 	}
 
-	return interestSets
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
+	return interestSets, nil
 }
 
 // extractAverageRates parses the average rates from Bluestep's historical rates page.
+// The table has a header row with "Månad" and the terms, and data rows with month + rates. If Bluestep publishes
+// several series per risk class, loan-to-value or loan amount band, each has its own table and the rates of each get
+// its heading as segment. Tables with other headings are not told apart, only the first is parsed.
 func (c *BluestepCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	// The average rates table has a header row with terms and data rows with month + rates.
-	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Genomsnittsräntor")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find average rates table: %w", err)
+	tables, err := utils.ExtractTables(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Genomsnittsräntor"},
+		Columns: []utils.TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	headings := make([]string, len(tables))
+	for i, table := range tables {
+		c.fingerprints.RecordTable(bluestepAvgRatesURL, crawler.SeriesTableName("average rates", i), table.Header)
+		headings[i] = table.Heading
 	}
-
-	table, err := utils.ParseTable(tokenizer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse average rates table: %w", err)
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
 	}
 
-	// Parse header to get terms (skip first column which is "Månad")
-	terms := c.extractTermsFromHeader(table.Header)
-
+	labels := crawler.SeriesLabels(headings)
+	if labels == nil && len(tables) > 1 {
+		c.logger.Warn("average rate tables are not headed by a segment, parsing only the first", zap.Strings("headings", headings))
+		tables = tables[:1]
+	}
 	interestSets := []model.InterestSet{}
-	for _, row := range table.Rows {
-		if len(row) == 0 {
-			continue
+	for i, table := range tables {
+		sets := c.parseAverageTable(table, crawlTime)
+		if labels != nil {
+			crawler.SetAverageSegment(sets, labels[i])
 		}
+		interestSets = append(interestSets, sets...)
+	}
 
-		refMonth, err := c.parseBluestepMonth(row[0])
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
+	return interestSets, nil
+}
+
+// parseAverageTable parses the rates of one average rates table.
+func (c *BluestepCrawler) parseAverageTable(table utils.ExtractedTable, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		refMonth, err := c.parseBluestepMonth(record.Get("month"))
 		if err != nil {
-			c.logger.Warn("failed to parse month", zap.String("month", row[0]), zap.Error(err))
+			c.logger.Warn("failed to parse month", zap.String("month", record.Get("month")), zap.Error(err))
 			continue
 		}
 
-		for i, cell := range row {
-			if i == 0 {
-				continue // skip month column
-			}
-
-			term, ok := terms[i]
-			if !ok {
+		for _, cell := range record.Cells("rates") {
+			term, err := utils.ParseTerm(cell.Header)
+			if err != nil {
 				continue
 			}
 
-			rate, err := c.parseBluestepRate(cell)
+			rate, err := c.parseBluestepRate(cell.Text)
 			if err != nil {
 				continue // skip empty or invalid rates
 			}
@@ -225,24 +224,7 @@ func (c *BluestepCrawler) extractAverageRates(rawHTML string, crawlTime time.Tim
 			})
 		}
 	}
-
-	return interestSets, nil
-}
-
-// extractTermsFromHeader parses the header row to extract term mappings.
-// Returns a map of column index to Term.
-func (c *BluestepCrawler) extractTermsFromHeader(header []string) map[int]model.Term {
-	terms := make(map[int]model.Term)
-
-	for i, h := range header {
-		term, err := utils.ParseTerm(h)
-		if err != nil {
-			continue // skip non-term columns like "Månad"
-		}
-		terms[i] = term
-	}
-
-	return terms
+	return interestSets
 }
 
 // parseBluestepTerm parses a term string like "Rörlig 3 månader" or "Fast 3 år".
@@ -254,73 +236,23 @@ func (c *BluestepCrawler) parseBluestepTerm(termStr string) (model.Term, error)
 	// Extract the numeric part and unit using regex
 	matches := bluestepTermRegex.FindStringSubmatch(strings.ToLower(termStr))
 	if len(matches) != 3 {
-		return "", fmt.Errorf("failed to parse term: %s", termStr)
-	}
-
-	num := matches[1]
-	unit := matches[2]
-
-	// Reconstruct in standard format for ParseTerm
-	var standardTerm string
-	if unit == "mån" {
-		standardTerm = num + " mån"
-	} else {
-		standardTerm = num + " år"
+		return model.Term{}, fmt.Errorf("failed to parse term: %s", termStr)
 	}
 
-	term, err := utils.ParseTerm(standardTerm)
+	num, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return "", fmt.Errorf("failed to parse term %q: %w", standardTerm, err)
+		return model.Term{}, fmt.Errorf("failed to parse term %q: %w", termStr, err)
 	}
 
-	return term, nil
+	return utils.TermFromCount(num, matches[2]) //nolint:wrapcheck // utils error names the term
 }
 
 // parseBluestepRate parses a rate string like "4,45%" or "5.68%".
-func (c *BluestepCrawler) parseBluestepRate(rateStr string) (float32, error) {
-	rateStr = html.UnescapeString(rateStr)
-	rateStr = utils.NormalizeSpaces(rateStr)
-	rateStr = strings.ReplaceAll(rateStr, ",", ".") // Swedish decimal separator
-	rateStr = strings.ReplaceAll(rateStr, " ", "")
-
-	matches := bluestepRateRegex.FindStringSubmatch(rateStr)
-	if len(matches) != 2 {
-		return 0, fmt.Errorf("failed to match rate regex: %s", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(matches[1], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate: %w", err)
-	}
-
-	return float32(rate), nil
+func (c *BluestepCrawler) parseBluestepRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseBluestepMonth parses a month string like "2025 11" to AvgMonth.
 func (c *BluestepCrawler) parseBluestepMonth(monthStr string) (model.AvgMonth, error) {
-	monthStr = utils.NormalizeSpaces(monthStr)
-
-	matches := bluestepMonthRegex.FindStringSubmatch(monthStr)
-	if len(matches) != 3 {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %s", monthStr)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month number: %w", err)
-	}
-
-	if month < 1 || month > 12 {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %d", month)
-	}
-
-	return model.AvgMonth{
-		Month: time.Month(month),
-		Year:  uint(year),
-	}, nil
+	return utils.ParseMonthYear(monthStr)
 }
@@ -170,7 +170,7 @@ func TestBluestepCrawler_extractListRates(t *testing.T) {
 		}
 
 		if r.NominalRate <= 0 {
-			t.Errorf("expected positive rate, got %f for term %s", r.NominalRate, r.Term)
+			t.Errorf("expected positive rate, got %v for term %s", r.NominalRate, r.Term)
 		}
 
 		if _, ok := expectedTerms[r.Term]; ok {
@@ -220,7 +220,64 @@ func TestBluestepCrawler_extractAverageRates(t *testing.T) {
 		}
 
 		if r.NominalRate <= 0 {
-			t.Errorf("expected positive rate, got %f", r.NominalRate)
+			t.Errorf("expected positive rate, got %v", r.NominalRate)
+		}
+
+		if r.AverageSegment != nil {
+			t.Errorf("expected no segment for a single series, got %+v", r.AverageSegment)
+		}
+	}
+}
+
+func TestBluestepCrawler_extractAverageRates_Series(t *testing.T) {
+	t.Parallel()
+
+	seriesHTML := func(first, second string) string {
+		return `<h2>Genomsnittsräntor</h2>
+		<h3>` + first + `</h3>
+		<table><tr><td>Månad</td><td>3 mån</td><td>1 år</td></tr><tr><td>2025 11</td><td>5,68%</td><td>6,63%</td></tr></table>
+		<h3>` + second + `</h3>
+		<table><tr><td>Månad</td><td>3 mån</td><td>1 år</td></tr><tr><td>2025 11</td><td>7,10%</td><td></td></tr></table>`
+	}
+	crawler := NewBluestepCrawler(nil, zap.NewNop())
+
+	// Headings that don't name a segment can't tell the series apart, so only the first table is parsed.
+	rates, err := crawler.extractAverageRates(seriesHTML("Bolån", "Seniorlån"), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("extractAverageRates failed: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("got %d rates, want the 2 of the first table: %+v", len(rates), rates)
+	}
+	for _, r := range rates {
+		if r.AverageSegment != nil {
+			t.Errorf("rate %v has segment %+v, want none", r.NominalRate, r.AverageSegment)
+		}
+	}
+
+	rates, err = crawler.extractAverageRates(seriesHTML("Riskklass A", "Riskklass B"), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("extractAverageRates failed: %v", err)
+	}
+
+	got := map[string]model.Rate{}
+	for _, r := range rates {
+		if r.AverageSegment == nil {
+			t.Fatalf("rate %+v has no segment", r)
+		}
+		got[r.AverageSegment.Label+" "+r.Term.String()] = r.NominalRate
+	}
+	want := map[string]model.Rate{
+		"Riskklass A 3m": model.RateFromPercent(5.68),
+		"Riskklass A 1y": model.RateFromPercent(6.63),
+		"Riskklass B 3m": model.RateFromPercent(7.1),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for key, rate := range want {
+		if got[key] != rate {
+			t.Errorf("%s = %v, want %v", key, got[key], rate)
 		}
 	}
 }
@@ -243,7 +300,7 @@ func TestBluestepCrawler_parseBluestepTerm(t *testing.T) {
 		{"Fast 5 år", model.Term5years, false},
 		{"Fast 5 &aring;r", model.Term5years, false},
 		{"1 år", model.Term1year, false},
-		{"invalid", "", true},
+		{"invalid", model.Term{}, true},
 	}
 
 	for _, tt := range tests {
@@ -279,7 +336,7 @@ func TestBluestepCrawler_parseBluestepRate(t *testing.T) {
 
 	tests := []struct {
 		input    string
-		expected float32
+		expected float64
 		wantErr  bool
 	}{
 		{"4,45%", 4.45, false},
@@ -310,9 +367,8 @@ func TestBluestepCrawler_parseBluestepRate(t *testing.T) {
 				return
 			}
 
-			// Compare with tolerance for float comparison
-			if diff := result - tt.expected; diff > 0.001 || diff < -0.001 {
-				t.Errorf("expected %f, got %f", tt.expected, result)
+			if result != model.RateFromPercent(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
 			}
 		})
 	}
@@ -0,0 +1,273 @@
+package borgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// avgRatesTableText appears right before the average rates table on every site of the platform.
+const avgRatesTableText = "Snitträntor för bolån"
+
+var (
+	_ crawler.SiteCrawler    = &BorgoCrawler{}
+	_ crawler.Fingerprinter  = &BorgoCrawler{}
+	_ crawler.DocumentParser = &BorgoCrawler{}
+	_ crawler.DocumentParser = &Parser{}
+)
+
+// Distributor is a bank whose mortgage pages run on Borgo's white-label platform: a JSON API with the list rates and a
+// page with the average rates in the table after "Snitträntor för bolån", one row per month like "2025 01".
+//
+// Distributors set their own prices for Borgo's loans, so their rates are published under their own name without a
+// Lender and are not cross-checked against Borgo's.
+type Distributor struct {
+	Bank         model.Bank
+	ListRatesURL string
+	AvgRatesURL  string
+}
+
+// DefaultDistributors returns Borgo and the partners on its platform that have no crawler of their own. Ikano Bank
+// runs on the platform too but keeps its own crawler, see ikanobank. A new partner only needs an entry here and a bank
+// profile.
+func DefaultDistributors() []Distributor {
+	return []Distributor{
+		{
+			Bank:         "Borgo",
+			ListRatesURL: "https://borgo.se/api/interesttable/gettabledata",
+			AvgRatesURL:  "https://borgo.se/bolan/bolanerantor",
+		},
+		{
+			Bank:         "Söderberg & Partners",
+			ListRatesURL: "https://soderbergpartners.se/api/interesttable/gettabledata",
+			AvgRatesURL:  "https://soderbergpartners.se/privat/bolan/bolanerantor",
+		},
+	}
+}
+
+// listRatesResponse represents the JSON response of the list rates API.
+type listRatesResponse struct {
+	Success  bool           `json:"success"`
+	ListData []listRateItem `json:"listData"`
+}
+
+type listRateItem struct {
+	RateFixationPeriod    string `json:"rateFixationPeriod"`    // "3 mån", "1 år", etc.
+	ListPriceInterestRate string `json:"listPriceInterestRate"` // "3.4800"
+	EffectiveInterestRate string `json:"effectiveInterestRate"` // "3.5400"
+}
+
+// Parser parses the list rates API response and the average rates page of one distributor.
+type Parser struct {
+	distributor  Distributor
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+// NewParser creates a parser for the distributor's documents. The fingerprints may be nil.
+func NewParser(distributor Distributor, logger *zap.Logger, fingerprints *crawler.FingerprintRecorder) *Parser {
+	return &Parser{
+		distributor:  distributor,
+		logger:       logger,
+		fingerprints: fingerprints,
+	}
+}
+
+// ParseDocument parses an archived copy of the list rates API response or the average rates page again.
+func (p *Parser) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case p.distributor.ListRatesURL:
+		return p.ParseListRates(string(content), fetchedAt)
+	case p.distributor.AvgRatesURL:
+		return p.ParseAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
+// ParseListRates parses the list rates API response.
+func (p *Parser) ParseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	p.fingerprints.RecordRawJSON(p.distributor.ListRatesURL, "list rates", rawJSON)
+
+	var response listRatesResponse
+	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
+		p.logger.Error("failed unmarshalling list rates", zap.Error(err), zap.String("rawJSON", rawJSON))
+		return nil, fmt.Errorf("failed unmarshalling %s list rates: %w", p.distributor.Bank, err)
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("%s list rates API returned success=false", p.distributor.Bank)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, item := range response.ListData {
+		term, rate, ok := p.parseTermRate(item.RateFixationPeriod, item.ListPriceInterestRate)
+		if !ok {
+			continue
+		}
+
+		interestSets = append(interestSets, model.InterestSet{
+			Bank:          p.distributor.Bank,
+			Type:          model.TypeListRate,
+			Term:          term,
+			NominalRate:   rate,
+			LastCrawledAt: crawlTime,
+		})
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
+	return interestSets, nil
+}
+
+// ParseAverageRates parses the average rates table: Månad | 3 mån | 1 år | 2 år | ... (terms may change).
+func (p *Parser) ParseAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: avgRatesTableText},
+		Columns: []utils.TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	p.fingerprints.RecordTable(p.distributor.AvgRatesURL, "average rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract average rates table after %q: %w", avgRatesTableText, err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		month, err := parseAvgMonth(record.Get("month"))
+		if err != nil {
+			p.logger.Warn("failed to parse average month", zap.String("month", record.Get("month")), zap.Error(err))
+			continue
+		}
+
+		for _, cell := range record.Cells("rates") {
+			term, rate, ok := p.parseTermRate(cell.Header, cell.Text)
+			if !ok {
+				continue
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  p.distributor.Bank,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: month,
+			})
+		}
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "parseAverageRates")
+	return interestSets, nil
+}
+
+// parseTermRate parses a term and its rate. A missing rate, shown as "-" for months with too few loans, is skipped
+// silently.
+func (p *Parser) parseTermRate(termStr, rateStr string) (model.Term, model.Rate, bool) {
+	term, err := utils.ParseTerm(termStr)
+	if err != nil {
+		p.logger.Warn("term not supported - skipping", zap.String("term", termStr), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+
+	rate, err := parseRate(rateStr)
+	if errors.Is(err, utils.ErrEmptyRate) {
+		return model.Term{}, 0, false
+	}
+	if err != nil {
+		p.logger.Warn("failed to parse rate", zap.String("rate", rateStr), zap.Stringer("term", term), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+	return term, rate, true
+}
+
+// BorgoCrawler crawls the list and average rates of one distributor on Borgo's platform.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type BorgoCrawler struct {
+	distributor  Distributor
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+// NewBorgoCrawler creates a crawler for the distributor.
+func NewBorgoCrawler(distributor Distributor, httpClient http.Client, logger *zap.Logger) *BorgoCrawler {
+	return &BorgoCrawler{
+		distributor:  distributor,
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(distributor.Bank),
+	}
+}
+
+// NewBorgoCrawlers returns one crawler per distributor, each logging under its bank name.
+func NewBorgoCrawlers(distributors []Distributor, httpClient http.Client, logger *zap.Logger) []crawler.SiteCrawler {
+	crawlers := make([]crawler.SiteCrawler, 0, len(distributors))
+	for _, distributor := range distributors {
+		crawlers = append(crawlers, NewBorgoCrawler(distributor, httpClient, logger.With(zap.String("bank", string(distributor.Bank)))))
+	}
+	return crawlers
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *BorgoCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+// Crawl fetches the distributor's list and average rates and sends them to the channel.
+func (c *BorgoCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	interestSets := []model.InterestSet{}
+	for _, url := range []string{c.distributor.ListRatesURL, c.distributor.AvgRatesURL} {
+		content, err := c.httpClient.Fetch(url, nil)
+		if err != nil {
+			c.logger.Error("failed reading Borgo platform source", zap.String("url", url), zap.Error(err))
+			continue
+		}
+
+		sets, err := c.ParseDocument(url, []byte(content), crawlTime)
+		if err != nil {
+			c.logger.Error("failed parsing Borgo platform source", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		crawler.SetSource(sets, url, []byte(content))
+		interestSets = append(interestSets, sets...)
+	}
+
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the list rates API response or the average rates page again.
+func (c *BorgoCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	return NewParser(c.distributor, c.logger, c.fingerprints).ParseDocument(url, content, fetchedAt)
+}
+
+// parseRate parses a rate in the API format ("3.4800") or the HTML format ("3,61 %").
+func parseRate(rateStr string) (model.Rate, error) {
+	rate, err := utils.ParseRate(rateStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
+	}
+	return rate, nil
+}
+
+// parseAvgMonth parses a month of the average rates table, e.g. "2025 01".
+func parseAvgMonth(monthStr string) (*model.AvgMonth, error) {
+	month, err := utils.ParseMonthYear(monthStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse month %q: %w", monthStr, err)
+	}
+	return &month, nil
+}
@@ -0,0 +1,381 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package borgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+var testDistributor = Distributor{
+	Bank:         "Borgo",
+	ListRatesURL: "https://borgo.se/api/interesttable/gettabledata",
+	AvgRatesURL:  "https://borgo.se/bolan/bolanerantor",
+}
+
+func TestBorgoCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	documents := map[string]string{
+		testDistributor.ListRatesURL: crawlertest.LoadGoldenFile(t, "testdata/borgo_list_rates.json"),
+		testDistributor.AvgRatesURL:  crawlertest.LoadGoldenFile(t, "testdata/borgo_avg_rates.html"),
+	}
+
+	tests := []struct {
+		name          string
+		failURL       string
+		wantListRates int
+		wantAvgRates  int
+	}{
+		{
+			name:          "successful crawl extracts list and average rates",
+			wantListRates: 6,  // 3 mån to 10 år, "Topplån" skipped
+			wantAvgRates:  33, // 6 months × 6 terms, 10 år missing in three months
+		},
+		{
+			name:         "list rates fetch error still returns average rates",
+			failURL:      testDistributor.ListRatesURL,
+			wantAvgRates: 33,
+		},
+		{
+			name:          "average rates fetch error still returns list rates",
+			failURL:       testDistributor.AvgRatesURL,
+			wantListRates: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &httpmock.ClientMock{
+				FetchFunc: func(url string, _ map[string]string) (string, error) {
+					document, ok := documents[url]
+					if !ok || url == tt.failURL {
+						return "", errors.New("network error")
+					}
+					return document, nil
+				},
+			}
+
+			results := crawlertest.RunCrawl(t, NewBorgoCrawler(testDistributor, client, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != tt.wantListRates {
+				t.Errorf("list rate count = %d, want %d", listRateCount, tt.wantListRates)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, testDistributor.Bank)
+			for _, r := range results {
+				if r.Lender != "" {
+					t.Errorf("Lender of %s = %q, want none", r.Key(), r.Lender)
+				}
+				if r.Source == nil || r.Source.Hash == "" {
+					t.Errorf("Source of %s = %+v, want the fetched document", r.Key(), r.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_ParseListRates(t *testing.T) {
+	t.Parallel()
+
+	rawJSON := crawlertest.LoadGoldenFile(t, "testdata/borgo_list_rates.json")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	parser := NewParser(testDistributor, zap.NewNop(), nil)
+
+	results, err := parser.ParseListRates(rawJSON, crawlTime)
+	if err != nil {
+		t.Fatalf("ParseListRates() error = %v", err)
+	}
+
+	rates := map[model.Term]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: testDistributor.Bank}, crawlTime)
+		rates[r.Term] = r.NominalRate
+	}
+	if got := rates[model.Term3months]; got != model.RateFromPercent(3.39) {
+		t.Errorf("3m rate = %s, want 3.39", got)
+	}
+	if got := rates[model.Term10years]; got != model.RateFromPercent(4.09) {
+		t.Errorf("10y rate = %s, want 4.09", got)
+	}
+
+	if _, err := parser.ParseListRates(`{"success":false,"listData":[]}`, crawlTime); err == nil {
+		t.Error("ParseListRates(success=false) error = nil, want error")
+	}
+	crawlertest.TestInvalidJSON(t, func(rawJSON string) error {
+		_, err := parser.ParseListRates(rawJSON, crawlTime)
+		return err
+	})
+}
+
+func TestParser_ParseAverageRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/borgo_avg_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	parser := NewParser(testDistributor, zap.NewNop(), nil)
+
+	results, err := parser.ParseAverageRates(rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("ParseAverageRates() error = %v", err)
+	}
+
+	rates := map[model.AvgMonth]map[model.Term]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertAverageRateFields(t, r, testDistributor.Bank, crawlTime)
+		if rates[*r.AverageReferenceMonth] == nil {
+			rates[*r.AverageReferenceMonth] = map[model.Term]model.Rate{}
+		}
+		rates[*r.AverageReferenceMonth][r.Term] = r.NominalRate
+	}
+
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	april := model.AvgMonth{Year: 2025, Month: time.April}
+	if got := rates[september][model.Term3months]; got != model.RateFromPercent(2.84) {
+		t.Errorf("3m rate of September = %s, want 2.84", got)
+	}
+	if got := rates[april][model.Term10years]; got != model.RateFromPercent(3.58) {
+		t.Errorf("10y rate of April = %s, want 3.58", got)
+	}
+	if got, ok := rates[september][model.Term10years]; ok {
+		t.Errorf("10y rate of September = %s, want none for \"-\"", got)
+	}
+
+	if _, err := parser.ParseAverageRates("<html><p>Inga räntor</p></html>", crawlTime); err == nil {
+		t.Error("ParseAverageRates() without average rates table error = nil, want error")
+	}
+}
+
+func TestParser_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	partner := Distributor{
+		Bank:         "Söderberg & Partners",
+		ListRatesURL: "https://soderbergpartners.se/api/interesttable/gettabledata",
+		AvgRatesURL:  "https://soderbergpartners.se/privat/bolan/bolanerantor",
+	}
+
+	tests := []struct {
+		name        string
+		distributor Distributor
+		url         string
+		file        string
+		wantType    model.Type
+		wantError   error
+	}{
+		{
+			name: "list rates", distributor: testDistributor, url: testDistributor.ListRatesURL,
+			file: "testdata/borgo_list_rates.json", wantType: model.TypeListRate,
+		},
+		{
+			name: "partner average rates", distributor: partner, url: partner.AvgRatesURL,
+			file: "testdata/borgo_avg_rates.html", wantType: model.TypeAverageRate,
+		},
+		{
+			name: "other distributor's document", distributor: partner, url: testDistributor.ListRatesURL,
+			file: "testdata/borgo_list_rates.json", wantError: crawlertest.ErrUnknownSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser := NewParser(tt.distributor, zap.NewNop(), nil)
+			results, err := parser.ParseDocument(tt.url, crawlertest.LoadGoldenFileBytes(t, tt.file), fetchedAt)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ParseDocument() error = %v, want %v", err, tt.wantError)
+			}
+			if tt.wantError != nil {
+				return
+			}
+
+			if len(results) == 0 {
+				t.Fatal("ParseDocument() returned no rates")
+			}
+			crawlertest.AssertBankName(t, results, tt.distributor.Bank)
+			for _, r := range results {
+				if r.Type != tt.wantType || !r.LastCrawledAt.Equal(fetchedAt) {
+					t.Errorf("rate %s crawled at %v, want %s crawled at %v", r.Key(), r.LastCrawledAt, tt.wantType, fetchedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultDistributors(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[model.Bank]bool{}
+	for _, p := range model.BankProfiles() {
+		profiles[p.Bank] = true
+	}
+
+	seen := map[model.Bank]bool{}
+	for _, distributor := range DefaultDistributors() {
+		if seen[distributor.Bank] {
+			t.Errorf("distributor %q listed twice", distributor.Bank)
+		}
+		seen[distributor.Bank] = true
+		if !profiles[distributor.Bank] {
+			t.Errorf("distributor %q is not in the bank catalogue", distributor.Bank)
+		}
+		if distributor.ListRatesURL == "" || distributor.AvgRatesURL == "" {
+			t.Errorf("distributor %q is missing a source URL", distributor.Bank)
+		}
+	}
+
+	if crawlers := NewBorgoCrawlers(DefaultDistributors(), &httpmock.ClientMock{}, zap.NewNop()); len(crawlers) != len(seen) {
+		t.Errorf("NewBorgoCrawlers() returned %d crawlers, want %d", len(crawlers), len(seen))
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:    "API format - 3.4800",
+			input:   "3.4800",
+			want:    3.48,
+			wantErr: false,
+		},
+		{
+			name:    "HTML format with comma - 3,61 %",
+			input:   "3,61 %",
+			want:    3.61,
+			wantErr: false,
+		},
+		{
+			name:    "HTML format without space - 3,61%",
+			input:   "3,61%",
+			want:    3.61,
+			wantErr: false,
+		},
+		{
+			name:    "simple decimal - 2.5",
+			input:   "2.5",
+			want:    2.5,
+			wantErr: false,
+		},
+		{
+			name:    "invalid - not a number",
+			input:   "abc",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty string",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
+				t.Errorf("parseRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAvgMonth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		wantYear  uint
+		wantMonth time.Month
+		wantErr   bool
+	}{
+		{
+			name:      "valid - November 2024",
+			input:     "2024 11",
+			wantYear:  2024,
+			wantMonth: time.November,
+			wantErr:   false,
+		},
+		{
+			name:      "valid - January 2025",
+			input:     "2025 01",
+			wantYear:  2025,
+			wantMonth: time.January,
+			wantErr:   false,
+		},
+		{
+			name:      "valid - December 2024",
+			input:     "2024 12",
+			wantYear:  2024,
+			wantMonth: time.December,
+			wantErr:   false,
+		},
+		{
+			name:      "single digit month",
+			input:     "2024 1",
+			wantYear:  2024,
+			wantMonth: time.January,
+			wantErr:   false,
+		},
+		{
+			name:      "dash separator",
+			input:     "2024-01",
+			wantYear:  2024,
+			wantMonth: time.January,
+			wantErr:   false,
+		},
+		{
+			name:    "invalid - not a number",
+			input:   "abc",
+			wantErr: true,
+		},
+		{
+			name:    "invalid - empty string",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseAvgMonth(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseAvgMonth() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if got.Year != tt.wantYear {
+					t.Errorf("parseAvgMonth() year = %v, want %v", got.Year, tt.wantYear)
+				}
+				if got.Month != tt.wantMonth {
+					t.Errorf("parseAvgMonth() month = %v, want %v", got.Month, tt.wantMonth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package crawler
+
+import (
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// Disagreement is a product whose rate differs between the distributing bank and the lender's own publication.
+type Disagreement struct {
+	Distributor     model.Bank
+	Lender          model.Bank
+	Type            model.Type
+	Term            model.Term
+	DistributorRate model.Rate
+	LenderRate      model.Rate
+	DiffBps         float64
+}
+
+// ConsistencyResult is the outcome of comparing all distributed products with their lender's own rates.
+type ConsistencyResult struct {
+	Matched       uint
+	Disagreements []Disagreement
+	// Unmatched are distributed products for which the lender published no equivalent rate in the same crawl.
+	Unmatched []model.InterestSet
+}
+
+// ConsistencyChecker cross-checks rates published by distributors (e.g. Avanza, Nordnet) against the same products
+// published directly by the lender (e.g. Stabelo, Landshypotek). Disagreements are an independent signal that one
+// of the parsers broke.
+type ConsistencyChecker struct {
+	tolerance model.Rate
+}
+
+// NewConsistencyChecker creates a checker that tolerates differences up to toleranceBps basis points.
+func NewConsistencyChecker(toleranceBps float32) *ConsistencyChecker {
+	return &ConsistencyChecker{tolerance: model.RateFromBasisPoints(float64(toleranceBps))}
+}
+
+// Check matches every InterestSet with a Lender to the lender's own InterestSet of the same type and term. Only plain
+// products are compared: a distributed set limited to some borrowers, like a green or union discount, has no plain
+// equivalent and is unmatched.
+func (c *ConsistencyChecker) Check(sets []model.InterestSet) ConsistencyResult {
+	type productKey struct {
+		bank model.Bank
+		typ  model.Type
+		term model.Term
+	}
+
+	direct := make(map[productKey]model.InterestSet)
+	for _, set := range sets {
+		if set.Lender != "" || !isPlainProduct(set) {
+			continue
+		}
+		direct[productKey{bank: set.Bank, typ: set.Type, term: set.Term}] = set
+	}
+
+	result := ConsistencyResult{}
+	for _, set := range sets {
+		if set.Lender == "" || set.Lender == set.Bank {
+			continue
+		}
+
+		lenderSet, ok := direct[productKey{bank: set.Lender, typ: set.Type, term: set.Term}]
+		if !ok || !isPlainProduct(set) {
+			result.Unmatched = append(result.Unmatched, set)
+			continue
+		}
+
+		result.Matched++
+		diff := set.NominalRate - lenderSet.NominalRate
+		if diff.Abs() <= c.tolerance {
+			continue
+		}
+
+		result.Disagreements = append(result.Disagreements, Disagreement{
+			Distributor:     set.Bank,
+			Lender:          set.Lender,
+			Type:            set.Type,
+			Term:            set.Term,
+			DistributorRate: set.NominalRate,
+			LenderRate:      lenderSet.NominalRate,
+			DiffBps:         diff.BasisPoints(),
+		})
+	}
+
+	return result
+}
+
+// isPlainProduct reports whether the set applies to every borrower and month, so that the bank, type and term identify
+// it. The boundaries are the ones sameBoundaries compares.
+func isPlainProduct(set model.InterestSet) bool {
+	return set.RatioDiscountBoundaries == nil && set.LoanAmountBoundaries == nil && set.MaxEnergyClass == "" &&
+		len(set.UnionOrganisations) == 0 && set.AverageReferenceMonth == nil && set.AverageSegment == nil
+}
@@ -0,0 +1,124 @@
+package crawler
+
+import (
+	"testing"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func distributedRate(bank, lender model.Bank, term model.Term, rate float64) model.InterestSet {
+	set := listRate(bank, term, rate)
+	set.Lender = lender
+	return set
+}
+
+func TestConsistencyChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	tier := listRate("Stabelo", model.Term3months, 2.5)
+	tier.Type = model.TypeRatioDiscounted
+	tier.RatioDiscountBoundaries = &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6}
+
+	green := listRate("Stabelo", model.Term3months, 2.7)
+	green.MaxEnergyClass = model.EnergyClassB
+	largeLoan := listRate("Stabelo", model.Term3months, 2.8)
+	largeLoan.LoanAmountBoundaries = &model.LoanAmountBoundary{MinAmount: 5_000_000}
+	union := listRate("Stabelo", model.Term3months, 2.9)
+	union.UnionOrganisations = []string{"Saco"}
+	distributedGreen := distributedRate("Avanza", "Stabelo", model.Term3months, 2.7)
+	distributedGreen.MaxEnergyClass = model.EnergyClassB
+
+	tests := []struct {
+		name              string
+		sets              []model.InterestSet
+		wantMatched       uint
+		wantDisagreements []Disagreement
+		wantUnmatched     int
+	}{
+		{
+			name: "matching rates agree",
+			sets: []model.InterestSet{
+				listRate("Stabelo", model.Term3months, 3.01),
+				distributedRate("Avanza", "Stabelo", model.Term3months, 3.01),
+				distributedRate("Nordnet", "Stabelo", model.Term3months, 3.015),
+			},
+			wantMatched: 2,
+		},
+		{
+			name: "differing rates are reported per distributor and term",
+			sets: []model.InterestSet{
+				listRate("Landshypotek", model.Term1year, 3.2),
+				listRate("Landshypotek", model.Term3months, 3.0),
+				distributedRate("Avanza", "Landshypotek", model.Term1year, 3.25),
+				distributedRate("Avanza", "Landshypotek", model.Term3months, 3.0),
+			},
+			wantMatched: 2,
+			wantDisagreements: []Disagreement{{
+				Distributor: "Avanza", Lender: "Landshypotek", Type: model.TypeListRate, Term: model.Term1year,
+				DistributorRate: model.RateFromPercent(3.25), LenderRate: model.RateFromPercent(3.2),
+			}},
+		},
+		{
+			name: "missing lender rate is unmatched",
+			sets: []model.InterestSet{
+				distributedRate("Avanza", "Stabelo", model.Term10years, 3.5),
+			},
+			wantUnmatched: 1,
+		},
+		{
+			name: "ratio discounted tiers are not compared with list rates",
+			sets: []model.InterestSet{
+				tier,
+				distributedRate("Avanza", "Stabelo", model.Term3months, 3.1),
+			},
+			wantUnmatched: 1,
+		},
+		{
+			name: "discounts listed after the list rate don't replace it",
+			sets: []model.InterestSet{
+				listRate("Stabelo", model.Term3months, 3.1),
+				green,
+				largeLoan,
+				union,
+				distributedRate("Avanza", "Stabelo", model.Term3months, 3.1),
+			},
+			wantMatched: 1,
+		},
+		{
+			name: "distributed discount has no plain equivalent",
+			sets: []model.InterestSet{
+				listRate("Stabelo", model.Term3months, 3.1),
+				distributedGreen,
+			},
+			wantUnmatched: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NewConsistencyChecker(1).Check(tt.sets)
+
+			if got.Matched != tt.wantMatched {
+				t.Errorf("Matched = %d, want %d", got.Matched, tt.wantMatched)
+			}
+			if len(got.Unmatched) != tt.wantUnmatched {
+				t.Errorf("Unmatched = %d, want %d", len(got.Unmatched), tt.wantUnmatched)
+			}
+			if len(got.Disagreements) != len(tt.wantDisagreements) {
+				t.Fatalf("Disagreements = %v, want %v", got.Disagreements, tt.wantDisagreements)
+			}
+			for i, want := range tt.wantDisagreements {
+				d := got.Disagreements[i]
+				if d.Distributor != want.Distributor || d.Lender != want.Lender || d.Term != want.Term ||
+					d.DistributorRate != want.DistributorRate || d.LenderRate != want.LenderRate {
+					t.Errorf("Disagreement = %+v, want %+v", d, want)
+				}
+				if d.DiffBps <= 0 {
+					t.Errorf("DiffBps = %v, want positive", d.DiffBps)
+				}
+			}
+		})
+	}
+}
@@ -3,9 +3,6 @@ package danskebank
 import (
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
@@ -21,52 +18,32 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &DanskeBankCrawler{}
-
-	// YY-MM-DD format regex.
-	changedDateRegex = regexp.MustCompile(`^(\d{4})-(0[1-9]|1[0-2])-([0-2][1-9]|[1-3]0|3[01])$`)
-	interestRegex    = regexp.MustCompile(`^(\d+\.\d+ ?)%?$`)
-
-	swedishMonthMap = map[string]time.Month{ //nolint: gochecknoglobals
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-		"jan":       time.January,
-		"feb":       time.February,
-		"mar":       time.March,
-		"apr":       time.April,
-		"jun":       time.June,
-		"jul":       time.July,
-		"aug":       time.August,
-		"sep":       time.September,
-		"oct":       time.October,
-		"nov":       time.November,
-		"dec":       time.December,
-	}
+	_ crawler.SiteCrawler    = &DanskeBankCrawler{}
+	_ crawler.Fingerprinter  = &DanskeBankCrawler{}
+	_ crawler.DocumentParser = &DanskeBankCrawler{}
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type DanskeBankCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewDanskeBankCrawler(httpClient http.Client, logger *zap.Logger) *DanskeBankCrawler {
-	return &DanskeBankCrawler{httpClient: httpClient, logger: logger}
+	return &DanskeBankCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(danskeBankName),
+	}
 }
 
-func (c *DanskeBankCrawler) Crawl(channel chan<- model.InterestSet) {
-	interestSets := []model.InterestSet{}
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *DanskeBankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
 
+func (c *DanskeBankCrawler) Crawl(channel chan<- model.InterestSet) {
 	crawlTime := time.Now().UTC()
 	rawHTML, err := c.httpClient.Fetch(danskeURL, nil)
 	if err != nil {
@@ -74,6 +51,25 @@ func (c *DanskeBankCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	interestSets := c.parseRatesPage(rawHTML, crawlTime)
+	crawler.SetSource(interestSets, danskeURL, []byte(rawHTML))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates page again.
+func (c *DanskeBankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != danskeURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseRatesPage(string(content), fetchedAt), nil
+}
+
+// parseRatesPage extracts the list and average rates from the rates page.
+func (c *DanskeBankCrawler) parseRatesPage(rawHTML string, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
+
 	listInterestSets, err := c.extractListRates(rawHTML, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing Danske List Rates website", zap.Error(err))
@@ -88,9 +84,7 @@ func (c *DanskeBankCrawler) Crawl(channel chan<- model.InterestSet) {
 		interestSets = append(interestSets, avgInterest...)
 	}
 
-	for _, set := range interestSets {
-		channel <- set
-	}
+	return interestSets
 }
 
 func (c *DanskeBankCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
@@ -105,6 +99,7 @@ func (c *DanskeBankCrawler) extractListRates(rawHTML string, crawlTime time.Time
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse table: %w", err)
 	}
+	c.fingerprints.RecordTable(danskeURL, "list rates", table.Header)
 
 	interestSets := []model.InterestSet{}
 	for _, row := range table.Rows {
@@ -140,6 +135,7 @@ func (c *DanskeBankCrawler) extractListRates(rawHTML string, crawlTime time.Time
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
 	return interestSets, nil
 }
 
@@ -153,6 +149,7 @@ func (c *DanskeBankCrawler) extractAverageRates(rawHTML string, crawlTime time.T
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse table: %w", err)
 	}
+	c.fingerprints.RecordTable(danskeURL, "average rates", table.Header)
 
 	table = c.sanitizeAvgRows(table)
 
@@ -192,6 +189,7 @@ func (c *DanskeBankCrawler) extractAverageRates(rawHTML string, crawlTime time.T
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
 	return interestSets, nil
 }
 
@@ -246,93 +244,25 @@ func (c *DanskeBankCrawler) sanitizeAvgRows(table utils.Table) utils.Table {
 	}
 }
 
-//nolint:cyclop // complexity is ok for this simple function
 func parseDanskeBankChangeDate(data string) (time.Time, error) {
-	matches := changedDateRegex.FindStringSubmatch(data)
-	if len(matches) != 4 {
-		return time.Time{}, fmt.Errorf("failed to match change date regex")
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil || year < 0 {
-		return time.Time{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
-	if err != nil || month < 1 || month > 12 {
-		return time.Time{}, fmt.Errorf("failed to parse month: %w", err)
-	}
-
-	day, err := strconv.Atoi(matches[3])
-	if err != nil || day < 1 || day > 31 {
-		return time.Time{}, fmt.Errorf("failed to parse day: %w", err)
-	}
-
-	// assume all double-digit year numbers lower than 40 are from the 21st century, otherwise 20th century. This will
-	// ensure that this function works until the year 2039 and assumes we don't get historical data from before 1940
-	// presented in this format.
-	switch {
-	case year < 40:
-		year += 2000
-	case year < 100:
-		year += 1900
-	}
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(data)
 }
 
 // parseReferenceMonth parses Swedish month names into AvgMonth.
 // E.g. "Augusti 2021" -> AvgMonth{Month: time.August,   Year: 2021}.
 // E.g. "Feb 1955"     -> AvgMonth{Month: time.February, Year: 1955}.
 func (c *DanskeBankCrawler) parseReferenceMonth(data string) (model.AvgMonth, error) {
-	data = strings.ToLower(data)
-	data = strings.TrimSpace(data)
-
-	parts := strings.Fields(data)
-	if len(parts) < 2 {
-		return model.AvgMonth{}, fmt.Errorf("failed to split reference month into parts: %s", data)
-	}
-
-	var month time.Month
-	for swedishMonth, monthObject := range swedishMonthMap {
-		rawMonth := utils.NormalizeSpaces(parts[0])
-		if strings.Contains(rawMonth, swedishMonth) {
-			month = monthObject
-			break
-		}
-	}
-	if month == 0 {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %s", parts)
-	}
-
-	yearInt, err := strconv.Atoi(parts[1])
+	month, err := utils.ParseMonthYear(data)
 	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
+		return model.AvgMonth{}, err
 	}
-	if yearInt < 1940 || yearInt > 2100 {
-		return model.AvgMonth{}, fmt.Errorf("year out of range: %d", yearInt)
+	if month.Year < 1940 || month.Year > 2100 {
+		return model.AvgMonth{}, fmt.Errorf("year out of range: %d", month.Year)
 	}
 
-	return model.AvgMonth{
-		Month: month,
-		Year:  uint(yearInt),
-	}, nil
+	return month, nil
 }
 
-func parseNominalRate(data string) (float32, error) {
-	data = utils.NormalizeSpaces(data)
-	data = strings.ReplaceAll(data, ",", ".") // replace Swedish decimal separator with dot
-	data = strings.ReplaceAll(data, " ", "")  // remove spaces
-
-	matches := interestRegex.FindStringSubmatch(data)
-	if len(matches) != 2 {
-		return 0, fmt.Errorf("failed to match interest rate regex")
-	}
-
-	rate, err := strconv.ParseFloat(matches[1], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse interest rate: %w", err)
-	}
-
-	return float32(rate), nil
+func parseNominalRate(data string) (model.Rate, error) {
+	return utils.ParseRate(data)
 }
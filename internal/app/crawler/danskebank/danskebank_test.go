@@ -23,7 +23,7 @@ func assertListRateFields(t *testing.T, r model.InterestSet, crawlTime time.Time
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -44,7 +44,7 @@ func assertAverageRateFields(t *testing.T, r model.InterestSet, crawlTime time.T
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
@@ -272,7 +272,7 @@ func TestParseNominalRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -331,7 +331,7 @@ func TestParseNominalRate(t *testing.T) {
 				t.Errorf("parseNominalRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseNominalRate() = %v, want %v", got, tt.want)
 			}
 		})
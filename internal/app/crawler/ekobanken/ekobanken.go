@@ -0,0 +1,191 @@
+package ekobanken
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	ekobankenBankName model.Bank = "Ekobanken"
+	ekobankenRatesURL string     = "https://www.ekobanken.se/privat/lana/bolan/"
+)
+
+var (
+	_ crawler.SiteCrawler    = &EkobankenCrawler{}
+	_ crawler.Fingerprinter  = &EkobankenCrawler{}
+	_ crawler.DocumentParser = &EkobankenCrawler{}
+)
+
+// EkobankenCrawler crawls Ekobanken's mortgage page. Ekobanken is a small cooperative bank owned by its members and
+// lends only on homes with a social or environmental purpose. List and average rates are on the same page.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type EkobankenCrawler struct {
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+func NewEkobankenCrawler(httpClient http.Client, logger *zap.Logger) *EkobankenCrawler {
+	return &EkobankenCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(ekobankenBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *EkobankenCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func (c *EkobankenCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	rawHTML, err := c.httpClient.Fetch(ekobankenRatesURL, nil)
+	if err != nil {
+		c.logger.Error("failed fetching Ekobanken mortgage page", zap.Error(err))
+		return
+	}
+
+	interestSets, err := c.extractRates(rawHTML, crawlTime)
+	if err != nil {
+		c.logger.Error("failed parsing Ekobanken rates", zap.Error(err))
+		return
+	}
+
+	crawler.SetSource(interestSets, ekobankenRatesURL, []byte(rawHTML))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the mortgage page again.
+func (c *EkobankenCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != ekobankenRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.extractRates(string(content), fetchedAt)
+}
+
+// extractRates parses both tables of the page. A missing average rates table only costs the average rates.
+func (c *EkobankenCrawler) extractRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	interestSets, err := c.extractListRates(rawHTML, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	avgRates, err := c.extractAverageRates(rawHTML, crawlTime)
+	if err != nil {
+		c.logger.Warn("failed to extract Ekobanken average rates", zap.Error(err))
+	}
+	interestSets = append(interestSets, avgRates...)
+
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractRates")
+	return interestSets, nil
+}
+
+// extractListRates parses the list rates table: Bindningstid | Ränta | Effektiv ränta.
+func (c *EkobankenCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Aktuella bolåneräntor"},
+		Columns: []utils.TableColumn{
+			{Name: "term", Match: utils.HeaderContains("bindningstid")},
+			{Name: "rate", Match: utils.HeaderContains("ränta").Except(utils.HeaderContains("effektiv"))},
+		},
+	})
+	c.fingerprints.RecordTable(ekobankenRatesURL, "list rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("rate"))
+		if !ok {
+			continue
+		}
+
+		interestSets = append(interestSets, model.InterestSet{
+			Bank:          ekobankenBankName,
+			Type:          model.TypeListRate,
+			Term:          term,
+			NominalRate:   rate,
+			LastCrawledAt: crawlTime,
+		})
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no list rates found in table")
+	}
+	return interestSets, nil
+}
+
+// extractAverageRates parses the average rates table captioned "Snitträntor": Månad | 3 månader | 1 år | 3 år, one row
+// per month like "september 2025".
+func (c *EkobankenCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{Caption: "Snitträntor"},
+		Columns: []utils.TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	c.fingerprints.RecordTable(ekobankenRatesURL, "average rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		month, err := utils.ParseMonthYear(record.Get("month"))
+		if err != nil {
+			c.logger.Warn("failed to parse average rate month", zap.String("month", record.Get("month")), zap.Error(err))
+			continue
+		}
+
+		for _, cell := range record.Cells("rates") {
+			term, rate, ok := c.parseTermRate(cell.Header, cell.Text)
+			if !ok {
+				continue
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  ekobankenBankName,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: &month,
+			})
+		}
+	}
+	return interestSets, nil
+}
+
+// parseTermRate parses a term and its rate. A missing rate, shown as "-" for months with too few loans, is skipped
+// silently.
+func (c *EkobankenCrawler) parseTermRate(termStr, rateStr string) (model.Term, model.Rate, bool) {
+	term, err := utils.ParseTerm(termStr)
+	if err != nil {
+		c.logger.Warn("failed to parse term", zap.String("term", termStr), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+
+	rate, err := utils.ParseRate(rateStr)
+	if errors.Is(err, utils.ErrEmptyRate) {
+		return model.Term{}, 0, false
+	}
+	if err != nil {
+		c.logger.Warn("failed to parse rate", zap.String("rate", rateStr), zap.Stringer("term", term), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+	return term, rate, true
+}
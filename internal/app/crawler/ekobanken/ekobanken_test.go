@@ -0,0 +1,143 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package ekobanken
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestEkobankenCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	ratesHTML := crawlertest.LoadGoldenFile(t, "testdata/ekobanken_rates.html")
+
+	tests := []struct {
+		name          string
+		mockFetch     func(url string, headers map[string]string) (string, error)
+		wantListRates int
+		wantAvgRates  int
+	}{
+		{
+			name: "successful crawl extracts list and average rates",
+			mockFetch: func(url string, _ map[string]string) (string, error) {
+				if url != ekobankenRatesURL {
+					return "", errors.New("unexpected URL " + url)
+				}
+				return ratesHTML, nil
+			},
+			wantListRates: 3,
+			wantAvgRates:  16, // 6 months × 3 terms, two missing
+		},
+		{
+			name: "page without average rates still returns list rates",
+			mockFetch: func(_ string, _ map[string]string) (string, error) {
+				return `<html><h2>Aktuella bolåneräntor</h2><table><tr><th>Bindningstid</th><th>Ränta</th></tr>` +
+					`<tr><td>3 månader</td><td>3,95 %</td></tr></table></html>`, nil
+			},
+			wantListRates: 1,
+		},
+		{
+			name: "fetch error returns no results",
+			mockFetch: func(_ string, _ map[string]string) (string, error) {
+				return "", errors.New("network error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := crawlertest.RunCrawl(t, NewEkobankenCrawler(&httpmock.ClientMock{FetchFunc: tt.mockFetch}, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != tt.wantListRates {
+				t.Errorf("list rate count = %d, want %d", listRateCount, tt.wantListRates)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, ekobankenBankName)
+		})
+	}
+}
+
+func TestEkobankenCrawler_extractRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/ekobanken_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &EkobankenCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.extractRates(rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractRates() error = %v", err)
+	}
+
+	listRates := map[model.Term]model.Rate{}
+	avgRates := map[model.AvgMonth]map[model.Term]model.Rate{}
+	for _, r := range results {
+		switch r.Type {
+		case model.TypeListRate:
+			crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: ekobankenBankName}, crawlTime)
+			listRates[r.Term] = r.NominalRate
+		case model.TypeAverageRate:
+			crawlertest.AssertAverageRateFields(t, r, ekobankenBankName, crawlTime)
+			if avgRates[*r.AverageReferenceMonth] == nil {
+				avgRates[*r.AverageReferenceMonth] = map[model.Term]model.Rate{}
+			}
+			avgRates[*r.AverageReferenceMonth][r.Term] = r.NominalRate
+		default:
+			t.Errorf("unexpected type %q", r.Type)
+		}
+	}
+
+	if got := listRates[model.Term3months]; got != model.RateFromPercent(3.95) {
+		t.Errorf("3m list rate = %s, want 3.95 (not the effective rate)", got)
+	}
+	if got := listRates[model.Term3years]; got != model.RateFromPercent(3.75) {
+		t.Errorf("3y list rate = %s, want 3.75", got)
+	}
+
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	august := model.AvgMonth{Year: 2025, Month: time.August}
+	if got := avgRates[september][model.Term1year]; got != model.RateFromPercent(3.28) {
+		t.Errorf("1y average rate of September = %s, want 3.28", got)
+	}
+	if got, ok := avgRates[august][model.Term1year]; ok {
+		t.Errorf("1y average rate of August = %s, want none for \"-\"", got)
+	}
+	if len(avgRates) != 6 {
+		t.Errorf("months = %d, want 6", len(avgRates))
+	}
+
+	if _, err := crawler.extractRates("<html><p>Bolån</p></html>", crawlTime); err == nil {
+		t.Error("extractRates() without list rates table error = nil, want error")
+	}
+}
+
+func TestEkobankenCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &EkobankenCrawler{logger: zap.NewNop()}
+	content := crawlertest.LoadGoldenFileBytes(t, "testdata/ekobanken_rates.html")
+
+	results, err := crawler.ParseDocument(ekobankenRatesURL, content, fetchedAt)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	if len(results) != 19 {
+		t.Errorf("ParseDocument() returned %d rates, want 19", len(results))
+	}
+
+	if _, err := crawler.ParseDocument("https://www.ekobanken.se/", content, fetchedAt); !errors.Is(err, crawlertest.ErrUnknownSource) {
+		t.Errorf("ParseDocument(unknown URL) error = %v, want ErrUnknownSource", err)
+	}
+}
@@ -0,0 +1,140 @@
+package crawler
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// maxJSONDepth bounds the walk over decoded JSON, whose embedded page payloads may reference themselves.
+const maxJSONDepth = 32
+
+// Fingerprinter is implemented by crawlers that record the structure of the sources they parse. The Service compares
+// every crawl's fingerprints with the previous crawl's, so a renamed column or a new JSON field is reported before the
+// crawler silently starts skipping rates.
+type Fingerprinter interface {
+	// Fingerprints returns the fingerprints recorded since the last call, one per source.
+	Fingerprints() []model.Fingerprint
+}
+
+// FingerprintRecorder collects the structural features of a crawler's sources during a crawl. It is safe for
+// concurrent use. A nil recorder discards everything.
+type FingerprintRecorder struct {
+	bank    model.Bank
+	mu      sync.Mutex
+	sources map[string]map[string]struct{}
+}
+
+func NewFingerprintRecorder(bank model.Bank) *FingerprintRecorder {
+	return &FingerprintRecorder{bank: bank, sources: map[string]map[string]struct{}{}}
+}
+
+// RecordTable records the header of a table, one feature per column. Parts of the header that change with the data,
+// like a date, must be removed by the caller.
+func (r *FingerprintRecorder) RecordTable(source, table string, header []string) {
+	features := make([]string, 0, len(header))
+	for i, text := range header {
+		features = append(features, fmt.Sprintf("%s: column %d %q", table, i, text))
+	}
+	r.record(source, features)
+}
+
+// RecordJSON records the key paths of decoded JSON, e.g. "rates[*].term". Array elements share the path "[*]".
+func (r *FingerprintRecorder) RecordJSON(source, document string, doc any) {
+	var features []string
+	walkJSONPaths(doc, "", 0, func(path string) {
+		features = append(features, fmt.Sprintf("%s: path %s", document, path))
+	})
+	r.record(source, features)
+}
+
+// RecordRawJSON records the key paths of a JSON document like RecordJSON. Invalid JSON records no paths.
+func (r *FingerprintRecorder) RecordRawJSON(source, document, rawJSON string) {
+	var doc any
+	if err := json.Unmarshal([]byte(rawJSON), &doc); err != nil {
+		r.record(source, nil)
+		return
+	}
+	r.RecordJSON(source, document, doc)
+}
+
+// RecordTokens records texts that mark the structure of a document without markup, e.g. the column headings of a PDF
+// table.
+func (r *FingerprintRecorder) RecordTokens(source, document string, tokens []string) {
+	features := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		features = append(features, fmt.Sprintf("%s: token %q", document, token))
+	}
+	r.record(source, features)
+}
+
+// record adds features to the source's fingerprint. The source is fingerprinted even without features, so a source
+// that lost all of them is reported as changed.
+func (r *FingerprintRecorder) record(source string, features []string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, ok := r.sources[source]
+	if !ok {
+		set = map[string]struct{}{}
+		r.sources[source] = set
+	}
+	for _, feature := range features {
+		set[feature] = struct{}{}
+	}
+}
+
+// Fingerprints returns the recorded fingerprints sorted by source and starts over.
+func (r *FingerprintRecorder) Fingerprints() []model.Fingerprint {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	capturedAt := time.Now().UTC()
+	fingerprints := make([]model.Fingerprint, 0, len(r.sources))
+	for _, source := range slices.Sorted(maps.Keys(r.sources)) {
+		fingerprints = append(fingerprints, model.Fingerprint{
+			Bank:       r.bank,
+			Source:     source,
+			Features:   slices.Sorted(maps.Keys(r.sources[source])),
+			CapturedAt: capturedAt,
+		})
+	}
+	r.sources = map[string]map[string]struct{}{}
+	return fingerprints
+}
+
+func walkJSONPaths(value any, path string, depth int, visit func(path string)) {
+	if depth > maxJSONDepth {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			visit(childPath)
+			walkJSONPaths(child, childPath, depth+1, visit)
+		}
+	case []any:
+		for _, child := range v {
+			visit(path + "[*]")
+			walkJSONPaths(child, path+"[*]", depth+1, visit)
+		}
+	}
+}
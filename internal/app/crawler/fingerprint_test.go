@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFingerprintRecorder(t *testing.T) {
+	t.Parallel()
+
+	r := NewFingerprintRecorder("Prime Bank")
+	r.RecordTable("https://example.com/rates", "list rates", []string{"Bindningstid", "Ränta"})
+	r.RecordRawJSON("https://example.com/api", "rates", `{"rates": [{"term": "3M", "rate": 3.33}, {"term": "1Y", "fee": null}], "meta": {}}`)
+	r.RecordTokens("https://example.com/avg.pdf", "avg rates", []string{"3 mån", "1 år"})
+	r.RecordTable("https://example.com/rates", "avg rates", nil)
+	r.RecordRawJSON("https://example.com/broken", "rates", `{`)
+
+	got := r.Fingerprints()
+	want := map[string][]string{
+		"https://example.com/api": {
+			"rates: path meta",
+			"rates: path rates",
+			"rates: path rates[*]",
+			"rates: path rates[*].fee",
+			"rates: path rates[*].rate",
+			"rates: path rates[*].term",
+		},
+		"https://example.com/avg.pdf": {`avg rates: token "1 år"`, `avg rates: token "3 mån"`},
+		"https://example.com/broken":  nil,
+		"https://example.com/rates":   {`list rates: column 0 "Bindningstid"`, `list rates: column 1 "Ränta"`},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Fingerprints() returned %d fingerprints, want %d", len(got), len(want))
+	}
+	for i, fingerprint := range got {
+		if i > 0 && got[i-1].Source >= fingerprint.Source {
+			t.Errorf("Fingerprints() not sorted by source: %q before %q", got[i-1].Source, fingerprint.Source)
+		}
+		if fingerprint.Bank != "Prime Bank" {
+			t.Errorf("Bank = %q, want Prime Bank", fingerprint.Bank)
+		}
+		features := fingerprint.Features
+		if len(features) == 0 {
+			features = nil
+		}
+		if !reflect.DeepEqual(features, want[fingerprint.Source]) {
+			t.Errorf("Features of %s = %q, want %q", fingerprint.Source, features, want[fingerprint.Source])
+		}
+	}
+
+	if got := r.Fingerprints(); len(got) != 0 {
+		t.Errorf("Fingerprints() after taking them = %v, want none", got)
+	}
+}
+
+func TestFingerprintRecorder_Nil(t *testing.T) {
+	t.Parallel()
+
+	var r *FingerprintRecorder
+	r.RecordTable("https://example.com/rates", "list rates", []string{"Bindningstid"})
+	if got := r.Fingerprints(); got != nil {
+		t.Errorf("Fingerprints() of nil recorder = %v, want nil", got)
+	}
+}
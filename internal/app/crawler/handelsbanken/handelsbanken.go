@@ -3,13 +3,13 @@ package handelsbanken
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -20,14 +20,16 @@ const (
 )
 
 var (
-	_                            crawler.SiteCrawler = &HandelsbankenCrawler{}
-	handelsbankenPeriodYYYYMMRgx                     = regexp.MustCompile(`^(\d{4})(0[1-9]|1[0-2])$`) // YYYYMM
+	_ crawler.SiteCrawler    = &HandelsbankenCrawler{}
+	_ crawler.Fingerprinter  = &HandelsbankenCrawler{}
+	_ crawler.DocumentParser = &HandelsbankenCrawler{}
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type HandelsbankenCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 // handelsbankenListRatesResponse represents the JSON response for list rates.
@@ -43,8 +45,8 @@ type handelsbankenInterestRate struct {
 }
 
 type handelsbankenRateValue struct {
-	Value    string  `json:"value"`    // formatted string "3,84"
-	ValueRaw float32 `json:"valueRaw"` // numeric value 3.84
+	Value    string     `json:"value"`    // formatted string "3,84"
+	ValueRaw model.Rate `json:"valueRaw"` // numeric value 3.84
 }
 
 // handelsbankenAvgRatesResponse represents the JSON response for average rates.
@@ -58,7 +60,16 @@ type handelsbankenAvgRatePeriod struct {
 }
 
 func NewHandelsbankenCrawler(httpClient http.Client, logger *zap.Logger) *HandelsbankenCrawler {
-	return &HandelsbankenCrawler{httpClient: httpClient, logger: logger}
+	return &HandelsbankenCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(handelsbankenBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *HandelsbankenCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *HandelsbankenCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -79,12 +90,37 @@ func (c *HandelsbankenCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates API response again.
+func (c *HandelsbankenCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case handelsbankenListRateURL:
+		return c.parseListRates(string(content), fetchedAt)
+	case handelsbankenAvgRatesURL:
+		return c.parseAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *HandelsbankenCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet, error) {
 	rawJSON, err := c.httpClient.Fetch(handelsbankenListRateURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading Handelsbanken list rates API: %w", err)
 	}
 
+	interestSets, err := c.parseListRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, handelsbankenListRateURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseListRates parses the list rates API response.
+func (c *HandelsbankenCrawler) parseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(handelsbankenListRateURL, "list rates", rawJSON)
+
 	var response handelsbankenListRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling Handelsbanken list rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -116,6 +152,7 @@ func (c *HandelsbankenCrawler) fetchListRates(crawlTime time.Time) ([]model.Inte
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
 	return interestSets, nil
 }
 
@@ -125,6 +162,19 @@ func (c *HandelsbankenCrawler) fetchAverageRates(crawlTime time.Time) ([]model.I
 		return nil, fmt.Errorf("failed reading Handelsbanken average rates API: %w", err)
 	}
 
+	interestSets, err := c.parseAverageRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, handelsbankenAvgRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseAverageRates parses the average rates API response.
+func (c *HandelsbankenCrawler) parseAverageRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(handelsbankenAvgRatesURL, "average rates", rawJSON)
+
 	var response handelsbankenAvgRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling Handelsbanken average rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -164,68 +214,29 @@ func (c *HandelsbankenCrawler) fetchAverageRates(crawlTime time.Time) ([]model.I
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseAverageRates")
 	return interestSets, nil
 }
 
-// handelsbankenYearTermMap maps year numbers to model.Term for Handelsbanken.
-//
-//nolint:gochecknoglobals // constant lookup map to reduce cyclomatic complexity
-var handelsbankenYearTermMap = map[int]model.Term{
-	1:  model.Term1year,
-	2:  model.Term2years,
-	3:  model.Term3years,
-	4:  model.Term4years,
-	5:  model.Term5years,
-	6:  model.Term6years,
-	7:  model.Term7years,
-	8:  model.Term8years,
-	9:  model.Term9years,
-	10: model.Term10years,
-}
-
 // parseHandelsbankenTerm converts Handelsbanken's periodBasisType and term to model.Term.
 // periodBasisType "3" = months, "4" = years.
 func parseHandelsbankenTerm(periodBasisType, term string) (model.Term, error) {
 	termNum, err := strconv.Atoi(term)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse term number: %w", err)
+		return model.Term{}, fmt.Errorf("failed to parse term number: %w", err)
 	}
 
 	switch periodBasisType {
-	case "3": // months
-		if termNum == 3 {
-			return model.Term3months, nil
-		}
-		return "", fmt.Errorf("unsupported month term: %d", termNum)
-	case "4": // years
-		if t, ok := handelsbankenYearTermMap[termNum]; ok {
-			return t, nil
-		}
-		return "", fmt.Errorf("unsupported year term: %d", termNum)
+	case "3":
+		return utils.TermFromCount(termNum, "months") //nolint:wrapcheck // utils error names the term
+	case "4":
+		return utils.TermFromCount(termNum, "years") //nolint:wrapcheck // utils error names the term
 	default:
-		return "", fmt.Errorf("unsupported periodBasisType: %s", periodBasisType)
+		return model.Term{}, fmt.Errorf("unsupported periodBasisType: %s", periodBasisType)
 	}
 }
 
 // parseHandelsbankenPeriod converts a YYYYMM period string to model.AvgMonth.
 func parseHandelsbankenPeriod(period string) (model.AvgMonth, error) {
-	matches := handelsbankenPeriodYYYYMMRgx.FindStringSubmatch(period)
-	if len(matches) != 3 {
-		return model.AvgMonth{}, fmt.Errorf("failed to match period format YYYYMM: %s", period)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %w", err)
-	}
-
-	return model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(month),
-	}, nil
+	return utils.ParseMonthYear(period)
 }
@@ -289,15 +289,23 @@ func TestParseHandelsbankenTerm(t *testing.T) {
 			wantErr:         false,
 		},
 		{
-			name:            "unsupported month term",
+			name:            "6 months",
 			periodBasisType: "3",
 			term:            "6",
-			wantErr:         true,
+			want:            model.Term6months,
+			wantErr:         false,
+		},
+		{
+			name:            "15 years",
+			periodBasisType: "4",
+			term:            "15",
+			want:            model.Term15years,
+			wantErr:         false,
 		},
 		{
-			name:            "unsupported year term",
+			name:            "zero years",
 			periodBasisType: "4",
-			term:            "11",
+			term:            "0",
 			wantErr:         true,
 		},
 		{
@@ -451,7 +459,7 @@ func assertHandelsbankenListRateFields(t *testing.T, r model.InterestSet, crawlT
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	// Handelsbanken API doesn't provide change dates
 	if r.ChangedOn != nil {
@@ -473,7 +481,7 @@ func assertHandelsbankenAverageRateFields(t *testing.T, r model.InterestSet, cra
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
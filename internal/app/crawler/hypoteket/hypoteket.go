@@ -1,15 +1,15 @@
 package hypoteket
 
 import (
-	"encoding/json"
 	"fmt"
 	"regexp"
-	"strconv"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/embedded"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -19,20 +19,33 @@ const (
 )
 
 var (
-	_                       crawler.SiteCrawler = &HypoteketCrawler{}
-	hypoteketPeriodYYYYMMRx                     = regexp.MustCompile(`^(\d{4})-(\d{2})$`) // YYYY-MM
+	_ crawler.SiteCrawler    = &HypoteketCrawler{}
+	_ crawler.Fingerprinter  = &HypoteketCrawler{}
+	_ crawler.DocumentParser = &HypoteketCrawler{}
+
+	hypoteketTermRegex = regexp.MustCompile(`^([a-z]+)(Months?|Years?)$`)
 )
 
 // HypoteketCrawler crawls Hypoteket's Nuxt.js payload for mortgage rates.
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type HypoteketCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewHypoteketCrawler(httpClient http.Client, logger *zap.Logger) *HypoteketCrawler {
-	return &HypoteketCrawler{httpClient: httpClient, logger: logger}
+	return &HypoteketCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(hypoteketBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *HypoteketCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *HypoteketCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -44,6 +57,23 @@ func (c *HypoteketCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	interestSets := c.parseRatesPayload(rawJSON, crawlTime)
+	crawler.SetSource(interestSets, hypoteketRatesURL, []byte(rawJSON))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates payload again.
+func (c *HypoteketCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != hypoteketRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseRatesPayload(string(content), fetchedAt), nil
+}
+
+// parseRatesPayload extracts the list and average rates from the rates payload.
+func (c *HypoteketCrawler) parseRatesPayload(rawJSON string, crawlTime time.Time) []model.InterestSet {
 	listRates, err := c.parseListRates(rawJSON, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing Hypoteket list rates", zap.Error(err))
@@ -54,90 +84,46 @@ func (c *HypoteketCrawler) Crawl(channel chan<- model.InterestSet) {
 		c.logger.Error("failed parsing Hypoteket average rates", zap.Error(err))
 	}
 
-	for _, set := range append(listRates, avgRates...) {
-		channel <- set
-	}
+	return append(listRates, avgRates...)
 }
 
 // parseListRates extracts list rates from the Nuxt.js payload.
-// The payload structure uses array indices as references.
 func (c *HypoteketCrawler) parseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
-	var payload []any
-	if err := json.Unmarshal([]byte(rawJSON), &payload); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	payload, err := embedded.DecodeNuxtPayload(rawJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
 	}
 
-	rateIndices, err := findListRateIndices(payload)
+	entries, err := embedded.Query(payload, "data.interest-rates[*]")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to query list rates: %w", err)
+	}
+	c.fingerprints.RecordJSON(hypoteketRatesURL, "list rates", entries)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no interest-rates found in payload")
 	}
 
 	interestSets := []model.InterestSet{}
-	for _, rateIdxRaw := range rateIndices {
-		set, ok := c.extractListRateEntry(payload, rateIdxRaw, crawlTime)
+	for _, entry := range entries {
+		set, ok := c.extractListRateEntry(entry, crawlTime)
 		if ok {
 			interestSets = append(interestSets, set)
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
 	return interestSets, nil
 }
 
-// findListRateIndices locates the list rate entry indices in the payload.
-func findListRateIndices(payload []any) ([]any, error) {
-	if len(payload) < 3 {
-		return nil, fmt.Errorf("payload too short, expected at least 3 elements")
-	}
-
-	dataMap, ok := payload[2].(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("expected object at index 2, got %T", payload[2])
-	}
-
-	interestRatesIdxRaw, ok := dataMap["interest-rates"]
-	if !ok {
-		return nil, fmt.Errorf("interest-rates key not found in data object")
-	}
-
-	interestRatesIdx, ok := interestRatesIdxRaw.(float64)
-	if !ok {
-		return nil, fmt.Errorf("interest-rates value is not a number: %T", interestRatesIdxRaw)
-	}
-
-	// The value at interest-rates index is ["Reactive", arrayIndex]
-	reactiveWrapper, ok := payload[int(interestRatesIdx)].([]any)
-	if !ok || len(reactiveWrapper) != 2 || reactiveWrapper[0] != "Reactive" {
-		return nil, fmt.Errorf("expected Reactive wrapper at index %d", int(interestRatesIdx))
-	}
-
-	ratesArrayIdx, ok := reactiveWrapper[1].(float64)
-	if !ok {
-		return nil, fmt.Errorf("expected array index in Reactive wrapper")
-	}
-
-	// Get the array of rate entry indices
-	rateIndices, ok := payload[int(ratesArrayIdx)].([]any)
-	if !ok {
-		return nil, fmt.Errorf("expected array of rate indices at index %d", int(ratesArrayIdx))
-	}
-
-	return rateIndices, nil
-}
-
-// extractListRateEntry extracts a single list rate entry from the payload.
-func (c *HypoteketCrawler) extractListRateEntry(payload []any, rateIdxRaw any, crawlTime time.Time) (model.InterestSet, bool) {
-	rateIdx, ok := rateIdxRaw.(float64)
-	if !ok {
-		return model.InterestSet{}, false
-	}
-
-	rateEntry, ok := payload[int(rateIdx)].(map[string]any)
-	if !ok {
+// extractListRateEntry extracts a single list rate entry from the decoded payload.
+func (c *HypoteketCrawler) extractListRateEntry(entry any, crawlTime time.Time) (model.InterestSet, bool) {
+	term, err := embedded.String(entry, "interestTerm")
+	if err != nil {
 		return model.InterestSet{}, false
 	}
 
-	term, rate, ok := extractTermAndRate(payload, rateEntry)
-	if !ok {
+	rate, err := embedded.Number(entry, "rate")
+	if err != nil {
 		return model.InterestSet{}, false
 	}
 
@@ -149,14 +135,12 @@ func (c *HypoteketCrawler) extractListRateEntry(payload []any, rateIdxRaw any, c
 		return model.InterestSet{}, false
 	}
 
-	changedOn := extractValidFromDate(payload, rateEntry)
-
 	return model.InterestSet{
 		Bank:          hypoteketBankName,
 		Type:          model.TypeListRate,
 		Term:          termModel,
-		NominalRate:   float32(rate),
-		ChangedOn:     changedOn,
+		NominalRate:   model.RateFromPercent(rate),
+		ChangedOn:     extractValidFromDate(entry),
 		LastCrawledAt: crawlTime,
 
 		RatioDiscountBoundaries: nil,
@@ -165,38 +149,10 @@ func (c *HypoteketCrawler) extractListRateEntry(payload []any, rateIdxRaw any, c
 	}, true
 }
 
-// extractTermAndRate extracts the term string and rate value from a rate entry.
-func extractTermAndRate(payload []any, rateEntry map[string]any) (string, float64, bool) {
-	termIdx, ok := rateEntry["interestTerm"].(float64)
-	if !ok {
-		return "", 0, false
-	}
-	termStr, ok := payload[int(termIdx)].(string)
-	if !ok {
-		return "", 0, false
-	}
-
-	rateValIdx, ok := rateEntry["rate"].(float64)
-	if !ok {
-		return "", 0, false
-	}
-	rate, ok := payload[int(rateValIdx)].(float64)
-	if !ok {
-		return "", 0, false
-	}
-
-	return termStr, rate, true
-}
-
 // extractValidFromDate extracts the validFrom date from a rate entry.
-func extractValidFromDate(payload []any, rateEntry map[string]any) *time.Time {
-	validFromIdx, ok := rateEntry["validFrom"].(float64)
-	if !ok {
-		return nil
-	}
-
-	validFromStr, ok := payload[int(validFromIdx)].(string)
-	if !ok {
+func extractValidFromDate(entry any) *time.Time {
+	validFromStr, err := embedded.String(entry, "validFrom")
+	if err != nil {
 		return nil
 	}
 
@@ -204,71 +160,43 @@ func extractValidFromDate(payload []any, rateEntry map[string]any) *time.Time {
 	if t, err := time.Parse(time.RFC3339, validFromStr); err == nil {
 		return &t
 	}
-	if t, err := time.Parse("2006-01-02T15:04:05.000Z", validFromStr); err == nil {
-		return &t
-	}
 
 	return nil
 }
 
 // parseAverageRates extracts historical average rates from the Nuxt.js payload.
 func (c *HypoteketCrawler) parseAverageRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
-	var payload []any
-	if err := json.Unmarshal([]byte(rawJSON), &payload); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	payload, err := embedded.DecodeNuxtPayload(rawJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
 	}
 
 	interestSets := []model.InterestSet{}
+	for _, entry := range embedded.FindObjects(payload, "monthPeriod") {
+		c.fingerprints.RecordJSON(hypoteketRatesURL, "average rates", entry)
 
-	for i, item := range payload {
-		entryMap, ok := item.(map[string]any)
-		if !ok {
+		monthPeriodStr, err := embedded.String(entry, "monthPeriod")
+		if err != nil {
 			continue
 		}
 
-		avgMonth, ok := c.extractAvgMonthFromEntry(payload, entryMap, i)
-		if !ok {
+		avgMonth, err := parseHypoteketPeriod(monthPeriodStr)
+		if err != nil {
+			c.logger.Warn("failed parsing Hypoteket average rate period",
+				zap.String("period", monthPeriodStr),
+				zap.Error(err))
 			continue
 		}
 
-		sets := extractAvgRatesFromEntry(payload, entryMap, avgMonth, crawlTime)
-		interestSets = append(interestSets, sets...)
+		interestSets = append(interestSets, extractAvgRatesFromEntry(entry, avgMonth, crawlTime)...)
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseAverageRates")
 	return interestSets, nil
 }
 
-// extractAvgMonthFromEntry extracts the average month from a payload entry.
-func (c *HypoteketCrawler) extractAvgMonthFromEntry(payload []any, entryMap map[string]any, index int) (model.AvgMonth, bool) {
-	monthPeriodIdxRaw, ok := entryMap["monthPeriod"]
-	if !ok {
-		return model.AvgMonth{}, false
-	}
-
-	monthPeriodIdx, ok := monthPeriodIdxRaw.(float64)
-	if !ok {
-		return model.AvgMonth{}, false
-	}
-
-	monthPeriodStr, ok := payload[int(monthPeriodIdx)].(string)
-	if !ok {
-		return model.AvgMonth{}, false
-	}
-
-	avgMonth, err := parseHypoteketPeriod(monthPeriodStr)
-	if err != nil {
-		c.logger.Warn("failed parsing Hypoteket average rate period",
-			zap.String("period", monthPeriodStr),
-			zap.Int("index", index),
-			zap.Error(err))
-		return model.AvgMonth{}, false
-	}
-
-	return avgMonth, true
-}
-
 // extractAvgRatesFromEntry extracts all term rates from an average rate entry.
-func extractAvgRatesFromEntry(payload []any, entryMap map[string]any, avgMonth model.AvgMonth, crawlTime time.Time) []model.InterestSet {
+func extractAvgRatesFromEntry(entry map[string]any, avgMonth model.AvgMonth, crawlTime time.Time) []model.InterestSet {
 	termFields := []struct {
 		field string
 		term  model.Term
@@ -282,7 +210,7 @@ func extractAvgRatesFromEntry(payload []any, entryMap map[string]any, avgMonth m
 
 	sets := make([]model.InterestSet, 0, len(termFields))
 	for _, tf := range termFields {
-		rate, ok := extractRateValue(payload, entryMap, tf.field)
+		rate, ok := extractRateValue(entry[tf.field])
 		if !ok {
 			continue
 		}
@@ -293,7 +221,7 @@ func extractAvgRatesFromEntry(payload []any, entryMap map[string]any, avgMonth m
 			Bank:                  hypoteketBankName,
 			Type:                  model.TypeAverageRate,
 			Term:                  tf.term,
-			NominalRate:           float32(rate),
+			NominalRate:           rate,
 			LastCrawledAt:         crawlTime,
 
 			RatioDiscountBoundaries: nil,
@@ -305,28 +233,14 @@ func extractAvgRatesFromEntry(payload []any, entryMap map[string]any, avgMonth m
 	return sets
 }
 
-// extractRateValue extracts a rate value from a payload entry.
-func extractRateValue(payload []any, entryMap map[string]any, field string) (float64, bool) {
-	rateIdxRaw, ok := entryMap[field]
-	if !ok {
-		return 0, false
-	}
-
-	rateIdx, ok := rateIdxRaw.(float64)
-	if !ok {
-		return 0, false
-	}
-
-	rateVal := payload[int(rateIdx)]
-	switch v := rateVal.(type) {
+// extractRateValue converts an average rate value, which Hypoteket publishes as either a number or a string.
+func extractRateValue(value any) (model.Rate, bool) {
+	switch v := value.(type) {
 	case float64:
-		return v, true
+		return model.RateFromPercent(v), true
 	case string:
-		if v == "-" || v == "" {
-			return 0, false // Skip missing values
-		}
-		// Try parsing as number
-		parsed, err := strconv.ParseFloat(v, 64)
+		// Missing values ("-" or "") fail to parse and are skipped
+		parsed, err := utils.ParseRate(v)
 		if err != nil {
 			return 0, false
 		}
@@ -336,47 +250,21 @@ func extractRateValue(payload []any, entryMap map[string]any, field string) (flo
 	}
 }
 
-// parseHypoteketTermToTerm converts Hypoteket's term names to model.Term.
+// parseHypoteketTermToTerm converts Hypoteket's term names like "threeMonth" or "fiveYear" to model.Term.
 func parseHypoteketTermToTerm(term string) (model.Term, error) {
-	switch term {
-	case "threeMonth":
-		return model.Term3months, nil
-	case "oneYear":
-		return model.Term1year, nil
-	case "twoYear":
-		return model.Term2years, nil
-	case "threeYear":
-		return model.Term3years, nil
-	case "fiveYear":
-		return model.Term5years, nil
-	default:
-		return "", fmt.Errorf("unsupported Hypoteket term: %s", term)
-	}
-}
-
-// parseHypoteketPeriod converts a YYYY-MM period string to model.AvgMonth.
-func parseHypoteketPeriod(period string) (model.AvgMonth, error) {
-	matches := hypoteketPeriodYYYYMMRx.FindStringSubmatch(period)
-	if len(matches) != 3 {
-		return model.AvgMonth{}, fmt.Errorf("failed to match period format YYYY-MM: %s", period)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
+	matches := hypoteketTermRegex.FindStringSubmatch(term)
+	if matches == nil {
+		return model.Term{}, fmt.Errorf("unsupported Hypoteket term: %s", term)
 	}
 
-	month, err := strconv.Atoi(matches[2])
+	count, err := utils.ParseNumberWord(matches[1])
 	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %w", err)
-	}
-
-	if month < 1 || month > 12 {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %d", month)
+		return model.Term{}, fmt.Errorf("unsupported Hypoteket term %s: %w", term, err)
 	}
+	return utils.TermFromCount(count, matches[2]) //nolint:wrapcheck // utils error names the term
+}
 
-	return model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(month),
-	}, nil
+// parseHypoteketPeriod converts a YYYY-MM period string to model.AvgMonth.
+func parseHypoteketPeriod(period string) (model.AvgMonth, error) {
+	return utils.ParseMonthYear(period)
 }
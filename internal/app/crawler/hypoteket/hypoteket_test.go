@@ -221,18 +221,20 @@ func TestParseHypoteketTermToTerm(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "unsupported term fourYear",
+			name:    "4 years",
 			term:    "fourYear",
-			wantErr: true,
+			want:    model.Term4years,
+			wantErr: false,
 		},
 		{
-			name:    "unsupported term sevenYear",
-			term:    "sevenYear",
-			wantErr: true,
+			name:    "18 months",
+			term:    "eighteenMonth",
+			want:    model.FixedTerm(18),
+			wantErr: false,
 		},
 		{
-			name:    "unsupported term tenYear",
-			term:    "tenYear",
+			name:    "unknown number",
+			term:    "hundredYear",
 			wantErr: true,
 		},
 		{
@@ -295,14 +297,18 @@ func TestParseHypoteketPeriod(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:    "invalid format - with day",
-			input:   "2025-11-30",
-			wantErr: true,
+			name:      "full date yields its month",
+			input:     "2025-11-30",
+			wantYear:  2025,
+			wantMonth: time.November,
+			wantErr:   false,
 		},
 		{
-			name:    "invalid format - wrong separator",
-			input:   "2025/11",
-			wantErr: true,
+			name:      "slash separator",
+			input:     "2025/11",
+			wantYear:  2025,
+			wantMonth: time.November,
+			wantErr:   false,
 		},
 		{
 			name:    "invalid month 00",
@@ -384,7 +390,7 @@ func assertHypoteketListRateFields(t *testing.T, r model.InterestSet, crawlTime
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	// Hypoteket API provides change dates (validFrom)
 	if r.ChangedOn == nil {
@@ -406,7 +412,7 @@ func assertHypoteketAverageRateFields(t *testing.T, r model.InterestSet, crawlTi
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
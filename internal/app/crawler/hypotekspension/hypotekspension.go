@@ -0,0 +1,194 @@
+package hypotekspension
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	hypotekspensionBankName    model.Bank = "Svensk Hypotekspension"
+	hypotekspensionListRateURL string     = "https://www.hypotekspension.se/rantor/"
+	hypotekspensionAvgRatesURL string     = "https://www.hypotekspension.se/rantor/genomsnittsrantor/"
+)
+
+var (
+	_ crawler.SiteCrawler    = &HypotekspensionCrawler{}
+	_ crawler.Fingerprinter  = &HypotekspensionCrawler{}
+	_ crawler.DocumentParser = &HypotekspensionCrawler{}
+)
+
+// HypotekspensionCrawler crawls Svensk Hypotekspension's rates. Its only product is an equity release mortgage for
+// homeowners over 60 without amortization, so its rates run well above the ordinary mortgages of the same term.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type HypotekspensionCrawler struct {
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+func NewHypotekspensionCrawler(httpClient http.Client, logger *zap.Logger) *HypotekspensionCrawler {
+	return &HypotekspensionCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(hypotekspensionBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *HypotekspensionCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func (c *HypotekspensionCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	interestSets := []model.InterestSet{}
+	for _, url := range []string{hypotekspensionListRateURL, hypotekspensionAvgRatesURL} {
+		rawHTML, err := c.httpClient.Fetch(url, nil)
+		if err != nil {
+			c.logger.Error("failed reading Svensk Hypotekspension rates page", zap.String("url", url), zap.Error(err))
+			continue
+		}
+
+		sets, err := c.ParseDocument(url, []byte(rawHTML), crawlTime)
+		if err != nil {
+			c.logger.Error("failed parsing Svensk Hypotekspension rates page", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		crawler.SetSource(sets, url, []byte(rawHTML))
+		interestSets = append(interestSets, sets...)
+	}
+
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the list rates page or the average rates page again.
+func (c *HypotekspensionCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case hypotekspensionListRateURL:
+		return c.extractListRates(string(content), fetchedAt)
+	case hypotekspensionAvgRatesURL:
+		return c.extractAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
+// extractListRates parses the pivoted list rates table after "Aktuella räntor", one column per term:
+//
+//	Bindningstid   | 3 mån  | 1 år   | 3 år   | 5 år
+//	Ränta          | 5,95 % | 6,05 % | 6,25 % | 6,45 %
+//	Effektiv ränta | 6,12 % | 6,23 % | 6,44 % | 6,65 %
+func (c *HypotekspensionCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate:      utils.TableLocator{TextBefore: "Aktuella räntor"},
+		Orientation: utils.ColumnRecords,
+		Columns: []utils.TableColumn{
+			{Name: "term", Index: 0},
+			{Name: "rate", Match: utils.HeaderContains("ränta").Except(utils.HeaderContains("effektiv"))},
+		},
+	})
+	c.fingerprints.RecordTable(hypotekspensionListRateURL, "list rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("rate"))
+		if !ok {
+			continue
+		}
+
+		interestSets = append(interestSets, model.InterestSet{
+			Bank:          hypotekspensionBankName,
+			Type:          model.TypeListRate,
+			Term:          term,
+			NominalRate:   rate,
+			LastCrawledAt: crawlTime,
+		})
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no list rates found in table")
+	}
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
+	return interestSets, nil
+}
+
+// extractAverageRates parses the average rates table captioned "Genomsnittlig ränta": Månad | 3 mån | 1 år | 3 år |
+// 5 år, one row per month like "2025-09".
+func (c *HypotekspensionCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{Caption: "Genomsnittlig ränta"},
+		Columns: []utils.TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	c.fingerprints.RecordTable(hypotekspensionAvgRatesURL, "average rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		month, err := utils.ParseMonthYear(record.Get("month"))
+		if err != nil {
+			c.logger.Warn("failed to parse average rate month", zap.String("month", record.Get("month")), zap.Error(err))
+			continue
+		}
+
+		for _, cell := range record.Cells("rates") {
+			term, rate, ok := c.parseTermRate(cell.Header, cell.Text)
+			if !ok {
+				continue
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  hypotekspensionBankName,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: &month,
+			})
+		}
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no average rates found in table")
+	}
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
+	return interestSets, nil
+}
+
+// parseTermRate parses a term and its rate. A missing rate, shown as "-" for months with too few loans, is skipped
+// silently.
+func (c *HypotekspensionCrawler) parseTermRate(termStr, rateStr string) (model.Term, model.Rate, bool) {
+	term, err := utils.ParseTerm(termStr)
+	if err != nil {
+		c.logger.Warn("failed to parse term", zap.String("term", termStr), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+
+	rate, err := utils.ParseRate(rateStr)
+	if errors.Is(err, utils.ErrEmptyRate) {
+		return model.Term{}, 0, false
+	}
+	if err != nil {
+		c.logger.Warn("failed to parse rate", zap.String("rate", rateStr), zap.Stringer("term", term), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+	return term, rate, true
+}
@@ -0,0 +1,180 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package hypotekspension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestHypotekspensionCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		hypotekspensionListRateURL: crawlertest.LoadGoldenFile(t, "testdata/hypotekspension_list_rates.html"),
+		hypotekspensionAvgRatesURL: crawlertest.LoadGoldenFile(t, "testdata/hypotekspension_avg_rates.html"),
+	}
+
+	tests := []struct {
+		name          string
+		failURL       string
+		wantListRates int
+		wantAvgRates  int
+	}{
+		{
+			name:          "successful crawl extracts list and average rates",
+			wantListRates: 4,  // 3 mån, 1 år, 3 år, 5 år
+			wantAvgRates:  17, // 6 months × 4 terms, 7 missing
+		},
+		{
+			name:         "list rates fetch error still returns average rates",
+			failURL:      hypotekspensionListRateURL,
+			wantAvgRates: 17,
+		},
+		{
+			name:          "average rates fetch error still returns list rates",
+			failURL:       hypotekspensionAvgRatesURL,
+			wantListRates: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &httpmock.ClientMock{
+				FetchFunc: func(url string, _ map[string]string) (string, error) {
+					page, ok := pages[url]
+					if !ok || url == tt.failURL {
+						return "", errors.New("network error")
+					}
+					return page, nil
+				},
+			}
+
+			results := crawlertest.RunCrawl(t, NewHypotekspensionCrawler(client, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != tt.wantListRates {
+				t.Errorf("list rate count = %d, want %d", listRateCount, tt.wantListRates)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, hypotekspensionBankName)
+		})
+	}
+}
+
+func TestHypotekspensionCrawler_extractListRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/hypotekspension_list_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &HypotekspensionCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.extractListRates(rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractListRates() error = %v", err)
+	}
+
+	want := map[model.Term]model.Rate{
+		model.Term3months: model.RateFromPercent(5.95),
+		model.Term1year:   model.RateFromPercent(6.05),
+		model.Term3years:  model.RateFromPercent(6.25),
+		model.Term5years:  model.RateFromPercent(6.45),
+	}
+	if len(results) != len(want) {
+		t.Errorf("extractListRates() returned %d rates, want %d", len(results), len(want))
+	}
+	for _, r := range results {
+		crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: hypotekspensionBankName}, crawlTime)
+		if r.NominalRate != want[r.Term] {
+			t.Errorf("%s list rate = %s, want %s", r.Term, r.NominalRate, want[r.Term])
+		}
+	}
+}
+
+func TestHypotekspensionCrawler_extractAverageRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/hypotekspension_avg_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &HypotekspensionCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.extractAverageRates(rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+
+	rates := map[model.AvgMonth]map[model.Term]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertAverageRateFields(t, r, hypotekspensionBankName, crawlTime)
+		if rates[*r.AverageReferenceMonth] == nil {
+			rates[*r.AverageReferenceMonth] = map[model.Term]model.Rate{}
+		}
+		rates[*r.AverageReferenceMonth][r.Term] = r.NominalRate
+	}
+
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	april := model.AvgMonth{Year: 2025, Month: time.April}
+	if got := rates[september][model.Term3months]; got != model.RateFromPercent(5.93) {
+		t.Errorf("3m rate of September = %s, want 5.93", got)
+	}
+	if got := rates[april][model.Term5years]; got != model.RateFromPercent(6.51) {
+		t.Errorf("5y rate of April = %s, want 6.51", got)
+	}
+	if got, ok := rates[september][model.Term5years]; ok {
+		t.Errorf("5y rate of September = %s, want none for \"-\"", got)
+	}
+	if len(rates) != 6 {
+		t.Errorf("months = %d, want 6", len(rates))
+	}
+}
+
+func TestHypotekspensionCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		url       string
+		file      string
+		wantType  model.Type
+		wantError error
+	}{
+		{name: "list rates", url: hypotekspensionListRateURL, file: "testdata/hypotekspension_list_rates.html", wantType: model.TypeListRate},
+		{name: "average rates", url: hypotekspensionAvgRatesURL, file: "testdata/hypotekspension_avg_rates.html", wantType: model.TypeAverageRate},
+		{name: "unknown page", url: "https://www.hypotekspension.se/", file: "testdata/hypotekspension_list_rates.html", wantError: crawlertest.ErrUnknownSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			crawler := &HypotekspensionCrawler{logger: zap.NewNop()}
+			results, err := crawler.ParseDocument(tt.url, crawlertest.LoadGoldenFileBytes(t, tt.file), fetchedAt)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ParseDocument() error = %v, want %v", err, tt.wantError)
+			}
+			if tt.wantError != nil {
+				return
+			}
+
+			if len(results) == 0 {
+				t.Fatal("ParseDocument() returned no rates")
+			}
+			for _, r := range results {
+				if r.Type != tt.wantType || !r.LastCrawledAt.Equal(fetchedAt) {
+					t.Errorf("rate %s crawled at %v, want %s crawled at %v", r.Key(), r.LastCrawledAt, tt.wantType, fetchedAt)
+				}
+			}
+		})
+	}
+}
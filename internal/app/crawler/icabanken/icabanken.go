@@ -2,8 +2,6 @@ package icabanken
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -20,27 +18,32 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &ICABankenCrawler{}
-
-	// ICA Banken date format: YYYY-MM-DD.
-	icaDateRegex = regexp.MustCompile(`^(\d{4})-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
-	// ICA Banken average month format: "2025 11" or "2024 12".
-	icaAvgMonthRegex = regexp.MustCompile(`^(\d{4})\s+(\d{1,2})$`)
+	_ crawler.SiteCrawler    = &ICABankenCrawler{}
+	_ crawler.Fingerprinter  = &ICABankenCrawler{}
+	_ crawler.DocumentParser = &ICABankenCrawler{}
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type ICABankenCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewICABankenCrawler(httpClient http.Client, logger *zap.Logger) *ICABankenCrawler {
-	return &ICABankenCrawler{httpClient: httpClient, logger: logger}
+	return &ICABankenCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(icaBankenName),
+	}
 }
 
-func (c *ICABankenCrawler) Crawl(channel chan<- model.InterestSet) {
-	interestSets := []model.InterestSet{}
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *ICABankenCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
 
+func (c *ICABankenCrawler) Crawl(channel chan<- model.InterestSet) {
 	crawlTime := time.Now().UTC()
 	rawHTML, err := c.httpClient.Fetch(icaBankenURL, nil)
 	if err != nil {
@@ -48,6 +51,25 @@ func (c *ICABankenCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	interestSets := c.parseRatesPage(rawHTML, crawlTime)
+	crawler.SetSource(interestSets, icaBankenURL, []byte(rawHTML))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates page again.
+func (c *ICABankenCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != icaBankenURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseRatesPage(string(content), fetchedAt), nil
+}
+
+// parseRatesPage extracts the list and average rates from the rates page.
+func (c *ICABankenCrawler) parseRatesPage(rawHTML string, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
+
 	listRates, err := c.extractListRates(rawHTML, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing ICA Banken List Rates", zap.Error(err))
@@ -62,9 +84,7 @@ func (c *ICABankenCrawler) Crawl(channel chan<- model.InterestSet) {
 		interestSets = append(interestSets, averageRates...)
 	}
 
-	for _, set := range interestSets {
-		channel <- set
-	}
+	return interestSets
 }
 
 func (c *ICABankenCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
@@ -78,6 +98,7 @@ func (c *ICABankenCrawler) extractListRates(rawHTML string, crawlTime time.Time)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse table: %w", err)
 	}
+	c.fingerprints.RecordTable(icaBankenURL, "list rates", table.Header)
 
 	// Table structure: Bindningstid | Ränta | Senast ändrad
 	interestSets := []model.InterestSet{}
@@ -115,6 +136,7 @@ func (c *ICABankenCrawler) extractListRates(rawHTML string, crawlTime time.Time)
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
 	return interestSets, nil
 }
 
@@ -130,6 +152,7 @@ func (c *ICABankenCrawler) extractAverageRates(rawHTML string, crawlTime time.Ti
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse table: %w", err)
 	}
+	c.fingerprints.RecordTable(icaBankenURL, "average rates", table.Header)
 
 	// Table structure: Månad | 3 mån | 1 år | 2 år | 3 år | 4 år | 5 år | 7 år | 10 år
 	// Header maps column index to term
@@ -173,7 +196,7 @@ func (c *ICABankenCrawler) extractAverageRates(rawHTML string, crawlTime time.Ti
 			if err != nil {
 				c.logger.Warn("failed to parse average rate",
 					zap.String("rate", rateStr),
-					zap.String("term", string(term)),
+					zap.Stringer("term", term),
 					zap.Error(err))
 				continue
 			}
@@ -189,49 +212,23 @@ func (c *ICABankenCrawler) extractAverageRates(rawHTML string, crawlTime time.Ti
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
 	return interestSets, nil
 }
 
-func parseICARate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.TrimSpace(str)
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseICARate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 func parseICADate(dateStr string) (time.Time, error) {
-	str := utils.NormalizeSpaces(dateStr)
-	matches := icaDateRegex.FindStringSubmatch(str)
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("date %q does not match expected format YYYY-MM-DD", dateStr)
-	}
-
-	year, _ := strconv.Atoi(matches[1])
-	month, _ := strconv.Atoi(matches[2])
-	day, _ := strconv.Atoi(matches[3])
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(dateStr)
 }
 
 func parseICAAvgMonth(monthStr string) (*model.AvgMonth, error) {
-	str := utils.NormalizeSpaces(monthStr)
-	matches := icaAvgMonthRegex.FindStringSubmatch(str)
-	if matches == nil {
-		return nil, fmt.Errorf("month %q does not match expected format 'YYYY MM'", monthStr)
+	month, err := utils.ParseMonthYear(monthStr)
+	if err != nil {
+		return nil, err
 	}
 
-	year, _ := strconv.Atoi(matches[1])
-	month, _ := strconv.Atoi(matches[2])
-
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(month),
-	}, nil
+	return &month, nil
 }
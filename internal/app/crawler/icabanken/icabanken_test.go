@@ -23,7 +23,7 @@ func assertICAListRateFields(t *testing.T, r model.InterestSet, crawlTime time.T
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -44,7 +44,7 @@ func assertICAAverageRateFields(t *testing.T, r model.InterestSet, crawlTime tim
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
@@ -207,7 +207,7 @@ func TestParseICARate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -255,7 +255,7 @@ func TestParseICARate(t *testing.T) {
 				t.Errorf("parseICARate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseICARate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -366,14 +366,18 @@ func TestParseICAAvgMonth(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:    "invalid format - month name",
-			input:   "November 2024",
-			wantErr: true,
+			name:      "month name",
+			input:     "November 2024",
+			wantMonth: time.November,
+			wantYear:  2024,
+			wantErr:   false,
 		},
 		{
-			name:    "invalid format - no space",
-			input:   "202411",
-			wantErr: true,
+			name:      "compact format",
+			input:     "202411",
+			wantMonth: time.November,
+			wantYear:  2024,
+			wantErr:   false,
 		},
 		{
 			name:    "empty string",
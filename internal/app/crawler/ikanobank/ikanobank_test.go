@@ -242,141 +242,6 @@ func TestIkanoBankCrawler_fetchAverageRates(t *testing.T) {
 	}
 }
 
-func TestParseIkanoBankRate(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name    string
-		input   string
-		want    float32
-		wantErr bool
-	}{
-		{
-			name:    "API format - 3.4800",
-			input:   "3.4800",
-			want:    3.48,
-			wantErr: false,
-		},
-		{
-			name:    "HTML format with comma - 3,61 %",
-			input:   "3,61 %",
-			want:    3.61,
-			wantErr: false,
-		},
-		{
-			name:    "HTML format without space - 3,61%",
-			input:   "3,61%",
-			want:    3.61,
-			wantErr: false,
-		},
-		{
-			name:    "simple decimal - 2.5",
-			input:   "2.5",
-			want:    2.5,
-			wantErr: false,
-		},
-		{
-			name:    "invalid - not a number",
-			input:   "abc",
-			wantErr: true,
-		},
-		{
-			name:    "invalid - empty string",
-			input:   "",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			got, err := parseIkanoBankRate(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseIkanoBankRate() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr && got != tt.want {
-				t.Errorf("parseIkanoBankRate() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestParseIkanoBankAvgMonth(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name      string
-		input     string
-		wantYear  uint
-		wantMonth time.Month
-		wantErr   bool
-	}{
-		{
-			name:      "valid - November 2024",
-			input:     "2024 11",
-			wantYear:  2024,
-			wantMonth: time.November,
-			wantErr:   false,
-		},
-		{
-			name:      "valid - January 2025",
-			input:     "2025 01",
-			wantYear:  2025,
-			wantMonth: time.January,
-			wantErr:   false,
-		},
-		{
-			name:      "valid - December 2024",
-			input:     "2024 12",
-			wantYear:  2024,
-			wantMonth: time.December,
-			wantErr:   false,
-		},
-		{
-			name:    "invalid - single digit month",
-			input:   "2024 1",
-			wantErr: true,
-		},
-		{
-			name:    "invalid - wrong format",
-			input:   "2024-01",
-			wantErr: true,
-		},
-		{
-			name:    "invalid - not a number",
-			input:   "abc",
-			wantErr: true,
-		},
-		{
-			name:    "invalid - empty string",
-			input:   "",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			got, err := parseIkanoBankAvgMonth(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseIkanoBankAvgMonth() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr {
-				if got.Year != tt.wantYear {
-					t.Errorf("parseIkanoBankAvgMonth() year = %v, want %v", got.Year, tt.wantYear)
-				}
-				if got.Month != tt.wantMonth {
-					t.Errorf("parseIkanoBankAvgMonth() month = %v, want %v", got.Month, tt.wantMonth)
-				}
-			}
-		})
-	}
-}
-
 func TestNewIkanoBankCrawler(t *testing.T) {
 	t.Parallel()
 
@@ -414,7 +279,7 @@ func assertIkanoBankListRateFields(t *testing.T, r model.InterestSet, crawlTime
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
@@ -432,7 +297,7 @@ func assertIkanoBankAverageRateFields(t *testing.T, r model.InterestSet, crawlTi
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
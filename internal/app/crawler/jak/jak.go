@@ -2,9 +2,6 @@ package jak
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
@@ -20,14 +17,9 @@ const (
 )
 
 var (
-	_             crawler.SiteCrawler = &JAKCrawler{}
-	jakRateRegex                      = regexp.MustCompile(`^(\d+[,.]?\d*)\s*%?$`)
-	jakMonthRegex                     = regexp.MustCompile(`^(\d{4})\s+(\d{1,2})$`)
-	// JAK's HTML is malformed in two ways:
-	// 1. Rows start with <td> directly instead of <tr><td>.
-	// 2. Cells start with <td> without closing the previous <td> with </td>.
-	jakMalformedRowRegex = regexp.MustCompile(`(</tr>|<tbody>)\s*<td`)
-	jakUnclosedCellRegex = regexp.MustCompile(`<td([^>]*)>\s*([^<]*)\s*<td`)
+	_ crawler.SiteCrawler    = &JAKCrawler{}
+	_ crawler.Fingerprinter  = &JAKCrawler{}
+	_ crawler.DocumentParser = &JAKCrawler{}
 )
 
 // JAKCrawler crawls JAK Medlemsbank's rates page.
@@ -36,12 +28,22 @@ var (
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type JAKCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewJAKCrawler(httpClient http.Client, logger *zap.Logger) *JAKCrawler {
-	return &JAKCrawler{httpClient: httpClient, logger: logger}
+	return &JAKCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(jakBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *JAKCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *JAKCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -59,14 +61,20 @@ func (c *JAKCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	crawler.SetSource(interestSets, jakRatesURL, []byte(rawHTML))
 	for _, set := range interestSets {
 		channel <- set
 	}
 }
 
-// extractRates parses both list and average rates from JAK's HTML page.
-// JAK has two tables: one for "3 månader" and one for "12 månader".
-// Each table contains both list rates (Listränta) and average rates (Snittränta).
+// ParseDocument parses an archived copy of the rates page again.
+func (c *JAKCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != jakRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.extractRates(string(content), fetchedAt)
+}
+
 func (c *JAKCrawler) extractRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
 	interestSets := make([]model.InterestSet, 0, 50)
 
@@ -90,15 +98,14 @@ func (c *JAKCrawler) extractRates(rawHTML string, crawlTime time.Time) ([]model.
 		return nil, fmt.Errorf("no rates extracted from JAK page")
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractRates")
 	return interestSets, nil
 }
 
 // extractTermRates extracts rates for a specific term from the table.
 func (c *JAKCrawler) extractTermRates(rawHTML, tableMarker string, term model.Term, crawlTime time.Time) ([]model.InterestSet, error) {
-	// Fix JAK's malformed HTML where rows start with <td> instead of <tr><td>
-	fixedHTML := fixMalformedTableHTML(rawHTML)
-
-	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(fixedHTML, tableMarker)
+	// JAK's table markup is malformed (rows without <tr>, cells without </td>), ParseTable implies the missing tags.
+	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, tableMarker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find table for %s: %w", tableMarker, err)
 	}
@@ -107,6 +114,7 @@ func (c *JAKCrawler) extractTermRates(rawHTML, tableMarker string, term model.Te
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse table for %s: %w", tableMarker, err)
 	}
+	c.fingerprints.RecordTable(jakRatesURL, tableMarker, table.Header)
 
 	return c.parseRateTable(table, term, crawlTime), nil
 }
@@ -174,85 +182,11 @@ func (c *JAKCrawler) parseRateTable(table utils.Table, term model.Term, crawlTim
 }
 
 // parseJAKRate parses a rate string like "3,58 %" or "3.24%".
-func (c *JAKCrawler) parseJAKRate(rateStr string) (float32, error) {
-	rateStr = utils.NormalizeSpaces(rateStr)
-	rateStr = strings.ReplaceAll(rateStr, ",", ".")
-	rateStr = strings.ReplaceAll(rateStr, " ", "")
-	rateStr = strings.TrimSuffix(rateStr, "%")
-
-	// Handle double percent signs like "3,58 % %"
-	rateStr = strings.TrimSuffix(rateStr, "%")
-	rateStr = strings.TrimSpace(rateStr)
-
-	// Skip empty or dash values
-	if rateStr == "" || rateStr == "-" {
-		return 0, fmt.Errorf("empty or missing rate")
-	}
-
-	matches := jakRateRegex.FindStringSubmatch(rateStr)
-	if len(matches) != 2 {
-		return 0, fmt.Errorf("failed to match rate regex: %s", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(matches[1], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate: %w", err)
-	}
-
-	return float32(rate), nil
-}
-
-// fixMalformedTableHTML fixes JAK's malformed HTML where:
-// 1. Table rows start with <td> directly instead of <tr><td>
-// 2. Table cells start with <td> without closing the previous cell with </td>
-// This is a workaround for broken HTML from jak.se.
-func fixMalformedTableHTML(htmlStr string) string {
-	// First fix: unclosed cells like "<td>content<td>" -> "<td>content</td><td>"
-	// We need to loop because the regex can't handle overlapping matches
-	for {
-		newHTML := jakUnclosedCellRegex.ReplaceAllString(htmlStr, "<td$1>$2</td><td")
-		if newHTML == htmlStr {
-			break
-		}
-		htmlStr = newHTML
-	}
-
-	// Second fix: missing <tr> tags like "</tr><td" -> "</tr><tr><td"
-	htmlStr = jakMalformedRowRegex.ReplaceAllStringFunc(htmlStr, func(match string) string {
-		if strings.HasPrefix(match, "</tr>") {
-			return "</tr><tr><td"
-		}
-		return "<tbody><tr><td"
-	})
-
-	return htmlStr
+func (c *JAKCrawler) parseJAKRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseJAKMonth parses a month string like "2025 11" to AvgMonth.
 func (c *JAKCrawler) parseJAKMonth(monthStr string) (model.AvgMonth, error) {
-	monthStr = utils.NormalizeSpaces(monthStr)
-
-	matches := jakMonthRegex.FindStringSubmatch(monthStr)
-	if len(matches) != 3 {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %s", monthStr)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month number: %w", err)
-	}
-
-	if month < 1 || month > 12 {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %d", month)
-	}
-
-	return model.AvgMonth{
-		Month: time.Month(month),
-		Year:  uint(year),
-	}, nil
+	return utils.ParseMonthYear(monthStr)
 }
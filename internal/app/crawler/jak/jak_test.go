@@ -3,6 +3,8 @@ package jak
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -136,6 +138,37 @@ func TestJAKCrawler_extractRates(t *testing.T) {
 	}
 }
 
+// JAK only lends to its members and offers all of them the same rate for the same kind of loan, so the list rate is the
+// member rate and there is no separate member or union discount to extract.
+func TestJAKCrawler_extractRates_memberRates(t *testing.T) {
+	t.Parallel()
+
+	goldenHTML := crawlertest.LoadGoldenFile(t, "testdata/jak_rates.html")
+	if !strings.Contains(goldenHTML, "Vi erbjuder alla samma räntor för samma typ av bolån") {
+		t.Fatal("golden file no longer states that all members get the same rate")
+	}
+
+	crawler := &JAKCrawler{logger: zap.NewNop()}
+	results, err := crawler.extractRates(goldenHTML, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("extractRates() error = %v", err)
+	}
+
+	listRates := map[model.Term]int{}
+	for _, r := range results {
+		if r.Type == model.TypeListRate {
+			listRates[r.Term]++
+		}
+		if r.Type == model.TypeUnionDiscounted || r.UnionDiscount || r.MaxEnergyClass != "" {
+			t.Errorf("got discounted set %s %s, want only list and average rates", r.Type, r.Term)
+		}
+	}
+	want := map[model.Term]int{model.Term3months: 1, model.Term1year: 1}
+	if !reflect.DeepEqual(listRates, want) {
+		t.Errorf("list rates per term = %v, want %v", listRates, want)
+	}
+}
+
 func TestJAKCrawler_parseJAKRate(t *testing.T) {
 	t.Parallel()
 
@@ -145,7 +178,7 @@ func TestJAKCrawler_parseJAKRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -202,9 +235,7 @@ func TestJAKCrawler_parseJAKRate(t *testing.T) {
 				return
 			}
 			if !tt.wantErr {
-				// Compare with tolerance for float comparison
-				diff := got - tt.want
-				if diff > 0.001 || diff < -0.001 {
+				if got != model.RateFromPercent(tt.want) {
 					t.Errorf("parseJAKRate() = %v, want %v", got, tt.want)
 				}
 			}
@@ -254,9 +285,11 @@ func TestJAKCrawler_parseJAKMonth(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:    "invalid format - with day",
-			input:   "2025-11-30",
-			wantErr: true,
+			name:      "full date yields its month",
+			input:     "2025-11-30",
+			wantYear:  2025,
+			wantMonth: time.November,
+			wantErr:   false,
 		},
 		{
 			name:    "invalid month 00",
@@ -338,7 +371,7 @@ func assertJAKRateFields(t *testing.T, r model.InterestSet, crawlTime time.Time)
 		t.Errorf("Type = %q, want TypeListRate or TypeAverageRate", r.Type)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.Type == model.TypeAverageRate && r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil for average rate")
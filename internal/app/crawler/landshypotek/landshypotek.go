@@ -2,7 +2,6 @@ package landshypotek
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,20 +18,33 @@ const (
 	landshypotekBankName model.Bank = "Landshypotek"
 )
 
-var _ crawler.SiteCrawler = &LandshypotekCrawler{}
+var (
+	_ crawler.SiteCrawler    = &LandshypotekCrawler{}
+	_ crawler.Fingerprinter  = &LandshypotekCrawler{}
+	_ crawler.DocumentParser = &LandshypotekCrawler{}
+)
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type LandshypotekCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewLandshypotekCrawler(httpClient http.Client, logger *zap.Logger) *LandshypotekCrawler {
-	return &LandshypotekCrawler{httpClient: httpClient, logger: logger}
+	return &LandshypotekCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(landshypotekBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *LandshypotekCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
-	interestSets := []model.InterestSet{}
 	crawlTime := time.Now().UTC()
 
 	// Fetch rates page
@@ -42,10 +54,27 @@ func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
-	// Extract discounted rates (visible on page load) - 60% LTV tier
-	var discounted60Rates, discounted75Rates, listRates, avgRates, historicalRates []model.InterestSet
+	interestSets := c.parseRatesPage(html, crawlTime)
+	crawler.SetSource(interestSets, landshypotekRatesURL, []byte(html))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates page again.
+func (c *LandshypotekCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != landshypotekRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseRatesPage(string(content), fetchedAt), nil
+}
+
+// parseRatesPage extracts the discounted, list and average rates from the rates page.
+func (c *LandshypotekCrawler) parseRatesPage(html string, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
 
-	discounted60Rates, err = c.extractDiscountedRates(html, crawlTime, "belåningsgrad 60", 0, 60)
+	// Extract discounted rates (visible on page load) - 60% LTV tier
+	discounted60Rates, err := c.extractDiscountedRates(html, crawlTime, "belåningsgrad 60", 0, 60)
 	if err != nil {
 		c.logger.Error("failed parsing Landshypotek discounted rates (60% LTV)", zap.Error(err))
 	} else {
@@ -53,7 +82,7 @@ func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 
 	// Extract discounted rates (visible on page load) - 75% LTV tier
-	discounted75Rates, err = c.extractDiscountedRates(html, crawlTime, "belåningsgrad 75", 60, 75)
+	discounted75Rates, err := c.extractDiscountedRates(html, crawlTime, "belåningsgrad 75", 60, 75)
 	if err != nil {
 		c.logger.Error("failed parsing Landshypotek discounted rates (75% LTV)", zap.Error(err))
 	} else {
@@ -61,7 +90,7 @@ func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 
 	// Extract list rates (in accordion, before discount)
-	listRates, err = c.extractListRates(html, crawlTime)
+	listRates, err := c.extractListRates(html, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing Landshypotek list rates", zap.Error(err))
 	} else {
@@ -69,7 +98,7 @@ func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 
 	// Extract average rates for current month (in accordion)
-	avgRates, err = c.extractCurrentMonthAverageRates(html, crawlTime)
+	avgRates, err := c.extractCurrentMonthAverageRates(html, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing Landshypotek current month average rates", zap.Error(err))
 	} else {
@@ -77,17 +106,14 @@ func (c *LandshypotekCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 
 	// Extract historical average rates (in accordion)
-	historicalRates, err = c.extractHistoricalAverageRates(html, crawlTime)
+	historicalRates, err := c.extractHistoricalAverageRates(html, crawlTime)
 	if err != nil {
 		c.logger.Error("failed parsing Landshypotek historical average rates", zap.Error(err))
 	} else {
 		interestSets = append(interestSets, historicalRates...)
 	}
 
-	// Log individual rates
-	for _, set := range interestSets {
-		channel <- set
-	}
+	return interestSets
 }
 
 func (c *LandshypotekCrawler) extractDiscountedRates(
@@ -106,6 +132,7 @@ func (c *LandshypotekCrawler) extractDiscountedRates(
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse discounted rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(landshypotekRatesURL, searchText, table.Header)
 
 	// Table structure: Bindningstid | Ränta | Effektiv ränta
 	interestSets := []model.InterestSet{}
@@ -140,6 +167,7 @@ func (c *LandshypotekCrawler) extractDiscountedRates(
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractDiscountedRates")
 	return interestSets, nil
 }
 
@@ -150,7 +178,9 @@ func (c *LandshypotekCrawler) extractListRates(rawHTML string, crawlTime time.Ti
 		return nil, err
 	}
 
-	return c.parseListRatesTable(&table, crawlTime), nil
+	interestSets := c.parseListRatesTable(&table, crawlTime)
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
+	return interestSets, nil
 }
 
 func (c *LandshypotekCrawler) findListRatesTable(rawHTML string) (utils.Table, error) {
@@ -170,6 +200,7 @@ func (c *LandshypotekCrawler) findListRatesTable(rawHTML string) (utils.Table, e
 	if err != nil {
 		return utils.Table{}, fmt.Errorf("failed to parse list rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(landshypotekRatesURL, "list rates", table.Header)
 
 	return table, nil
 }
@@ -224,7 +255,9 @@ func (c *LandshypotekCrawler) extractCurrentMonthAverageRates(
 		return nil, err
 	}
 
-	return c.parseAverageRatesTable(&table, avgMonth, crawlTime), nil
+	interestSets := c.parseAverageRatesTable(&table, avgMonth, crawlTime)
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractCurrentMonthAverageRates")
+	return interestSets, nil
 }
 
 func (c *LandshypotekCrawler) findCurrentMonthAverageRatesTable(
@@ -270,6 +303,7 @@ func (c *LandshypotekCrawler) findCurrentMonthAverageRatesTable(
 	if err != nil {
 		return model.AvgMonth{}, utils.Table{}, fmt.Errorf("failed to parse average rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(landshypotekRatesURL, "average rates", table.Header)
 
 	return avgMonth, table, nil
 }
@@ -337,7 +371,9 @@ func (c *LandshypotekCrawler) extractHistoricalAverageRates(
 		return nil, fmt.Errorf("no valid terms found in header")
 	}
 
-	return c.parseHistoricalRatesTable(&table, terms, crawlTime), nil
+	interestSets := c.parseHistoricalRatesTable(&table, terms, crawlTime)
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractHistoricalAverageRates")
+	return interestSets, nil
 }
 
 func (c *LandshypotekCrawler) findHistoricalAverageRatesTable(rawHTML string) (utils.Table, error) {
@@ -357,6 +393,7 @@ func (c *LandshypotekCrawler) findHistoricalAverageRatesTable(rawHTML string) (u
 	if err != nil {
 		return utils.Table{}, fmt.Errorf("failed to parse historical rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(landshypotekRatesURL, "historical average rates", table.Header)
 
 	if len(table.Header) < 3 {
 		return utils.Table{}, fmt.Errorf("invalid table header: expected at least 3 columns, got %d", len(table.Header))
@@ -422,91 +459,19 @@ func (c *LandshypotekCrawler) parseHistoricalRatesTable(
 	return interestSets
 }
 
-func parseLandshypotekRate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.ReplaceAll(str, "\u00a0", "") // Remove non-breaking spaces
-	str = strings.TrimSpace(str)
-
-	// Handle n/a or empty values
-	if str == "" || str == "-" || strings.ToLower(str) == "n/a" {
-		return 0, fmt.Errorf("empty or invalid rate: %q", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseLandshypotekRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 func parseLandshypotekChangeDate(dateStr string) (time.Time, error) {
 	// Format: "1 oktober 2025" or "20 november 2025"
-	str := utils.NormalizeSpaces(dateStr)
-	str = strings.TrimSpace(str)
-
-	// Parse using Swedish month names
-	monthMap := map[string]time.Month{
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-	}
-
-	parts := strings.Fields(str)
-	if len(parts) != 3 {
-		return time.Time{}, fmt.Errorf("invalid date format: %q", dateStr)
-	}
-
-	day, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day: %q", parts[0])
-	}
-
-	monthName := strings.ToLower(parts[1])
-	month, ok := monthMap[monthName]
-	if !ok {
-		return time.Time{}, fmt.Errorf("invalid month: %q", parts[1])
-	}
-
-	year, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid year: %q", parts[2])
-	}
-
-	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(dateStr)
 }
 
 func parseLandshypotekAvgMonth(monthStr string, crawlTime time.Time) (model.AvgMonth, error) {
-	monthMap := map[string]time.Month{
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-	}
-
-	monthName := strings.ToLower(strings.TrimSpace(monthStr))
-	month, ok := monthMap[monthName]
-	if !ok {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %q", monthStr)
+	month, err := utils.ParseSwedishMonth(monthStr)
+	if err != nil {
+		return model.AvgMonth{}, err
 	}
 
 	// Infer year based on current time
@@ -523,33 +488,6 @@ func parseLandshypotekAvgMonth(monthStr string, crawlTime time.Time) (model.AvgM
 }
 
 func parseLandshypotekHistoricalMonth(yearStr, monthStr string) (model.AvgMonth, error) {
-	monthMap := map[string]time.Month{
-		"Januari":   time.January,
-		"Februari":  time.February,
-		"Mars":      time.March,
-		"April":     time.April,
-		"Maj":       time.May,
-		"Juni":      time.June,
-		"Juli":      time.July,
-		"Augusti":   time.August,
-		"September": time.September,
-		"Oktober":   time.October,
-		"November":  time.November,
-		"December":  time.December,
-	}
-
-	month, ok := monthMap[strings.TrimSpace(monthStr)]
-	if !ok {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %q", monthStr)
-	}
-
-	year, err := strconv.ParseUint(strings.TrimSpace(yearStr), 10, 32)
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("invalid year: %q", yearStr)
-	}
-
-	return model.AvgMonth{
-		Month: month,
-		Year:  uint(year),
-	}, nil
+	// The historical table has one column per month name ("Oktober") and one row per year.
+	return utils.ParseMonthYear(monthStr + " " + yearStr)
 }
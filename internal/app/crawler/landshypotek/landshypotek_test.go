@@ -3,6 +3,7 @@ package landshypotek
 
 import (
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -23,7 +24,7 @@ func assertLandshypotekListRateFields(t *testing.T, r model.InterestSet, crawlTi
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
@@ -101,6 +102,40 @@ func TestLandshypotekCrawler_Crawl(t *testing.T) {
 	}
 }
 
+func TestLandshypotekCrawler_Crawl_Source(t *testing.T) {
+	t.Parallel()
+
+	html := crawlertest.LoadGoldenFile(t, "testdata/landshypotek_rates.html")
+	mockClient := &httpmock.ClientMock{
+		FetchFunc: func(_ string, _ map[string]string) (string, error) {
+			return html, nil
+		},
+	}
+	results := crawlertest.RunCrawl(t, NewLandshypotekCrawler(mockClient, zap.NewNop()))
+	if len(results) == 0 {
+		t.Fatal("Crawl() returned no rates")
+	}
+
+	// All tables are on one page, the method tells them apart.
+	wantMethods := map[model.Type][]string{
+		model.TypeRatioDiscounted: {"extractDiscountedRates"},
+		model.TypeListRate:        {"extractListRates"},
+		model.TypeAverageRate:     {"extractCurrentMonthAverageRates", "extractHistoricalAverageRates"},
+	}
+	hash := model.HashContent([]byte(html))
+	for _, r := range results {
+		if r.Source == nil {
+			t.Fatalf("Source of %s is nil", r.Key())
+		}
+		if r.Source.URL != landshypotekRatesURL || r.Source.Kind != model.SourceKindHTML || r.Source.Hash != hash {
+			t.Errorf("Source of %s = %+v, want the HTML rates page", r.Key(), r.Source)
+		}
+		if !slices.Contains(wantMethods[r.Type], r.Source.Method) {
+			t.Errorf("Source.Method of %s = %q, want one of %v", r.Key(), r.Source.Method, wantMethods[r.Type])
+		}
+	}
+}
+
 func TestLandshypotekCrawler_extractListRates(t *testing.T) {
 	t.Parallel()
 
@@ -124,7 +159,7 @@ func TestLandshypotekCrawler_extractListRates(t *testing.T) {
 	}
 
 	// Validate specific known values from golden file (list rates from accordion)
-	expectedRates := map[model.Term]float32{
+	expectedRates := map[model.Term]float64{
 		model.Term3months: 3.04,
 		model.Term1year:   3.19,
 		model.Term2years:  3.40,
@@ -139,8 +174,8 @@ func TestLandshypotekCrawler_extractListRates(t *testing.T) {
 			t.Errorf("unexpected term in results: %q", r.Term)
 			continue
 		}
-		if r.NominalRate != expectedRate {
-			t.Errorf("rate for term %q = %f, want %f", r.Term, r.NominalRate, expectedRate)
+		if r.NominalRate != model.RateFromPercent(expectedRate) {
+			t.Errorf("rate for term %q = %v, want %v", r.Term, r.NominalRate, expectedRate)
 		}
 	}
 }
@@ -151,7 +186,7 @@ func TestParseLandshypotekRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -200,7 +235,7 @@ func TestParseLandshypotekRate(t *testing.T) {
 				t.Errorf("parseLandshypotekRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseLandshypotekRate() = %v, want %v", got, tt.want)
 			}
 		})
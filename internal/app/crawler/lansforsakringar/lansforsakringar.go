@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -25,44 +24,36 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &LansforsakringarCrawler{}
+	_ crawler.SiteCrawler    = &LansforsakringarCrawler{}
+	_ crawler.Fingerprinter  = &LansforsakringarCrawler{}
+	_ crawler.DocumentParser = &LansforsakringarCrawler{}
 
 	// lfAvgMonthRegex matches "Genomsnittlig ränta oktober 2025" or similar in table header.
-	lfAvgMonthRegex = regexp.MustCompile(`(?i)genomsnittlig\s+ränta\s+(\w+)\s+(\d{4})`)
-	// lfListDateRegex matches "YYYY-MM-DD" format used in list rates table.
-	lfListDateRegex = regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})`)
+	lfAvgMonthRegex = regexp.MustCompile(`(?i)genomsnittlig\s+ränta\s+(\S+\s+\d{4})`)
 	// lfPDFDateRegex matches "YYYYMMDD" format used in PDF (e.g., "20251031" = October 31, 2025).
 	lfPDFDateRegex = regexp.MustCompile(`^(20\d{2})(\d{2})(\d{2})$`)
 	// lfPDFRateRegex matches Swedish decimal rates like "2,70" or "3,84".
 	lfPDFRateRegex = regexp.MustCompile(`^\d+,\d+$`)
 )
 
-// lfSwedishMonthMap maps Swedish month names to time.Month.
-//
-//nolint:gochecknoglobals // constant lookup map to reduce cyclomatic complexity
-var lfSwedishMonthMap = map[string]time.Month{
-	"januari":   time.January,
-	"februari":  time.February,
-	"mars":      time.March,
-	"april":     time.April,
-	"maj":       time.May,
-	"juni":      time.June,
-	"juli":      time.July,
-	"augusti":   time.August,
-	"september": time.September,
-	"oktober":   time.October,
-	"november":  time.November,
-	"december":  time.December,
-}
-
 //nolint:revive // Bank name prefix is intentional for clarity
 type LansforsakringarCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewLansforsakringarCrawler(httpClient http.Client, logger *zap.Logger) *LansforsakringarCrawler {
-	return &LansforsakringarCrawler{httpClient: httpClient, logger: logger}
+	return &LansforsakringarCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(lfBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *LansforsakringarCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *LansforsakringarCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -79,6 +70,7 @@ func (c *LansforsakringarCrawler) Crawl(channel chan<- model.InterestSet) {
 	if err != nil {
 		c.logger.Error("failed parsing Länsförsäkringar list rates", zap.Error(err))
 	} else {
+		crawler.SetSource(listRates, lfRatesURL, []byte(rawHTML))
 		for _, set := range listRates {
 			channel <- set
 		}
@@ -95,82 +87,61 @@ func (c *LansforsakringarCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
-// lfColumnIndices holds the column indices for rate and date in the list rates table.
-type lfColumnIndices struct {
-	rate int
-	date int
-}
-
-// findListRateColumns finds the rate and date column indices in the list rates table header.
-func findListRateColumns(header []string) lfColumnIndices {
-	indices := lfColumnIndices{rate: -1, date: -1}
-	for i, col := range header {
-		colLower := strings.ToLower(col)
-		if strings.Contains(colLower, "ränta") && !strings.Contains(colLower, "ändring") {
-			indices.rate = i
-		}
-		if strings.Contains(colLower, "datum") {
-			indices.date = i
-		}
+// ParseDocument parses an archived copy of the rates page or the average rates PDF again.
+func (c *LansforsakringarCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case lfRatesURL:
+		return c.extractListRates(string(content), fetchedAt)
+	case lfAvgRatesPDFURL:
+		return c.parsePDF(content, fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
 	}
-	return indices
 }
 
+// extractListRates parses the list rates table: Bindningstid | Ränta | Ändring | Datum.
 func (c *LansforsakringarCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Bindningstid")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find list rates table: %w", err)
-	}
-
-	table, err := utils.ParseTable(tokenizer)
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Bindningstid"},
+		Columns: []utils.TableColumn{
+			{Name: "term", Index: 0},
+			{Name: "rate", Match: utils.HeaderContains("ränta").Except(utils.HeaderContains("ändring"))},
+			{Name: "date", Match: utils.HeaderContains("datum"), Optional: true},
+		},
+	})
+	c.fingerprints.RecordTable(lfRatesURL, "list rates", table.Header)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse list rates table: %w", err)
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
 	}
 
-	cols := findListRateColumns(table.Header)
-	if cols.rate == -1 {
-		return nil, fmt.Errorf("could not find rate column in list rates table")
-	}
-
-	return c.parseListRateRows(table.Rows, cols, crawlTime), nil
-}
-
-// parseListRateRows parses the list rate rows from the table.
-func (c *LansforsakringarCrawler) parseListRateRows(rows [][]string, cols lfColumnIndices, crawlTime time.Time) []model.InterestSet {
 	interestSets := []model.InterestSet{}
-	for _, row := range rows {
-		set, ok := c.parseListRateRow(row, cols, crawlTime)
+	for _, record := range table.Records {
+		set, ok := c.parseListRateRecord(record, crawlTime)
 		if ok {
 			interestSets = append(interestSets, set)
 		}
 	}
-	return interestSets
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
+	return interestSets, nil
 }
 
-// parseListRateRow parses a single list rate row.
-func (c *LansforsakringarCrawler) parseListRateRow(row []string, cols lfColumnIndices, crawlTime time.Time) (model.InterestSet, bool) {
-	if len(row) <= cols.rate {
-		c.logger.Warn("skipping row with insufficient columns", zap.Strings("row", row))
-		return model.InterestSet{}, false
-	}
-
-	term, err := utils.ParseTerm(row[0])
+// parseListRateRecord parses a single list rate row.
+func (c *LansforsakringarCrawler) parseListRateRecord(record utils.TableRecord, crawlTime time.Time) (model.InterestSet, bool) {
+	term, err := utils.ParseTerm(record.Get("term"))
 	if err != nil {
-		c.logger.Warn("failed to parse term", zap.String("term", row[0]), zap.Error(err))
+		c.logger.Warn("failed to parse term", zap.String("term", record.Get("term")), zap.Error(err))
 		return model.InterestSet{}, false
 	}
 
-	rate, err := parseLFRate(row[cols.rate])
+	rate, err := parseLFRate(record.Get("rate"))
 	if err != nil {
-		c.logger.Warn("failed to parse rate", zap.String("rate", row[cols.rate]), zap.Error(err))
+		c.logger.Warn("failed to parse rate", zap.String("rate", record.Get("rate")), zap.Error(err))
 		return model.InterestSet{}, false
 	}
 
 	var changedOn *time.Time
-	if cols.date >= 0 && cols.date < len(row) {
-		if parsed, err := parseLFListDate(row[cols.date]); err == nil {
-			changedOn = &parsed
-		}
+	if parsed, err := parseLFListDate(record.Get("date")); err == nil {
+		changedOn = &parsed
 	}
 
 	return model.InterestSet{
@@ -183,68 +154,31 @@ func (c *LansforsakringarCrawler) parseListRateRow(row []string, cols lfColumnIn
 	}, true
 }
 
-// parseLFRate parses a Swedish format rate like "3,84 %" to float32.
-func parseLFRate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.TrimSpace(str)
-
-	if str == "" || str == "-" {
-		return 0, fmt.Errorf("empty or invalid rate: %q", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+// parseLFRate parses a Swedish format rate like "3,84 %".
+func parseLFRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseLFListDate parses a date in YYYY-MM-DD format.
 func parseLFListDate(dateStr string) (time.Time, error) {
-	str := utils.NormalizeSpaces(dateStr)
-	matches := lfListDateRegex.FindStringSubmatch(str)
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("date %q does not match expected format YYYY-MM-DD", dateStr)
-	}
-
-	year, _ := strconv.Atoi(matches[1])
-	month, _ := strconv.Atoi(matches[2])
-	day, _ := strconv.Atoi(matches[3])
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return utils.FindSwedishDate(dateStr)
 }
 
 // parseLFAvgMonth parses the average month from header text like "Genomsnittlig ränta oktober 2025".
 func parseLFAvgMonth(headerStr string) (*model.AvgMonth, error) {
 	str := utils.NormalizeSpaces(headerStr)
-	str = strings.ToLower(str)
 
 	matches := lfAvgMonthRegex.FindStringSubmatch(str)
 	if matches == nil {
 		return nil, fmt.Errorf("header %q does not match expected format 'Genomsnittlig ränta month YYYY'", headerStr)
 	}
 
-	monthName := matches[1]
-	year, _ := strconv.Atoi(matches[2])
-
-	month, ok := lfSwedishMonthToTime(monthName)
-	if !ok {
-		return nil, fmt.Errorf("unknown Swedish month: %q", monthName)
+	month, err := utils.ParseMonthYear(matches[1])
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: month,
-	}, nil
-}
-
-// lfSwedishMonthToTime converts Swedish month names to time.Month.
-func lfSwedishMonthToTime(monthName string) (time.Month, bool) {
-	m, ok := lfSwedishMonthMap[monthName]
-	return m, ok
+	return &month, nil
 }
 
 // fetchAverageRatesFromPDF fetches and parses the historical average rates PDF.
@@ -254,7 +188,13 @@ func (c *LansforsakringarCrawler) fetchAverageRatesFromPDF(crawlTime time.Time)
 		return nil, fmt.Errorf("failed to fetch PDF: %w", err)
 	}
 
-	return c.parsePDF(pdfContent, crawlTime)
+	avgRates, err := c.parsePDF(pdfContent, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(avgRates, lfAvgRatesPDFURL, pdfContent)
+	return avgRates, nil
 }
 
 // parsePDF extracts average rates from the Länsförsäkringar PDF document.
@@ -283,13 +223,24 @@ func (c *LansforsakringarCrawler) parsePDF(pdfContent []byte, crawlTime time.Tim
 		allText.WriteString("\n")
 	}
 
-	return c.parseAverageRatesPDFText(allText.String(), crawlTime)
+	interestSets, err := c.parseAverageRatesPDFText(allText.String(), crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindPDF, "parsePDF")
+	return interestSets, nil
 }
 
 // parseAverageRatesPDFText extracts average rates from the PDF text content.
 func (c *LansforsakringarCrawler) parseAverageRatesPDFText(text string, crawlTime time.Time) ([]model.InterestSet, error) {
 	// Extract terms from header
 	terms := extractLFTermsFromHeader(text)
+	tokens := make([]string, 0, len(terms))
+	for _, term := range terms {
+		tokens = append(tokens, term.String())
+	}
+	c.fingerprints.RecordTokens(lfAvgRatesPDFURL, "average rates PDF header", tokens)
 	if len(terms) == 0 {
 		return nil, fmt.Errorf("could not find terms in PDF header")
 	}
@@ -380,28 +331,22 @@ func (c *LansforsakringarCrawler) parsePDFDataRows(text string, terms []model.Te
 
 // parseLFPDFDate parses a date in YYYYMMDD format to year and month.
 func parseLFPDFDate(dateStr string) (*model.AvgMonth, bool) {
-	matches := lfPDFDateRegex.FindStringSubmatch(dateStr)
-	if matches == nil {
+	if !lfPDFDateRegex.MatchString(dateStr) {
 		return nil, false
 	}
 
-	year, _ := strconv.Atoi(matches[1])
-	month, _ := strconv.Atoi(matches[2])
-
-	if month < 1 || month > 12 {
+	month, err := utils.ParseMonthYear(dateStr)
+	if err != nil {
 		return nil, false
 	}
 
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(month),
-	}, true
+	return &month, true
 }
 
 // collectLFPDFRates collects rate values from tokens starting at the given index.
 // Returns -1 for empty/missing rates.
-func collectLFPDFRates(tokens []string, startIdx int, maxRates int) []float32 {
-	rates := make([]float32, 0, maxRates)
+func collectLFPDFRates(tokens []string, startIdx int, maxRates int) []model.Rate {
+	rates := make([]model.Rate, 0, maxRates)
 
 	for i := startIdx; i < len(tokens) && len(rates) < maxRates; i++ {
 		token := tokens[i]
@@ -24,7 +24,7 @@ func assertLFListRateFields(t *testing.T, r model.InterestSet, crawlTime time.Ti
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
@@ -239,7 +239,7 @@ func validatePDFResult(t *testing.T, r model.InterestSet) {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 }
 
@@ -249,7 +249,7 @@ func TestParseLFRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -302,7 +302,7 @@ func TestParseLFRate(t *testing.T) {
 				t.Errorf("parseLFRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseLFRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -440,45 +440,6 @@ func TestParseLFAvgMonth(t *testing.T) {
 	}
 }
 
-func TestLfSwedishMonthToTime(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		input  string
-		want   time.Month
-		wantOK bool
-	}{
-		{"januari", time.January, true},
-		{"februari", time.February, true},
-		{"mars", time.March, true},
-		{"april", time.April, true},
-		{"maj", time.May, true},
-		{"juni", time.June, true},
-		{"juli", time.July, true},
-		{"augusti", time.August, true},
-		{"september", time.September, true},
-		{"oktober", time.October, true},
-		{"november", time.November, true},
-		{"december", time.December, true},
-		{"invalid", 0, false},
-		{"", 0, false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			t.Parallel()
-
-			got, ok := lfSwedishMonthToTime(tt.input)
-			if ok != tt.wantOK {
-				t.Errorf("lfSwedishMonthToTime() ok = %v, want %v", ok, tt.wantOK)
-			}
-			if tt.wantOK && got != tt.want {
-				t.Errorf("lfSwedishMonthToTime() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestNewLansforsakringarCrawler(t *testing.T) {
 	t.Parallel()
 
@@ -630,35 +591,35 @@ func TestCollectLFPDFRates(t *testing.T) {
 		tokens   []string
 		startIdx int
 		maxRates int
-		want     []float32
+		want     []float64
 	}{
 		{
 			name:     "typical row with all rates",
 			tokens:   []string{"20251031", "2,70", "2,66", "3,07", "3,16", "3,31", "3,39", "3,85"},
 			startIdx: 1,
 			maxRates: 8,
-			want:     []float32{2.70, 2.66, 3.07, 3.16, 3.31, 3.39, 3.85},
+			want:     []float64{2.70, 2.66, 3.07, 3.16, 3.31, 3.39, 3.85},
 		},
 		{
 			name:     "stops at next date",
 			tokens:   []string{"20251031", "2,70", "2,66", "20250930", "3,04"},
 			startIdx: 1,
 			maxRates: 8,
-			want:     []float32{2.70, 2.66},
+			want:     []float64{2.70, 2.66},
 		},
 		{
 			name:     "respects maxRates",
 			tokens:   []string{"20251031", "2,70", "2,66", "3,07", "3,16", "3,31"},
 			startIdx: 1,
 			maxRates: 3,
-			want:     []float32{2.70, 2.66, 3.07},
+			want:     []float64{2.70, 2.66, 3.07},
 		},
 		{
 			name:     "empty tokens",
 			tokens:   []string{},
 			startIdx: 0,
 			maxRates: 8,
-			want:     []float32{},
+			want:     []float64{},
 		},
 	}
 
@@ -674,7 +635,7 @@ func TestCollectLFPDFRates(t *testing.T) {
 			}
 
 			for i, wantRate := range tt.want {
-				if got[i] != wantRate {
+				if got[i] != model.RateFromPercent(wantRate) {
 					t.Errorf("collectLFPDFRates() rate[%d] = %v, want %v", i, got[i], wantRate)
 				}
 			}
@@ -1,14 +1,12 @@
 package marginalen
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/embedded"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
 	"github.com/yama6a/bolan-compare/internal/pkg/utils"
@@ -23,31 +21,29 @@ const (
 )
 
 var (
-	// marginalenRateRegex matches rates like "5,92 %" or "6.35%".
-	marginalenRateRegex = regexp.MustCompile(`^(\d+[,.]?\d*)\s*%?$`)
-	// marginalenPeriodRegex matches "YYYYMM" format like "202412".
-	marginalenPeriodRegex = regexp.MustCompile(`^(\d{4})(\d{2})$`)
+	_ crawler.SiteCrawler    = &MarginalenCrawler{}
+	_ crawler.Fingerprinter  = &MarginalenCrawler{}
+	_ crawler.DocumentParser = &MarginalenCrawler{}
 )
 
-var _ crawler.SiteCrawler = &MarginalenCrawler{}
-
-// episerverResponse represents the Episerver CMS API response structure.
-type episerverResponse []struct {
-	MainContentArea []struct {
-		MainContentArea []struct {
-			Body string `json:"body"`
-		} `json:"mainContentArea"`
-	} `json:"mainContentArea"`
-}
-
 //nolint:revive // Bank name prefix is intentional for clarity
 type MarginalenCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewMarginalenCrawler(httpClient http.Client, logger *zap.Logger) *MarginalenCrawler {
-	return &MarginalenCrawler{httpClient: httpClient, logger: logger}
+	return &MarginalenCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(marginalenBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *MarginalenCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *MarginalenCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -67,15 +63,8 @@ func (c *MarginalenCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
-	// Parse the Episerver API JSON response
-	var apiResponse episerverResponse
-	if err := json.Unmarshal([]byte(jsonData), &apiResponse); err != nil {
-		c.logger.Error("failed parsing Marginalen API JSON", zap.Error(err))
-		return
-	}
-
 	// Extract the HTML content from the JSON structure
-	htmlContent, err := c.extractHTMLFromAPI(apiResponse)
+	htmlContent, err := c.extractHTMLFromAPI(jsonData)
 	if err != nil {
 		c.logger.Error("failed extracting HTML from Marginalen API response", zap.Error(err))
 		return
@@ -87,27 +76,38 @@ func (c *MarginalenCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	crawler.SetSource(avgRates, marginalenAPIURL, []byte(jsonData))
 	for _, set := range avgRates {
 		channel <- set
 	}
 }
 
-// extractHTMLFromAPI extracts the HTML body content from Episerver API response.
-// The API returns JSON with nested structure: [0].mainContentArea[0].mainContentArea[0].body.
-func (c *MarginalenCrawler) extractHTMLFromAPI(response episerverResponse) (string, error) {
-	if len(response) == 0 {
-		return "", fmt.Errorf("empty API response")
+// ParseDocument parses an archived copy of the content API response again.
+func (c *MarginalenCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != marginalenAPIURL {
+		return nil, crawler.ErrUnknownSource
+	}
+
+	htmlContent, err := c.extractHTMLFromAPI(string(content))
+	if err != nil {
+		return nil, err
 	}
+	return c.extractAverageRates(htmlContent, fetchedAt)
+}
 
-	if len(response[0].MainContentArea) == 0 {
-		return "", fmt.Errorf("no main content area in API response")
+// extractHTMLFromAPI extracts the HTML body content from Episerver API response.
+// The API returns JSON with nested structure: [0].mainContentArea[0].mainContentArea[0].body.
+func (c *MarginalenCrawler) extractHTMLFromAPI(jsonData string) (string, error) {
+	response, err := embedded.DecodeEpiserver(jsonData)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing API JSON: %w", err)
 	}
 
-	if len(response[0].MainContentArea[0].MainContentArea) == 0 {
-		return "", fmt.Errorf("no content blocks in API response")
+	htmlBody, err := embedded.String(response, "[0].mainContentArea[0].mainContentArea[0].body")
+	if err != nil {
+		return "", fmt.Errorf("no content block body in API response: %w", err)
 	}
 
-	htmlBody := response[0].MainContentArea[0].MainContentArea[0].Body
 	if htmlBody == "" {
 		return "", fmt.Errorf("empty HTML body in API response")
 	}
@@ -117,154 +117,109 @@ func (c *MarginalenCrawler) extractHTMLFromAPI(response episerverResponse) (stri
 
 // extractAverageRates parses average rates from Marginalen's HTML page.
 // The table has columns: Månad | 3 Mån | 6 Mån | 1 år | 2 år | 3 år
-// Missing values are shown as "-".
-//
-//nolint:cyclop // Table parsing with validation requires multiple conditionals
+// Missing values are shown as "-". If Marginalen publishes several series, e.g. per risk class, each has its own table
+// below its own heading, and the rates of each table get the heading as segment. Tables whose headings don't name a
+// risk class, loan-to-value or loan amount band are not told apart, only the first is parsed.
 func (c *MarginalenCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	// Find table by looking for "Genomsnittlig bolåneränta" heading
-	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Genomsnittlig bolåneränta")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find average rates table: %w", err)
+	// Find tables by looking for "Genomsnittlig bolåneränta" heading
+	tables, err := utils.ExtractTables(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Genomsnittlig bolåneränta"},
+		Columns: []utils.TableColumn{
+			{Name: "period", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	headings := make([]string, len(tables))
+	for i, table := range tables {
+		c.fingerprints.RecordTable(marginalenAPIURL, crawler.SeriesTableName("average rates", i), table.Header)
+		headings[i] = table.Heading
 	}
-
-	table, err := utils.ParseTable(tokenizer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse average rates table: %w", err)
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
 	}
 
-	if len(table.Header) == 0 {
-		return nil, fmt.Errorf("average rates table has no header row")
+	labels := crawler.SeriesLabels(headings)
+	if labels == nil && len(tables) > 1 {
+		c.logger.Warn("average rate tables are not headed by a segment, parsing only the first", zap.Strings("headings", headings))
+		tables = tables[:1]
+	}
+	interestSets := []model.InterestSet{}
+	for i, table := range tables {
+		sets := c.parseAverageTable(table, crawlTime)
+		if labels != nil {
+			crawler.SetAverageSegment(sets, labels[i])
+		}
+		interestSets = append(interestSets, sets...)
 	}
 
-	// Extract terms from header (skip first column which is "Månad")
-	terms, err := c.extractTermsFromHeader(table.Header[1:])
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract terms from header: %w", err)
+	if len(interestSets) == 0 {
+		return nil, fmt.Errorf("no average rates found in table")
 	}
 
-	// Parse each data row
-	interestSets := []model.InterestSet{}
-	for _, row := range table.Rows {
-		if len(row) < 2 {
-			c.logger.Warn("skipping row with insufficient columns", zap.Int("columns", len(row)))
-			continue
-		}
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "extractAverageRates")
+	return interestSets, nil
+}
 
+// parseAverageTable parses the rates of one average rates table.
+func (c *MarginalenCrawler) parseAverageTable(table utils.ExtractedTable, crawlTime time.Time) []model.InterestSet {
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
 		// First column is period (YYYYMM format)
-		period := strings.TrimSpace(row[0])
+		period := record.Get("period")
 		validFrom, err := c.parseMarginalenPeriod(period)
 		if err != nil {
 			c.logger.Warn("failed to parse period", zap.String("period", period), zap.Error(err))
 			continue
 		}
 
-		// Process each term column
-		for i, termStr := range row[1:] {
-			if i >= len(terms) {
-				break
-			}
-
-			// Skip missing values (shown as "-")
-			trimmedRate := strings.TrimSpace(termStr)
-			if trimmedRate == "-" || trimmedRate == "" {
+		for _, cell := range record.Cells("rates") {
+			term, err := utils.ParseTerm(cell.Header)
+			if err != nil {
 				continue
 			}
 
-			rate, err := c.parseMarginalenRate(trimmedRate)
+			rate, err := c.parseMarginalenRate(cell.Text)
+			if errors.Is(err, utils.ErrEmptyRate) {
+				continue // missing values are shown as "-"
+			}
 			if err != nil {
 				c.logger.Warn("failed to parse rate",
-					zap.String("rate", termStr),
+					zap.String("rate", cell.Text),
 					zap.String("period", period),
-					zap.String("term", string(terms[i])),
+					zap.Stringer("term", term),
 					zap.Error(err))
 				continue
 			}
 
-			avgMonth := &model.AvgMonth{
-				Month: validFrom.Month(),
-				Year:  uint(validFrom.Year()),
-			}
-
 			interestSets = append(interestSets, model.InterestSet{
-				Bank:                  marginalenBankName,
-				Type:                  model.TypeAverageRate,
-				Term:                  terms[i],
-				NominalRate:           float32(rate),
-				LastCrawledAt:         crawlTime,
-				AverageReferenceMonth: avgMonth,
+				Bank:          marginalenBankName,
+				Type:          model.TypeAverageRate,
+				Term:          term,
+				NominalRate:   rate,
+				LastCrawledAt: crawlTime,
+				AverageReferenceMonth: &model.AvgMonth{
+					Month: validFrom.Month(),
+					Year:  uint(validFrom.Year()),
+				},
 			})
 		}
 	}
-
-	if len(interestSets) == 0 {
-		return nil, fmt.Errorf("no average rates found in table")
-	}
-
-	return interestSets, nil
+	return interestSets
 }
 
-// extractTermsFromHeader extracts term codes from header row.
-// Header format: ["3 Mån", "6 Mån", "1 år", "2 år", "3 år"].
-func (c *MarginalenCrawler) extractTermsFromHeader(headers []string) ([]model.Term, error) {
-	terms := make([]model.Term, 0, len(headers))
-
-	for _, header := range headers {
-		termStr := strings.TrimSpace(header)
-		term, err := utils.ParseTerm(termStr)
-		if err != nil {
-			c.logger.Warn("failed to parse term from header", zap.String("header", termStr), zap.Error(err))
-			continue
-		}
-		terms = append(terms, term)
-	}
-
-	if len(terms) == 0 {
-		return nil, fmt.Errorf("no valid terms found in header")
-	}
-
-	return terms, nil
-}
-
-// parseMarginalenRate parses a rate string like "5,92 %" or "6.35%" to a float64.
-func (c *MarginalenCrawler) parseMarginalenRate(rateStr string) (float64, error) {
-	trimmed := strings.TrimSpace(rateStr)
-	matches := marginalenRateRegex.FindStringSubmatch(trimmed)
-	if matches == nil {
-		return 0, fmt.Errorf("rate does not match expected format: %s", rateStr)
-	}
-
-	// Replace comma with period for parsing
-	normalized := strings.ReplaceAll(matches[1], ",", ".")
-	rate, err := strconv.ParseFloat(normalized, 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate as float: %w", err)
-	}
-
-	return rate, nil
+// parseMarginalenRate parses a rate string like "5,92 %" or "6.35%".
+func (c *MarginalenCrawler) parseMarginalenRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseMarginalenPeriod parses period string "YYYYMM" to time.Time.
 // Example: "202412" -> December 2024.
 func (c *MarginalenCrawler) parseMarginalenPeriod(period string) (time.Time, error) {
-	matches := marginalenPeriodRegex.FindStringSubmatch(period)
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("period does not match YYYYMM format: %s", period)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
+	month, err := utils.ParseMonthYear(period)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse month: %w", err)
-	}
-
-	if month < 1 || month > 12 {
-		return time.Time{}, fmt.Errorf("invalid month: %d", month)
+		return time.Time{}, err
 	}
 
 	// Use first day of the month
-	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
+	return time.Date(int(month.Year), month.Month, 1, 0, 0, 0, 0, time.UTC), nil
 }
@@ -169,7 +169,7 @@ func TestMarginalenCrawler_ExtractAverageRates(t *testing.T) {
 				}
 
 				if r.NominalRate <= 0 {
-					t.Errorf("result[%d]: expected positive rate, got=%f", i, r.NominalRate)
+					t.Errorf("result[%d]: expected positive rate, got=%v", i, r.NominalRate)
 				}
 
 				// Marginalen publishes rates for: 3 Mån, 6 Mån, 1 år, 2 år, 3 år
@@ -195,6 +195,54 @@ func TestMarginalenCrawler_ExtractAverageRates(t *testing.T) {
 	}
 }
 
+func TestMarginalenCrawler_ExtractAverageRates_Series(t *testing.T) {
+	t.Parallel()
+
+	crawler := NewMarginalenCrawler(nil, zap.NewNop())
+	fixedTestTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results, err := crawler.extractAverageRates(crawlertest.LoadGoldenFile(t, "testdata/marginalen_avg_rates.html"), fixedTestTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+	for _, r := range results {
+		if r.AverageSegment != nil {
+			t.Fatalf("single series has segment %+v, want none", r.AverageSegment)
+		}
+	}
+
+	results, err = crawler.extractAverageRates(
+		crawlertest.LoadGoldenFile(t, "testdata/marginalen_avg_rates_segmented.html"), fixedTestTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+
+	// Headings that don't name a segment can't tell the series apart, so only the first table is parsed.
+	unsegmented, err := crawler.extractAverageRates(
+		crawlertest.LoadGoldenFile(t, "testdata/marginalen_avg_rates_unsegmented.html"), fixedTestTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+	if len(unsegmented) != 1 || unsegmented[0].NominalRate != model.RateFromPercent(5.1) || unsegmented[0].AverageSegment != nil {
+		t.Errorf("got %+v, want only the unsegmented rate of the first table", unsegmented)
+	}
+
+	want := map[string]model.AverageSegment{
+		"3m 5.1": {Label: "Belåningsgrad upp till 60 %", MaxRatio: 0.6},
+		"3m 6.2": {Label: "Belåningsgrad 60-85 %", MinRatio: 0.6, MaxRatio: 0.85},
+		"1y 6.4": {Label: "Belåningsgrad 60-85 %", MinRatio: 0.6, MaxRatio: 0.85},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, r := range results {
+		key := r.Term.String() + " " + r.NominalRate.String()
+		if r.AverageSegment == nil || *r.AverageSegment != want[key] {
+			t.Errorf("%s: segment = %+v, want %+v", key, r.AverageSegment, want[key])
+		}
+	}
+}
+
 func TestMarginalenCrawler_ParseMarginalenRate(t *testing.T) {
 	t.Parallel()
 
@@ -226,8 +274,8 @@ func TestMarginalenCrawler_ParseMarginalenRate(t *testing.T) {
 				t.Errorf("wantErr=%v, got err=%v", tt.wantErr, err)
 			}
 
-			if !tt.wantErr && rate != tt.wantRate {
-				t.Errorf("expected rate=%.2f, got=%.2f", tt.wantRate, rate)
+			if !tt.wantErr && rate != model.RateFromPercent(tt.wantRate) {
+				t.Errorf("expected rate=%v, got=%v", tt.wantRate, rate)
 			}
 		})
 	}
@@ -250,7 +298,7 @@ func TestMarginalenCrawler_ParseMarginalenPeriod(t *testing.T) {
 		{name: "valid period November 2025", input: "202511", wantYear: 2025, wantMonth: 11, wantErr: false},
 		{name: "invalid month 13", input: "202513", wantYear: 0, wantMonth: 0, wantErr: true},
 		{name: "invalid month 00", input: "202500", wantYear: 0, wantMonth: 0, wantErr: true},
-		{name: "invalid format YYYY-MM", input: "2025-11", wantYear: 0, wantMonth: 0, wantErr: true},
+		{name: "dash separated period", input: "2025-11", wantYear: 2025, wantMonth: 11, wantErr: false},
 		{name: "invalid format short", input: "20251", wantYear: 0, wantMonth: 0, wantErr: true},
 		{name: "empty string", input: "", wantYear: 0, wantMonth: 0, wantErr: true},
 	}
@@ -276,65 +324,3 @@ func TestMarginalenCrawler_ParseMarginalenPeriod(t *testing.T) {
 		})
 	}
 }
-
-func TestMarginalenCrawler_ExtractTermsFromHeader(t *testing.T) {
-	t.Parallel()
-
-	crawler := &MarginalenCrawler{logger: zap.NewNop()}
-
-	tests := []struct {
-		name      string
-		headers   []string
-		wantTerms []model.Term
-		wantErr   bool
-	}{
-		{
-			name:      "valid header row",
-			headers:   []string{"3 Mån", "6 Mån", "1 år", "2 år", "3 år"},
-			wantTerms: []model.Term{model.Term3months, model.Term6months, model.Term1year, model.Term2years, model.Term3years},
-			wantErr:   false,
-		},
-		{
-			name:      "partial valid headers",
-			headers:   []string{"3 Mån", "invalid", "1 år"},
-			wantTerms: []model.Term{model.Term3months, model.Term1year},
-			wantErr:   false,
-		},
-		{
-			name:      "empty headers",
-			headers:   []string{},
-			wantTerms: nil,
-			wantErr:   true,
-		},
-		{
-			name:      "all invalid headers",
-			headers:   []string{"invalid", "also invalid"},
-			wantTerms: nil,
-			wantErr:   true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			terms, err := crawler.extractTermsFromHeader(tt.headers)
-
-			if (err != nil) != tt.wantErr {
-				t.Errorf("wantErr=%v, got err=%v", tt.wantErr, err)
-			}
-
-			if !tt.wantErr {
-				if len(terms) != len(tt.wantTerms) {
-					t.Errorf("expected %d terms, got %d", len(tt.wantTerms), len(terms))
-				}
-
-				for i, wantTerm := range tt.wantTerms {
-					if i < len(terms) && terms[i] != wantTerm {
-						t.Errorf("term[%d]: expected=%s, got=%s", i, wantTerm, terms[i])
-					}
-				}
-			}
-		})
-	}
-}
@@ -1,16 +1,16 @@
 package nordax
 
 import (
-	"encoding/json"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/embedded"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -20,9 +20,9 @@ const (
 )
 
 var (
-	_                   crawler.SiteCrawler = &NordaxCrawler{}
-	nordaxRateRegex                         = regexp.MustCompile(`^(\d+[,.]?\d*)\s*%?$`)
-	nordaxNextDataRegex                     = regexp.MustCompile(`<script id="__NEXT_DATA__" type="application/json">(.+?)</script>`)
+	_ crawler.SiteCrawler    = &NordaxCrawler{}
+	_ crawler.Fingerprinter  = &NordaxCrawler{}
+	_ crawler.DocumentParser = &NordaxCrawler{}
 )
 
 // NordaxCrawler crawls Nordax Bank's rates page.
@@ -31,58 +31,22 @@ var (
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type NordaxCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
-// nextDataPage represents the page structure in Next.js __NEXT_DATA__.
-type nextDataPage struct {
-	Content []pageContent `json:"content"`
-}
-
-// pageContent represents a content block in the page.
-type pageContent struct {
-	ExpandableContent []expandableContent `json:"expandableContent"`
-}
-
-// expandableContent represents expandable content blocks.
-type expandableContent struct {
-	Body []contentBody `json:"body"`
-}
-
-// contentBody represents body content which can be a table.
-type contentBody struct {
-	Type    string       `json:"_type"` //nolint:tagliatelle // Nordax uses _type in JSON
-	Content tableContent `json:"content"`
-}
-
-// tableContent represents the table structure.
-type tableContent struct {
-	Rows []tableRow `json:"rows"`
-}
-
-// tableRow represents a single row in the table.
-type tableRow struct {
-	Cells []string `json:"cells"`
-}
-
-// nextDataResponse represents the full Next.js __NEXT_DATA__ structure.
-type nextDataResponse struct {
-	Props nextDataProps `json:"props"`
-}
-
-// nextDataProps represents the props section.
-type nextDataProps struct {
-	PageProps nextDataPageProps `json:"pageProps"`
-}
-
-// nextDataPageProps represents the page props section.
-type nextDataPageProps struct {
-	Page nextDataPage `json:"page"`
+func NewNordaxCrawler(httpClient http.Client, logger *zap.Logger) *NordaxCrawler {
+	return &NordaxCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(nordaxBankName),
+	}
 }
 
-func NewNordaxCrawler(httpClient http.Client, logger *zap.Logger) *NordaxCrawler {
-	return &NordaxCrawler{httpClient: httpClient, logger: logger}
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *NordaxCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *NordaxCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -101,51 +65,91 @@ func (c *NordaxCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	crawler.SetSource(interestSets, nordaxAvgRatesURL, []byte(avgHTML))
 	for _, set := range interestSets {
 		channel <- set
 	}
 }
 
-// extractAverageRates parses the average rates from the Next.js JSON data.
-//
-//nolint:cyclop // JSON navigation and parsing requires multiple checks
-func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	// Extract __NEXT_DATA__ JSON from HTML.
-	matches := nordaxNextDataRegex.FindStringSubmatch(rawHTML)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("could not find __NEXT_DATA__ in HTML")
+// ParseDocument parses an archived copy of the average rates page again.
+func (c *NordaxCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != nordaxAvgRatesURL {
+		return nil, crawler.ErrUnknownSource
 	}
+	return c.extractAverageRates(string(content), fetchedAt)
+}
 
-	var nextData nextDataResponse
-	if err := json.Unmarshal([]byte(matches[1]), &nextData); err != nil {
-		return nil, fmt.Errorf("failed to parse __NEXT_DATA__ JSON: %w", err)
+// extractAverageRates parses the average rates tables of the expandable sections titled "Genomsnittsräntor". Other
+// sections, like the one with the reference rates ("Basränta"), are skipped. If Nordax publishes several series per
+// risk class, loan-to-value or loan amount band, each table's rates get the section title as segment. Sections with
+// other titles are not told apart, only the first table is parsed.
+func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	nextData, err := embedded.ExtractNextData(rawHTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read __NEXT_DATA__: %w", err)
 	}
 
-	// Navigate to the table data.
-	if len(nextData.Props.PageProps.Page.Content) == 0 {
-		return nil, fmt.Errorf("no content found in page")
+	sections, err := embedded.Array(nextData, "props.pageProps.page.content[0].expandableContent")
+	if err != nil {
+		return nil, fmt.Errorf("no table content found in page: %w", err)
 	}
 
-	if len(nextData.Props.PageProps.Page.Content[0].ExpandableContent) == 0 {
-		return nil, fmt.Errorf("no expandable content found")
+	var tables []map[string]any
+	var headings []string
+	for _, section := range sections {
+		title, _ := embedded.String(section, "title")
+		if !strings.Contains(strings.ToLower(title), "genomsnitt") {
+			continue
+		}
+		body, err := embedded.Array(section, "body")
+		if err != nil {
+			continue
+		}
+		for _, block := range body {
+			if table, ok := block.(map[string]any); ok && table["_type"] == "table" {
+				tables = append(tables, table)
+				headings = append(headings, title)
+			}
+		}
 	}
-
-	if len(nextData.Props.PageProps.Page.Content[0].ExpandableContent[0].Body) == 0 {
-		return nil, fmt.Errorf("no body content found")
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no average rates table found in page")
 	}
 
-	tableBody := nextData.Props.PageProps.Page.Content[0].ExpandableContent[0].Body[0]
-	if tableBody.Type != "table" {
-		return nil, fmt.Errorf("expected table type, got: %s", tableBody.Type)
+	labels := crawler.SeriesLabels(headings)
+	if labels == nil && len(tables) > 1 {
+		c.logger.Warn("average rate tables are not headed by a segment, parsing only the first", zap.Strings("headings", headings))
+		tables = tables[:1]
 	}
+	var results []model.InterestSet
+	for i, table := range tables {
+		sets, err := c.parseAverageTable(table, crawler.SeriesTableName("average rates", i), crawlTime)
+		if err != nil {
+			return nil, err
+		}
+		if labels != nil {
+			crawler.SetAverageSegment(sets, labels[i])
+		}
+		results = append(results, sets...)
+	}
+
+	crawler.SetExtraction(results, model.SourceKindHTML, "extractAverageRates")
+	return results, nil
+}
 
-	rows := tableBody.Content.Rows
+// parseAverageTable parses the rates of one average rates table, recording its header in the fingerprint as name.
+func (c *NordaxCrawler) parseAverageTable(tableBody map[string]any, name string, crawlTime time.Time) ([]model.InterestSet, error) {
+	rows, err := embedded.Array(tableBody, "content.rows")
+	if err != nil {
+		return nil, fmt.Errorf("no rows found in table: %w", err)
+	}
 	if len(rows) < 2 {
 		return nil, fmt.Errorf("table has insufficient rows")
 	}
 
 	// First row is the header: ["Datum", "3 månaders", "36 månaders", "60 månaders"].
-	header := rows[0].Cells
+	header := rowCells(rows[0])
+	c.fingerprints.RecordTable(nordaxAvgRatesURL, name, header)
 	if len(header) < 2 {
 		return nil, fmt.Errorf("header row has insufficient columns")
 	}
@@ -169,12 +173,13 @@ func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time)
 
 	// Process data rows (skip header row).
 	for _, row := range rows[1:] {
-		if len(row.Cells) == 0 {
+		cells := rowCells(row)
+		if len(cells) == 0 {
 			continue
 		}
 
 		// First cell is the date in "YYYY-MM" format.
-		dateStr := strings.TrimSpace(row.Cells[0])
+		dateStr := strings.TrimSpace(cells[0])
 		month, err := parseNordaxMonth(dateStr)
 		if err != nil {
 			c.logger.Warn("failed to parse month", zap.String("date", dateStr), zap.Error(err))
@@ -183,11 +188,11 @@ func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time)
 
 		// Process each term column.
 		for colIdx, term := range termMap {
-			if colIdx >= len(row.Cells) {
+			if colIdx >= len(cells) {
 				continue
 			}
 
-			rateStr := strings.TrimSpace(row.Cells[colIdx])
+			rateStr := strings.TrimSpace(cells[colIdx])
 			if rateStr == "" {
 				// Empty cell - no data for this term in this month.
 				continue
@@ -203,7 +208,7 @@ func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time)
 				Bank:        nordaxBankName,
 				Term:        term,
 				Type:        model.TypeAverageRate,
-				NominalRate: float32(rate),
+				NominalRate: rate,
 				AverageReferenceMonth: &model.AvgMonth{
 					Month: month.Month(),
 					Year:  uint(month.Year()),
@@ -216,6 +221,20 @@ func (c *NordaxCrawler) extractAverageRates(rawHTML string, crawlTime time.Time)
 	return results, nil
 }
 
+// rowCells returns the text cells of a table row, with non-text cells left empty.
+func rowCells(row any) []string {
+	values, err := embedded.Array(row, "cells")
+	if err != nil {
+		return nil
+	}
+
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i], _ = value.(string)
+	}
+	return cells
+}
+
 // parseNordaxTerm parses Swedish term strings like "3 månaders", "36 månaders", "60 månaders".
 func parseNordaxTerm(termStr string) (model.Term, error) {
 	termStr = strings.TrimSpace(strings.ToLower(termStr))
@@ -224,57 +243,31 @@ func parseNordaxTerm(termStr string) (model.Term, error) {
 	if strings.Contains(termStr, "månader") {
 		parts := strings.Fields(termStr)
 		if len(parts) < 1 {
-			return "", fmt.Errorf("invalid term format: %s", termStr)
+			return model.Term{}, fmt.Errorf("invalid term format: %s", termStr)
 		}
 
 		months, err := strconv.Atoi(parts[0])
 		if err != nil {
-			return "", fmt.Errorf("failed to parse months: %w", err)
-		}
-
-		switch months {
-		case 3:
-			return model.Term3months, nil
-		case 36:
-			return model.Term3years, nil
-		case 60:
-			return model.Term5years, nil
-		default:
-			return "", fmt.Errorf("unsupported term: %d months", months)
+			return model.Term{}, fmt.Errorf("failed to parse months: %w", err)
 		}
+		return utils.TermFromCount(months, "months") //nolint:wrapcheck // utils error names the term
 	}
 
-	return "", fmt.Errorf("unrecognized term format: %s", termStr)
+	return model.Term{}, fmt.Errorf("unrecognized term format: %s", termStr)
 }
 
 // parseNordaxRate parses Swedish rate format like "4,66%" or "4.66%".
-func parseNordaxRate(rateStr string) (float64, error) {
-	rateStr = strings.TrimSpace(rateStr)
-
-	matches := nordaxRateRegex.FindStringSubmatch(rateStr)
-	if len(matches) < 2 {
-		return 0, fmt.Errorf("invalid rate format: %s", rateStr)
-	}
-
-	// Replace comma with period for parsing.
-	rateNumStr := strings.ReplaceAll(matches[1], ",", ".")
-	rate, err := strconv.ParseFloat(rateNumStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate: %w", err)
-	}
-
-	return rate, nil
+func parseNordaxRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseNordaxMonth parses date strings in "YYYY-MM" format.
 func parseNordaxMonth(dateStr string) (time.Time, error) {
-	dateStr = strings.TrimSpace(dateStr)
-
 	// Parse "YYYY-MM" format.
-	t, err := time.Parse("2006-01", dateStr)
+	month, err := utils.ParseMonthYear(dateStr)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse date %s: %w", dateStr, err)
+		return time.Time{}, err
 	}
 
-	return t, nil
+	return time.Date(int(month.Year), month.Month, 1, 0, 0, 0, 0, time.UTC), nil
 }
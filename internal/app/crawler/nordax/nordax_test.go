@@ -126,7 +126,7 @@ func TestNordaxCrawler_extractAverageRates(t *testing.T) {
 		}
 
 		if result.NominalRate <= 0 {
-			t.Errorf("expected positive rate, got %f", result.NominalRate)
+			t.Errorf("expected positive rate, got %v", result.NominalRate)
 		}
 
 		if result.AverageReferenceMonth == nil {
@@ -136,6 +136,57 @@ func TestNordaxCrawler_extractAverageRates(t *testing.T) {
 		if result.Term != model.Term3months && result.Term != model.Term3years && result.Term != model.Term5years {
 			t.Errorf("unexpected term: %v", result.Term)
 		}
+
+		// The "Basränta" section holds reference rates like 1,9310% and is no average rate series.
+		if result.AverageSegment != nil || result.NominalRate < model.RateFromPercent(3) {
+			t.Errorf("unexpected rate %v with segment %+v", result.NominalRate, result.AverageSegment)
+		}
+	}
+}
+
+func TestNordaxCrawler_extractAverageRates_Series(t *testing.T) {
+	t.Parallel()
+
+	section := func(title, rate string) string {
+		return `{"title":"` + title + `","body":[{"_type":"table","content":{"rows":[` +
+			`{"cells":["Datum","3 månaders","36 månaders"]},{"cells":["2025-11","` + rate + `",""]}]}}]}`
+	}
+	pageHTML := func(first, second string) string {
+		return `<html><body><script id="__NEXT_DATA__" type="application/json">` +
+			`{"props":{"pageProps":{"page":{"content":[{"expandableContent":[` +
+			section(first, "4,66%") + "," +
+			section(second, "5,12%") + "," +
+			section("Basränta*", "1,9310%") +
+			`]}]}}}}</script></body></html>`
+	}
+	crawler := NewNordaxCrawler(nil, zap.NewNop())
+	crawlTime := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	// Titles that don't name a segment can't tell the series apart, so only the first table is parsed.
+	results, err := crawler.extractAverageRates(pageHTML("Genomsnittsräntor bolån", "Genomsnittsräntor seniorlån"), crawlTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+	if len(results) != 1 || results[0].NominalRate != model.RateFromPercent(4.66) || results[0].AverageSegment != nil {
+		t.Fatalf("got %+v, want only the unsegmented rate of the first table", results)
+	}
+
+	results, err = crawler.extractAverageRates(pageHTML("Genomsnittsräntor riskklass A", "Genomsnittsräntor riskklass B"), crawlTime)
+	if err != nil {
+		t.Fatalf("extractAverageRates() error = %v", err)
+	}
+
+	want := map[string]model.Rate{
+		"Genomsnittsräntor riskklass A": model.RateFromPercent(4.66),
+		"Genomsnittsräntor riskklass B": model.RateFromPercent(5.12),
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for _, result := range results {
+		if result.AverageSegment == nil || want[result.AverageSegment.Label] != result.NominalRate {
+			t.Errorf("rate %v has segment %+v", result.NominalRate, result.AverageSegment)
+		}
 	}
 }
 
@@ -179,21 +230,21 @@ func TestParseNordaxTerm(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "unsupported term",
+			name:    "12 månaders",
 			input:   "12 månaders",
-			want:    "",
-			wantErr: true,
+			want:    model.Term1year,
+			wantErr: false,
 		},
 		{
 			name:    "invalid format",
 			input:   "invalid",
-			want:    "",
+			want:    model.Term{},
 			wantErr: true,
 		},
 		{
 			name:    "empty string",
 			input:   "",
-			want:    "",
+			want:    model.Term{},
 			wantErr: true,
 		},
 	}
@@ -278,7 +329,7 @@ func TestParseNordaxRate(t *testing.T) {
 				return
 			}
 
-			if got != tt.want {
+			if got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseNordaxRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -314,9 +365,9 @@ func TestParseNordaxMonth(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "invalid format",
+			name:    "month name",
 			input:   "Nov 2025",
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name:    "invalid month",
@@ -26,22 +26,32 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &NordeaCrawler{}
+	_ crawler.SiteCrawler    = &NordeaCrawler{}
+	_ crawler.Fingerprinter  = &NordeaCrawler{}
+	_ crawler.DocumentParser = &NordeaCrawler{}
 
-	// Nordea date format: YYYY-MM-DD.
-	nordeaDateRegex = regexp.MustCompile(`^(\d{4})-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
 	// Nordea historic date format in XLSX: MM-DD-YY.
 	nordeaHistoricDateRegex = regexp.MustCompile(`^(\d{2})-(\d{2})-(\d{2})$`)
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type NordeaCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewNordeaCrawler(httpClient http.Client, logger *zap.Logger) *NordeaCrawler {
-	return &NordeaCrawler{httpClient: httpClient, logger: logger}
+	return &NordeaCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(nordeaBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *NordeaCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *NordeaCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -58,6 +68,7 @@ func (c *NordeaCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Nordea List Rates", zap.Error(err))
 		} else {
+			crawler.SetSource(listRates, nordeaListRatesURL, []byte(listRatesHTML))
 			interestSets = append(interestSets, listRates...)
 		}
 	}
@@ -75,6 +86,19 @@ func (c *NordeaCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list rates page or the historic rates XLSX again. The XLSX is linked
+// from the historic rates page under a changing file name, so any XLSX on Nordea's site is taken for it.
+func (c *NordeaCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch {
+	case url == nordeaListRatesURL:
+		return c.extractListRates(string(content), fetchedAt)
+	case strings.HasPrefix(url, "https://www.nordea.se/") && strings.HasSuffix(url, ".xlsx"):
+		return c.parseHistoricRatesXLSX(content, fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *NordeaCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
 	// Find the table with caption "Listräntor för bolån"
 	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Listräntor för bolån")
@@ -86,6 +110,7 @@ func (c *NordeaCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse list rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(nordeaListRatesURL, "list rates", table.Header)
 
 	// Table structure: Bindningstid | Ränta | Ändring | Senast ändrad
 	interestSets := []model.InterestSet{}
@@ -123,6 +148,7 @@ func (c *NordeaCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
 	return interestSets, nil
 }
 
@@ -150,7 +176,13 @@ func (c *NordeaCrawler) fetchHistoricRates(crawlTime time.Time) ([]model.Interes
 	}
 
 	// Parse the XLSX file
-	return c.parseHistoricRatesXLSX(xlsxData, crawlTime)
+	historicRates, err := c.parseHistoricRatesXLSX(xlsxData, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(historicRates, xlsxURL, xlsxData)
+	return historicRates, nil
 }
 
 // findXLSXLink searches the HTML for any link to an XLSX file.
@@ -201,6 +233,8 @@ func (c *NordeaCrawler) parseHistoricRatesXLSX(xlsxData []byte, crawlTime time.T
 
 	// Find the header row dynamically (contains "Ränteändringsdag" or similar)
 	headerRowIdx, headerRow := c.findHeaderRow(rows)
+	// The workbook is linked from the historic rates page under a changing name, so the page is the source.
+	c.fingerprints.RecordTable(nordeaHistoricRatesURL, "historic rates", headerRow)
 	if headerRowIdx < 0 {
 		return nil, fmt.Errorf("could not find header row in XLSX")
 	}
@@ -264,6 +298,7 @@ func (c *NordeaCrawler) parseHistoricRatesXLSX(xlsxData []byte, crawlTime time.T
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindXLSX, "parseHistoricRatesXLSX")
 	return interestSets, nil
 }
 
@@ -340,36 +375,12 @@ func (c *NordeaCrawler) parseTermsFromHeader(headerRow []string) map[int]model.T
 	return termColumns
 }
 
-func parseNordeaRate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.TrimSpace(str)
-
-	if str == "" || str == "-" {
-		return 0, fmt.Errorf("empty or invalid rate: %q", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseNordeaRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 func parseNordeaDate(dateStr string) (time.Time, error) {
-	str := utils.NormalizeSpaces(dateStr)
-	matches := nordeaDateRegex.FindStringSubmatch(str)
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("date %q does not match expected format YYYY-MM-DD", dateStr)
-	}
-
-	year, _ := strconv.Atoi(matches[1])
-	month, _ := strconv.Atoi(matches[2])
-	day, _ := strconv.Atoi(matches[3])
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(dateStr)
 }
 
 func parseNordeaHistoricDate(dateStr string) (time.Time, error) {
@@ -383,12 +394,5 @@ func parseNordeaHistoricDate(dateStr string) (time.Time, error) {
 	day, _ := strconv.Atoi(matches[2])
 	year, _ := strconv.Atoi(matches[3])
 
-	// Convert 2-digit year to 4-digit (assume 90+ is 1990s, otherwise 2000s)
-	if year >= 90 {
-		year += 1900
-	} else {
-		year += 2000
-	}
-
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+	return time.Date(utils.ExpandTwoDigitYear(year), time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
 }
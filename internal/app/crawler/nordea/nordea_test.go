@@ -216,7 +216,7 @@ func TestParseNordeaRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -269,7 +269,7 @@ func TestParseNordeaRate(t *testing.T) {
 				t.Errorf("parseNordeaRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseNordeaRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -363,15 +363,15 @@ func TestParseNordeaHistoricDate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "valid date boundary year 90",
-			input:   "01-01-90",
-			want:    time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+			name:    "valid date boundary year 40 (1940)",
+			input:   "01-01-40",
+			want:    time.Date(1940, time.January, 1, 0, 0, 0, 0, time.UTC),
 			wantErr: false,
 		},
 		{
-			name:    "valid date boundary year 89 (2089)",
-			input:   "12-31-89",
-			want:    time.Date(2089, time.December, 31, 0, 0, 0, 0, time.UTC),
+			name:    "valid date boundary year 39 (2039)",
+			input:   "12-31-39",
+			want:    time.Date(2039, time.December, 31, 0, 0, 0, 0, time.UTC),
 			wantErr: false,
 		},
 		{
@@ -4,8 +4,6 @@ package nordnet
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +16,7 @@ import (
 
 const (
 	nordnetBankName model.Bank = "Nordnet"
+	stabeloBankName model.Bank = "Stabelo"
 
 	// Nordnet uses Contentful CMS API to serve their rate data.
 	nordnetRatesURL = "https://api.prod.nntech.io/cms/v1/contentful-cache/spaces/main_se/environments/master/entries?include=5&sys.id=36p8FGv6CCUfUIiXPjPBJy"
@@ -26,15 +25,29 @@ const (
 // NordnetCrawler crawls mortgage rates from Nordnet.
 // Nordnet offers mortgages via Stabelo with different LTV-based rate tiers.
 type NordnetCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
-var _ crawler.SiteCrawler = &NordnetCrawler{}
+var (
+	_ crawler.SiteCrawler    = &NordnetCrawler{}
+	_ crawler.Fingerprinter  = &NordnetCrawler{}
+	_ crawler.DocumentParser = &NordnetCrawler{}
+)
 
 // NewNordnetCrawler creates a new Nordnet crawler.
 func NewNordnetCrawler(httpClient http.Client, logger *zap.Logger) *NordnetCrawler {
-	return &NordnetCrawler{httpClient: httpClient, logger: logger}
+	return &NordnetCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(nordnetBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *NordnetCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 // Crawl fetches and parses mortgage rates from Nordnet.
@@ -52,6 +65,14 @@ func (c *NordnetCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the CMS API response again.
+func (c *NordnetCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != nordnetRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseListRates(string(content), fetchedAt)
+}
+
 // nordnetCMSResponse represents the Contentful CMS response structure.
 type nordnetCMSResponse struct {
 	Includes struct {
@@ -87,6 +108,17 @@ func (c *NordnetCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSe
 		return nil, fmt.Errorf("failed reading Nordnet rates API: %w", err)
 	}
 
+	interestSets, err := c.parseListRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, nordnetRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseListRates finds the rate table in the CMS API response and parses it.
+func (c *NordnetCrawler) parseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
 	var response nordnetCMSResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling Nordnet rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -108,7 +140,22 @@ func (c *NordnetCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSe
 		return nil, fmt.Errorf("rate table not found in Nordnet CMS response")
 	}
 
-	return c.parseRateTable(*tableData, crawlTime)
+	// The CMS response holds all content of the page, only the rate table's header is part of the structure.
+	var header []string
+	if len(*tableData) > 0 {
+		for _, cell := range (*tableData)[0] {
+			header = append(header, cell.Value)
+		}
+	}
+	c.fingerprints.RecordTable(nordnetRatesURL, "rates", header)
+
+	interestSets, err := c.parseRateTable(*tableData, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
+	return interestSets, nil
 }
 
 // parseRateTable parses the rate table from Nordnet's CMS response.
@@ -121,8 +168,6 @@ func (c *NordnetCrawler) parseRateTable(rows [][]nordnetTableCell, crawlTime tim
 
 	// First row is headers, skip it
 	// Rate format: "2,54 (2,57)" where first number is nominal rate
-	rateRegex := regexp.MustCompile(`^(\d+),(\d+)`)
-
 	var interestSets []model.InterestSet
 
 	for i := 1; i < len(rows); i++ {
@@ -146,7 +191,7 @@ func (c *NordnetCrawler) parseRateTable(rows [][]nordnetTableCell, crawlTime tim
 
 		// Use the last column (highest LTV tier: 80-85%) for list rate
 		rateStr := row[len(row)-1].Value
-		rate, err := parseNordnetRate(rateStr, rateRegex)
+		rate, err := parseNordnetRate(rateStr)
 		if err != nil {
 			c.logger.Warn("failed parsing Nordnet rate, skipping",
 				zap.String("rate", rateStr),
@@ -160,6 +205,7 @@ func (c *NordnetCrawler) parseRateTable(rows [][]nordnetTableCell, crawlTime tim
 			Term:          term,
 			NominalRate:   rate,
 			LastCrawledAt: crawlTime,
+			Lender:        stabeloBankName,
 
 			ChangedOn:               nil, // Nordnet API doesn't provide change dates
 			RatioDiscountBoundaries: nil,
@@ -172,28 +218,8 @@ func (c *NordnetCrawler) parseRateTable(rows [][]nordnetTableCell, crawlTime tim
 }
 
 // parseNordnetRate parses a rate string like "2,54 (2,57)" and returns the nominal rate.
-func parseNordnetRate(rateStr string, rateRegex *regexp.Regexp) (float32, error) {
-	matches := rateRegex.FindStringSubmatch(rateStr)
-	if len(matches) != 3 {
-		return 0, fmt.Errorf("invalid rate format: %s", rateStr)
-	}
-
-	intPart, err := strconv.ParseFloat(matches[1], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed parsing rate integer part: %w", err)
-	}
-
-	decPart, err := strconv.ParseFloat(matches[2], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed parsing rate decimal part: %w", err)
-	}
-
-	// Calculate the rate value (e.g., "2,54" -> 2.54)
-	decDivisor := 10.0
-	for decPart >= decDivisor {
-		decDivisor *= 10.0
-	}
-
-	rate := float32(intPart + decPart/decDivisor)
-	return rate, nil
+func parseNordnetRate(rateStr string) (model.Rate, error) {
+	// The effective rate in parentheses is derived from the nominal rate, so only the nominal rate is parsed.
+	nominal, _, _ := strings.Cut(rateStr, "(")
+	return utils.ParseRate(nominal)
 }
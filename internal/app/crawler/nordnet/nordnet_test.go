@@ -3,7 +3,6 @@ package nordnet
 
 import (
 	"errors"
-	"regexp"
 	"testing"
 	"time"
 
@@ -124,6 +123,9 @@ func TestNordnetCrawler_fetchListRates(t *testing.T) {
 			expectedTerms[r.Term] = true
 		}
 		assertNordnetListRateFields(t, r, crawlTime)
+		if r.Lender != stabeloBankName {
+			t.Errorf("Lender = %q, want %q", r.Lender, stabeloBankName)
+		}
 	}
 
 	for term, found := range expectedTerms {
@@ -136,12 +138,10 @@ func TestNordnetCrawler_fetchListRates(t *testing.T) {
 func TestParseNordnetRate(t *testing.T) {
 	t.Parallel()
 
-	rateRegex := regexp.MustCompile(`^(\d+),(\d+)`)
-
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -162,6 +162,12 @@ func TestParseNordnetRate(t *testing.T) {
 			want:    4.21,
 			wantErr: false,
 		},
+		{
+			name:    "leading zero in decimals",
+			input:   "2,05 (2,07)",
+			want:    2.05,
+			wantErr: false,
+		},
 		{
 			name:    "invalid format",
 			input:   "invalid",
@@ -178,14 +184,13 @@ func TestParseNordnetRate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := parseNordnetRate(tt.input, rateRegex)
+			got, err := parseNordnetRate(tt.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseNordnetRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !tt.wantErr {
-				// Allow small floating point differences
-				if got < tt.want-0.01 || got > tt.want+0.01 {
+				if got != model.RateFromPercent(tt.want) {
 					t.Errorf("parseNordnetRate() = %v, want %v", got, tt.want)
 				}
 			}
@@ -230,7 +235,7 @@ func assertNordnetListRateFields(t *testing.T, r model.InterestSet, crawlTime ti
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	// Nordnet API doesn't provide change dates
 	if r.ChangedOn != nil {
@@ -0,0 +1,207 @@
+package olandsbank
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	olandsBankName     model.Bank = "Ölands Bank"
+	olandsBankRatesURL string     = "https://www.olandsbank.se/privat/bolan/bolanerantor/"
+)
+
+var (
+	_ crawler.SiteCrawler    = &OlandsBankCrawler{}
+	_ crawler.Fingerprinter  = &OlandsBankCrawler{}
+	_ crawler.DocumentParser = &OlandsBankCrawler{}
+
+	// olandsBankMonthRegex matches the month headers of the average rates table, e.g. "sep 2025".
+	olandsBankMonthRegex = regexp.MustCompile(`^\p{L}+\.? \d{4}$`)
+)
+
+// OlandsBankCrawler crawls Ölands Bank's rates page. Ölands Bank is a small local bank on Öland with its own prices and
+// site. List and average rates are on the same page.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type OlandsBankCrawler struct {
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+func NewOlandsBankCrawler(httpClient http.Client, logger *zap.Logger) *OlandsBankCrawler {
+	return &OlandsBankCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(olandsBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *OlandsBankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func (c *OlandsBankCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	rawHTML, err := c.httpClient.Fetch(olandsBankRatesURL, nil)
+	if err != nil {
+		c.logger.Error("failed fetching Ölands Bank rates page", zap.Error(err))
+		return
+	}
+
+	interestSets, err := c.extractRates(rawHTML, crawlTime)
+	if err != nil {
+		c.logger.Error("failed parsing Ölands Bank rates", zap.Error(err))
+		return
+	}
+
+	crawler.SetSource(interestSets, olandsBankRatesURL, []byte(rawHTML))
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the rates page again.
+func (c *OlandsBankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != olandsBankRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.extractRates(string(content), fetchedAt)
+}
+
+// extractRates parses both tables of the page. A missing average rates table only costs the average rates.
+func (c *OlandsBankCrawler) extractRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	interestSets, err := c.extractListRates(rawHTML, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	avgRates, err := c.extractAverageRates(rawHTML, crawlTime)
+	if err != nil {
+		c.logger.Warn("failed to extract Ölands Bank average rates", zap.Error(err))
+	}
+	interestSets = append(interestSets, avgRates...)
+
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractRates")
+	return interestSets, nil
+}
+
+// extractListRates parses the list rates table after "Listräntor": Bindningstid | Listränta.
+func (c *OlandsBankCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Listräntor"},
+		Columns: []utils.TableColumn{
+			{Name: "term", Match: utils.HeaderContains("bindningstid")},
+			{Name: "rate", Match: utils.HeaderContains("listränta")},
+		},
+	})
+	c.fingerprints.RecordTable(olandsBankRatesURL, "list rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("rate"))
+		if !ok {
+			continue
+		}
+
+		interestSets = append(interestSets, model.InterestSet{
+			Bank:          olandsBankName,
+			Type:          model.TypeListRate,
+			Term:          term,
+			NominalRate:   rate,
+			LastCrawledAt: crawlTime,
+		})
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no list rates found in table")
+	}
+	return interestSets, nil
+}
+
+// extractAverageRates parses the average rates table after "Snitträntor", one row per term and one column per month:
+// Bindningstid | sep 2025 | aug 2025 | jul 2025. The month headers change every month, so they are masked in the
+// fingerprint.
+func (c *OlandsBankCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Snitträntor"},
+		Columns: []utils.TableColumn{
+			{Name: "term", Index: 0},
+			{Name: "months", Match: utils.HeaderRegexp(olandsBankMonthRegex), Repeated: true},
+		},
+	})
+	c.fingerprints.RecordTable(olandsBankRatesURL, "average rates", maskMonths(table.Header))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		for _, cell := range record.Cells("months") {
+			month, err := utils.ParseMonthYear(cell.Header)
+			if err != nil {
+				c.logger.Warn("failed to parse average rate month", zap.String("month", cell.Header), zap.Error(err))
+				continue
+			}
+			term, rate, ok := c.parseTermRate(record.Get("term"), cell.Text)
+			if !ok {
+				continue
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  olandsBankName,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: &month,
+			})
+		}
+	}
+	return interestSets, nil
+}
+
+// parseTermRate parses a term and its rate. A missing rate, shown as "-" for months with too few loans, is skipped
+// silently.
+func (c *OlandsBankCrawler) parseTermRate(termStr, rateStr string) (model.Term, model.Rate, bool) {
+	term, err := utils.ParseTerm(termStr)
+	if err != nil {
+		c.logger.Warn("failed to parse term", zap.String("term", termStr), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+
+	rate, err := utils.ParseRate(rateStr)
+	if errors.Is(err, utils.ErrEmptyRate) {
+		return model.Term{}, 0, false
+	}
+	if err != nil {
+		c.logger.Warn("failed to parse rate", zap.String("rate", rateStr), zap.Stringer("term", term), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+	return term, rate, true
+}
+
+// maskMonths replaces the month headers so that the fingerprint only changes with the layout.
+func maskMonths(header []string) []string {
+	masked := make([]string, len(header))
+	for i, text := range header {
+		if olandsBankMonthRegex.MatchString(text) {
+			text = "<month>"
+		}
+		masked[i] = text
+	}
+	return masked
+}
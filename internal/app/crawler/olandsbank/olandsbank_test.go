@@ -0,0 +1,146 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package olandsbank
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestOlandsBankCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	ratesHTML := crawlertest.LoadGoldenFile(t, "testdata/olandsbank_rates.html")
+
+	tests := []struct {
+		name          string
+		mockFetch     func(url string, headers map[string]string) (string, error)
+		wantListRates int
+		wantAvgRates  int
+	}{
+		{
+			name: "successful crawl extracts list and average rates",
+			mockFetch: func(url string, _ map[string]string) (string, error) {
+				if url != olandsBankRatesURL {
+					return "", errors.New("unexpected URL " + url)
+				}
+				return ratesHTML, nil
+			},
+			wantListRates: 5,
+			wantAvgRates:  16, // 5 terms × 4 months, four missing
+		},
+		{
+			name: "fetch error returns no results",
+			mockFetch: func(_ string, _ map[string]string) (string, error) {
+				return "", errors.New("network error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := crawlertest.RunCrawl(t, NewOlandsBankCrawler(&httpmock.ClientMock{FetchFunc: tt.mockFetch}, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != tt.wantListRates {
+				t.Errorf("list rate count = %d, want %d", listRateCount, tt.wantListRates)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, olandsBankName)
+		})
+	}
+}
+
+func TestOlandsBankCrawler_extractRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/olandsbank_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &OlandsBankCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.extractRates(rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractRates() error = %v", err)
+	}
+
+	listRates := map[model.Term]model.Rate{}
+	avgRates := map[model.AvgMonth]map[model.Term]model.Rate{}
+	for _, r := range results {
+		switch r.Type {
+		case model.TypeListRate:
+			crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: olandsBankName}, crawlTime)
+			listRates[r.Term] = r.NominalRate
+		case model.TypeAverageRate:
+			crawlertest.AssertAverageRateFields(t, r, olandsBankName, crawlTime)
+			if avgRates[*r.AverageReferenceMonth] == nil {
+				avgRates[*r.AverageReferenceMonth] = map[model.Term]model.Rate{}
+			}
+			avgRates[*r.AverageReferenceMonth][r.Term] = r.NominalRate
+		default:
+			t.Errorf("unexpected type %q", r.Type)
+		}
+	}
+
+	if got := listRates[model.Term2years]; got != model.RateFromPercent(3.59) {
+		t.Errorf("2y list rate = %s, want 3.59", got)
+	}
+
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	june := model.AvgMonth{Year: 2025, Month: time.June}
+	if got := avgRates[september][model.Term3months]; got != model.RateFromPercent(3.02) {
+		t.Errorf("3m average rate of September = %s, want 3.02", got)
+	}
+	if got := avgRates[june][model.Term5years]; got != model.RateFromPercent(3.36) {
+		t.Errorf("5y average rate of June = %s, want 3.36", got)
+	}
+	if got, ok := avgRates[september][model.Term2years]; ok {
+		t.Errorf("2y average rate of September = %s, want none for \"-\"", got)
+	}
+	if len(avgRates) != 4 {
+		t.Errorf("months = %d, want 4", len(avgRates))
+	}
+
+	if _, err := crawler.extractRates("<html><p>Bolån</p></html>", crawlTime); err == nil {
+		t.Error("extractRates() without list rates table error = nil, want error")
+	}
+}
+
+func TestOlandsBankCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &OlandsBankCrawler{logger: zap.NewNop()}
+	content := crawlertest.LoadGoldenFileBytes(t, "testdata/olandsbank_rates.html")
+
+	results, err := crawler.ParseDocument(olandsBankRatesURL, content, fetchedAt)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	if len(results) != 21 {
+		t.Errorf("ParseDocument() returned %d rates, want 21", len(results))
+	}
+
+	if _, err := crawler.ParseDocument("https://www.olandsbank.se/", content, fetchedAt); !errors.Is(err, crawlertest.ErrUnknownSource) {
+		t.Errorf("ParseDocument(unknown URL) error = %v, want ErrUnknownSource", err)
+	}
+}
+
+func TestMaskMonths(t *testing.T) {
+	t.Parallel()
+
+	got := maskMonths([]string{"Bindningstid", "sep 2025", "aug. 2025", "Snittränta"})
+	want := []string{"Bindningstid", "<month>", "<month>", "Snittränta"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("maskMonths() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"sync"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// ReferenceRateCrawler collects the market reference rates the banks price their mortgages against, like the
+// Riksbank policy rate. They are no bank's offer, so they are kept apart from the InterestSets.
+type ReferenceRateCrawler interface {
+	CrawlReferenceRates(result chan<- model.ReferenceRate)
+}
+
+// ReferenceReport summarizes the outcome of a single reference rate collection.
+type ReferenceReport struct {
+	Received uint
+	Stored   uint
+	Rejected uint
+}
+
+// ReferenceCollector runs all ReferenceRateCrawlers and stores the rates as their own series.
+type ReferenceCollector struct {
+	store    store.Store
+	crawlers []ReferenceRateCrawler
+	logger   *zap.Logger
+}
+
+func NewReferenceCollector(s store.Store, crawlers []ReferenceRateCrawler, logger *zap.Logger) *ReferenceCollector {
+	return &ReferenceCollector{
+		store:    s,
+		crawlers: crawlers,
+		logger:   logger,
+	}
+}
+
+// Collect runs all crawlers concurrently and stores every rate they send. A rate of an unknown series or without a date
+// is rejected.
+func (c *ReferenceCollector) Collect() ReferenceReport {
+	var wg sync.WaitGroup
+	rateChan := make(chan model.ReferenceRate)
+	for _, rc := range c.crawlers {
+		wg.Add(1)
+		go func(rc ReferenceRateCrawler) {
+			defer wg.Done()
+			rc.CrawlReferenceRates(rateChan)
+		}(rc)
+	}
+	go func() {
+		wg.Wait()
+		close(rateChan)
+	}()
+
+	report := ReferenceReport{}
+	for rate := range rateChan {
+		report.Received++
+		if !rate.Series.Valid() || rate.Date.IsZero() {
+			c.logger.Warn("rejecting invalid reference rate",
+				zap.String("series", string(rate.Series)),
+				zap.Time("date", rate.Date),
+				zap.Stringer("rate", rate.Rate))
+			report.Rejected++
+			continue
+		}
+
+		if err := c.store.UpsertReferenceRate(rate); err != nil {
+			c.logger.Error("failed to upsert reference rate", zap.Any("referenceRate", rate), zap.Error(err))
+			continue
+		}
+		report.Stored++
+	}
+
+	c.logger.Info("reference rates collected",
+		zap.Uint("received", report.Received),
+		zap.Uint("stored", report.Stored),
+		zap.Uint("rejected", report.Rejected),
+	)
+	return report
+}
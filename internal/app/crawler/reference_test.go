@@ -0,0 +1,63 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// staticReferenceCrawler sends a fixed list of reference rates.
+type staticReferenceCrawler struct {
+	rates []model.ReferenceRate
+}
+
+func (c *staticReferenceCrawler) CrawlReferenceRates(result chan<- model.ReferenceRate) {
+	for _, rate := range c.rates {
+		result <- rate
+	}
+}
+
+func TestReferenceCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2025, time.October, d, 0, 0, 0, 0, time.UTC) }
+	policy1 := model.ReferenceRate{Series: model.ReferencePolicyRate, Date: day(1), Rate: model.RateFromPercent(2)}
+	policy1Revised := model.ReferenceRate{Series: model.ReferencePolicyRate, Date: day(1), Rate: model.RateFromPercent(1.75)}
+	policy2 := model.ReferenceRate{Series: model.ReferencePolicyRate, Date: day(2), Rate: model.RateFromPercent(1.75)}
+	stibor := model.ReferenceRate{Series: model.ReferenceSTIBOR3M, Date: day(1), Rate: model.RateFromPercent(1.9)}
+	unknown := model.ReferenceRate{Series: "euribor", Date: day(1), Rate: model.RateFromPercent(2.1)}
+	undated := model.ReferenceRate{Series: model.ReferenceSWESTR, Rate: model.RateFromPercent(1.7)}
+
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	if err := memStore.UpsertReferenceRate(policy1); err != nil {
+		t.Fatalf("UpsertReferenceRate() error = %v", err)
+	}
+	collector := NewReferenceCollector(memStore, []ReferenceRateCrawler{
+		&staticReferenceCrawler{rates: []model.ReferenceRate{policy2, policy1Revised, unknown}},
+		&staticReferenceCrawler{rates: []model.ReferenceRate{stibor, undated}},
+	}, zap.NewNop())
+
+	report := collector.Collect()
+	if want := (ReferenceReport{Received: 5, Stored: 3, Rejected: 2}); report != want {
+		t.Errorf("Collect() = %+v, want %+v", report, want)
+	}
+
+	policy, err := memStore.GetReferenceRates(model.ReferencePolicyRate)
+	if err != nil {
+		t.Fatalf("GetReferenceRates() error = %v", err)
+	}
+	if want := []model.ReferenceRate{policy1Revised, policy2}; !reflect.DeepEqual(policy, want) {
+		t.Errorf("policy rates = %+v, want %+v", policy, want)
+	}
+	swestr, err := memStore.GetReferenceRates(model.ReferenceSWESTR)
+	if err != nil {
+		t.Fatalf("GetReferenceRates() error = %v", err)
+	}
+	if len(swestr) != 0 {
+		t.Errorf("SWESTR rates = %+v, want none", swestr)
+	}
+}
@@ -0,0 +1,264 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/archive"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// ErrUnknownSource is returned by DocumentParser for documents that hold no rates of the crawler, like pages of other
+// banks or pages only fetched to find a download link.
+var ErrUnknownSource = errors.New("not a rate source of this crawler")
+
+// DocumentParser is implemented by crawlers that can parse an archived document of one of their sources again.
+type DocumentParser interface {
+	// ParseDocument parses a document fetched from url with the current parser, as if it was crawled at fetchedAt.
+	// It returns ErrUnknownSource if url is not one of the crawler's rate sources.
+	ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error)
+}
+
+// ReparseOptions selects the archived documents to parse again and whether to correct the store.
+type ReparseOptions struct {
+	Bank model.Bank // only rates of this bank, all banks if empty
+	From time.Time  // earliest fetch time, inclusive
+	To   time.Time  // latest fetch time, exclusive; zero means no limit
+	// Apply upserts the corrected rates into the store. Without it the corrections are only reported.
+	Apply bool
+}
+
+// RateCorrection is a rate that parsing an archived document again yields differently than stored.
+type RateCorrection struct {
+	Document archive.Record
+	Stored   *model.InterestSet // nil if the rate is missing in the store
+	Reparsed model.InterestSet
+	// Violations are the validation rules the reparsed rate breaks. A rejected correction is never applied.
+	Violations []Violation
+}
+
+// ReparseReport summarizes the outcome of a reparse run.
+type ReparseReport struct {
+	// Documents counts the archived documents parsed again.
+	Documents uint
+	// Failed counts the documents that could not be read from the archive or parsed.
+	Failed      uint
+	Corrections []RateCorrection
+	// Rejected counts the corrections that fail validation like an implausible crawled rate would.
+	Rejected uint
+	Applied  uint
+}
+
+// Reparser feeds archived source documents through the current parsers and compares the result with the store, so
+// that a fixed parser repairs the stored history and not only future crawls.
+//
+// A reparsed list rate is only compared with the stored one if that was parsed from the same document, since the store
+// only keeps the latest version of a list rate. Average rates belong to their reference month and are always
+// compared. Corrections are validated like crawled rates, so that a regressed parser cannot write implausible rates
+// into the history.
+type Reparser struct {
+	store     store.Store
+	archive   *archive.Archive
+	crawlers  []SiteCrawler
+	validator *Validator
+	logger    *zap.Logger
+}
+
+func NewReparser(s store.Store, a *archive.Archive, crawlers []SiteCrawler, validator *Validator, logger *zap.Logger) *Reparser {
+	return &Reparser{
+		store:     s,
+		archive:   a,
+		crawlers:  crawlers,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (r *Reparser) Reparse(opts ReparseOptions) (ReparseReport, error) {
+	var report ReparseReport
+
+	records, err := r.archive.Records()
+	if err != nil {
+		return report, fmt.Errorf("failed to list archived documents: %w", err)
+	}
+	stored, err := r.store.GetInterestSets()
+	if err != nil {
+		return report, fmt.Errorf("failed to load stored rates: %w", err)
+	}
+	storedByKey := map[string]model.InterestSet{}
+	for _, set := range stored {
+		storedByKey[set.Key()] = set
+	}
+
+	var reparsed []reparsedSet
+	for _, record := range latestFetchPerDocument(records, opts) {
+		content, err := r.archive.Get(record.Hash)
+		if err != nil {
+			r.logger.Error("failed to read archived document", zap.String("url", record.URL), zap.Error(err))
+			report.Failed++
+			continue
+		}
+
+		sets, parsed, err := r.parseDocument(record, content)
+		if !parsed {
+			continue
+		}
+		report.Documents++
+		if err != nil {
+			r.logger.Warn("failed to parse archived document", zap.String("url", record.URL),
+				zap.Time("fetchedAt", record.FetchedAt), zap.Error(err))
+			report.Failed++
+			continue
+		}
+
+		for _, set := range sets {
+			if opts.Bank == "" || set.Bank == opts.Bank {
+				reparsed = append(reparsed, reparsedSet{record: record, set: set})
+			}
+		}
+	}
+
+	for _, rs := range latestAverageRates(reparsed) {
+		correction, ok := compareWithStored(rs.record, rs.set, storedByKey)
+		if !ok {
+			continue
+		}
+		correction.Violations = r.validator.Validate(correction.Reparsed)
+		report.Corrections = append(report.Corrections, correction)
+		r.logger.Info("reparsed rate differs from stored rate",
+			zap.String("id", rs.set.Key()),
+			zap.String("url", rs.record.URL),
+			zap.Time("fetchedAt", rs.record.FetchedAt),
+			zap.Stringer("reparsedRate", rs.set.NominalRate),
+		)
+
+		if HasRejection(correction.Violations) {
+			for _, v := range correction.Violations {
+				r.logger.Warn("implausible reparsed interestSet",
+					zap.String("id", rs.set.Key()),
+					zap.String("rule", string(v.Rule)),
+					zap.String("message", v.Message),
+				)
+			}
+			report.Rejected++
+			continue
+		}
+		if !opts.Apply {
+			continue
+		}
+		if err := r.store.UpsertInterestSet(correction.Reparsed); err != nil {
+			r.logger.Error("failed to upsert corrected interestSet", zap.String("id", rs.set.Key()), zap.Error(err))
+			continue
+		}
+		report.Applied++
+	}
+
+	r.logger.Info("reparse finished",
+		zap.Uint("documents", report.Documents),
+		zap.Uint("failed", report.Failed),
+		zap.Int("corrections", len(report.Corrections)),
+		zap.Uint("rejected", report.Rejected),
+		zap.Uint("applied", report.Applied),
+	)
+	return report, nil
+}
+
+// reparsedSet is a set parsed again from an archived document.
+type reparsedSet struct {
+	record archive.Record
+	set    model.InterestSet
+}
+
+// parseDocument parses a document with the crawler it belongs to. It reports false if no crawler parses the document.
+func (r *Reparser) parseDocument(record archive.Record, content []byte) ([]model.InterestSet, bool, error) {
+	for _, c := range r.crawlers {
+		parser, ok := c.(DocumentParser)
+		if !ok {
+			continue
+		}
+
+		sets, err := parser.ParseDocument(record.URL, content, record.FetchedAt)
+		if errors.Is(err, ErrUnknownSource) {
+			continue
+		}
+		if err != nil {
+			return nil, true, err
+		}
+
+		SetSource(sets, record.URL, content)
+		return sets, true, nil
+	}
+	return nil, false, nil
+}
+
+// latestFetchPerDocument returns the records fetched in the selected time range with only the latest fetch of every
+// document, ordered by that fetch.
+func latestFetchPerDocument(records []archive.Record, opts ReparseOptions) []archive.Record {
+	seen := map[string]bool{}
+	result := []archive.Record{}
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.FetchedAt.Before(opts.From) || (!opts.To.IsZero() && !record.FetchedAt.Before(opts.To)) {
+			continue
+		}
+		if document := record.URL + "|" + record.Hash; !seen[document] {
+			seen[document] = true
+			result = append(result, record)
+		}
+	}
+	slices.Reverse(result)
+	return result
+}
+
+// latestAverageRates drops every average rate that a later document publishes again, since banks may revise a month's
+// average. List rates are kept, as each is only compared with the stored rate parsed from the same document.
+func latestAverageRates(reparsed []reparsedSet) []reparsedSet {
+	last := map[string]int{}
+	for i, rs := range reparsed {
+		if rs.set.Type == model.TypeAverageRate {
+			last[rs.set.Key()] = i
+		}
+	}
+
+	result := []reparsedSet{}
+	for i, rs := range reparsed {
+		if rs.set.Type != model.TypeAverageRate || last[rs.set.Key()] == i {
+			result = append(result, rs)
+		}
+	}
+	return result
+}
+
+// compareWithStored returns the correction for a reparsed set, or false if it matches the stored one or cannot be
+// compared with it.
+func compareWithStored(record archive.Record, set model.InterestSet, storedByKey map[string]model.InterestSet) (RateCorrection, bool) {
+	stored, ok := storedByKey[set.Key()]
+	sameDocument := ok && stored.Source != nil && stored.Source.Hash == record.Hash
+	if set.Type != model.TypeAverageRate && !sameDocument {
+		return RateCorrection{}, false
+	}
+
+	if !ok {
+		return RateCorrection{Document: record, Stored: nil, Reparsed: set}, true
+	}
+	if stored.NominalRate == set.NominalRate && equalTime(stored.ChangedOn, set.ChangedOn) {
+		return RateCorrection{}, false
+	}
+
+	// The stored rate may have been crawled after the document was fetched, which the correction must not hide.
+	if stored.LastCrawledAt.After(set.LastCrawledAt) {
+		set.LastCrawledAt = stored.LastCrawledAt
+	}
+	return RateCorrection{Document: record, Stored: &stored, Reparsed: set}, true
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,220 @@
+package crawler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/archive"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// documentCrawler parses archived documents of its URLs by looking up their content.
+type documentCrawler struct {
+	staticCrawler
+	documents map[string]map[string][]model.InterestSet // url -> content -> parsed sets
+}
+
+func (c *documentCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	contents, ok := c.documents[url]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+	sets, ok := contents[string(content)]
+	if !ok {
+		return nil, errors.New("unexpected layout")
+	}
+
+	result := make([]model.InterestSet, 0, len(sets))
+	for _, set := range sets {
+		set.LastCrawledAt = fetchedAt
+		result = append(result, set)
+	}
+	return result, nil
+}
+
+func TestReparser_Reparse(t *testing.T) {
+	t.Parallel()
+
+	const (
+		listURL = "https://seb.example/list"
+		avgURL  = "https://seb.example/avg"
+	)
+	start := time.Date(2025, time.October, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		opts            ReparseOptions
+		wantDocuments   uint
+		wantFailed      uint
+		wantCorrections []string
+		wantApplied     uint
+	}{
+		{
+			name:            "corrections are only reported",
+			opts:            ReparseOptions{Bank: "", From: time.Time{}, To: time.Time{}, Apply: false},
+			wantDocuments:   4,
+			wantFailed:      1,
+			wantCorrections: []string{"SEB|listRate|3m=3.6", "SEB|averageRate|3m|2025-09=2.65"},
+			wantApplied:     0,
+		},
+		{
+			name:            "corrections are applied",
+			opts:            ReparseOptions{Bank: "", From: time.Time{}, To: time.Time{}, Apply: true},
+			wantDocuments:   4,
+			wantFailed:      1,
+			wantCorrections: []string{"SEB|listRate|3m=3.6", "SEB|averageRate|3m|2025-09=2.65"},
+			wantApplied:     2,
+		},
+		{
+			name:            "documents fetched after the range are skipped",
+			opts:            ReparseOptions{Bank: "", From: time.Time{}, To: start.Add(48 * time.Hour), Apply: true},
+			wantDocuments:   2,
+			wantFailed:      0,
+			wantCorrections: []string{"SEB|listRate|3m=3.6"},
+			wantApplied:     1,
+		},
+		{
+			name:            "rates of other banks are skipped",
+			opts:            ReparseOptions{Bank: "Nordea", From: time.Time{}, To: time.Time{}, Apply: true},
+			wantDocuments:   4,
+			wantFailed:      1,
+			wantCorrections: nil,
+			wantApplied:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := start
+			sourceArchive := archive.New(archive.NewMemoryStore(), func() time.Time { return current })
+			put := func(url, content string) {
+				t.Helper()
+				if _, err := sourceArchive.Put(url, []byte(content)); err != nil {
+					t.Fatalf("Put() error = %v", err)
+				}
+			}
+			put(listURL, "list v1")
+			put(avgURL, "avg v1")
+			current = current.Add(24 * time.Hour)
+			put(listURL, "list v1")
+			current = current.Add(24 * time.Hour)
+			put(avgURL, "avg v2") // September revised
+			put("https://nordea.example/rates", "not a SEB document")
+			put(listURL, "changed layout")
+
+			septemberV1 := avgRate("SEB", model.Term3months, 2.6, time.September, 2025)
+			september := avgRate("SEB", model.Term3months, 2.65, time.September, 2025)
+			august := avgRate("SEB", model.Term3months, 2.7, time.August, 2025)
+			parser := &documentCrawler{documents: map[string]map[string][]model.InterestSet{
+				listURL: {"list v1": {listRate("SEB", model.Term3months, 3.6), listRate("SEB", model.Term1year, 4.0)}},
+				avgURL:  {"avg v1": {septemberV1, august}, "avg v2": {september, august}},
+			}}
+
+			// The 3 months list rate was misparsed from the archived document, the 1 year list rate was crawled from a
+			// later version of the page that is not archived.
+			storedList3m := listRate("SEB", model.Term3months, 3.5)
+			storedList3m.Source = &model.SourceRef{URL: listURL, Hash: model.HashContent([]byte("list v1"))}
+			storedList1y := listRate("SEB", model.Term1year, 3.9)
+			storedList1y.Source = &model.SourceRef{URL: listURL, Hash: model.HashContent([]byte("list v2"))}
+			memStore := store.NewMemoryStore(nil, zap.NewNop())
+			for _, set := range []model.InterestSet{storedList3m, storedList1y, septemberV1, august} {
+				if err := memStore.UpsertInterestSet(set); err != nil {
+					t.Fatalf("UpsertInterestSet() error = %v", err)
+				}
+			}
+
+			reparser := NewReparser(memStore, sourceArchive, []SiteCrawler{&staticCrawler{}, parser}, newTestValidator(start), zap.NewNop())
+			report, err := reparser.Reparse(tt.opts)
+			if err != nil {
+				t.Fatalf("Reparse() error = %v", err)
+			}
+
+			if report.Documents != tt.wantDocuments || report.Failed != tt.wantFailed || report.Applied != tt.wantApplied {
+				t.Errorf("report = documents %d, failed %d, applied %d; want %d, %d, %d",
+					report.Documents, report.Failed, report.Applied, tt.wantDocuments, tt.wantFailed, tt.wantApplied)
+			}
+			var corrections []string
+			for _, correction := range report.Corrections {
+				corrections = append(corrections, correction.Reparsed.Key()+"="+correction.Reparsed.NominalRate.String())
+				if correction.Reparsed.Source == nil || correction.Reparsed.Source.URL != correction.Document.URL {
+					t.Errorf("Source of %s = %+v, want the reparsed document", correction.Reparsed.Key(), correction.Reparsed.Source)
+				}
+			}
+			if !reflect.DeepEqual(corrections, tt.wantCorrections) {
+				t.Errorf("corrections = %v, want %v", corrections, tt.wantCorrections)
+			}
+
+			sets, err := memStore.GetInterestSets()
+			if err != nil {
+				t.Fatalf("GetInterestSets() error = %v", err)
+			}
+			stored := map[string]string{}
+			for _, set := range sets {
+				stored[set.Key()] = set.NominalRate.String()
+			}
+			wantList3m := "3.5"
+			if tt.wantApplied > 0 {
+				wantList3m = "3.6"
+			}
+			if stored["SEB|listRate|3m"] != wantList3m || stored["SEB|listRate|1y"] != "3.9" {
+				t.Errorf("stored list rates = 3m %s, 1y %s; want 3m %s, 1y 3.9",
+					stored["SEB|listRate|3m"], stored["SEB|listRate|1y"], wantList3m)
+			}
+		})
+	}
+}
+
+func TestReparser_Reparse_RejectsImplausibleCorrections(t *testing.T) {
+	t.Parallel()
+
+	const avgURL = "https://seb.example/avg"
+	now := time.Date(2025, time.October, 1, 8, 0, 0, 0, time.UTC)
+	sourceArchive := archive.New(archive.NewMemoryStore(), func() time.Time { return now })
+	if _, err := sourceArchive.Put(avgURL, []byte("avg v1")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// A regressed parser reads the 3 months average rate in basis points and the 1 year one correctly.
+	stored3m := avgRate("SEB", model.Term3months, 2.6, time.September, 2025)
+	regressed3m := avgRate("SEB", model.Term3months, 260, time.September, 2025)
+	corrected1y := avgRate("SEB", model.Term1year, 2.8, time.September, 2025)
+	parser := &documentCrawler{documents: map[string]map[string][]model.InterestSet{
+		avgURL: {"avg v1": {regressed3m, corrected1y}},
+	}}
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	if err := memStore.UpsertInterestSet(stored3m); err != nil {
+		t.Fatalf("UpsertInterestSet() error = %v", err)
+	}
+
+	report, err := NewReparser(memStore, sourceArchive, []SiteCrawler{parser}, newTestValidator(now), zap.NewNop()).
+		Reparse(ReparseOptions{Bank: "", From: time.Time{}, To: time.Time{}, Apply: true})
+	if err != nil {
+		t.Fatalf("Reparse() error = %v", err)
+	}
+
+	if len(report.Corrections) != 2 || report.Rejected != 1 || report.Applied != 1 {
+		t.Fatalf("report = %d corrections, %d rejected, %d applied; want 2, 1, 1",
+			len(report.Corrections), report.Rejected, report.Applied)
+	}
+	if rules := violationRules(report.Corrections[0].Violations); rules[RuleRateRange] != SeverityReject {
+		t.Errorf("violations of %s = %v, want %s rejected", report.Corrections[0].Reparsed.Key(), rules, RuleRateRange)
+	}
+
+	sets, err := memStore.GetInterestSets()
+	if err != nil {
+		t.Fatalf("GetInterestSets() error = %v", err)
+	}
+	stored := map[string]string{}
+	for _, set := range sets {
+		stored[set.Key()] = set.NominalRate.String()
+	}
+	if stored[stored3m.Key()] != "2.6" || stored[corrected1y.Key()] != "2.8" {
+		t.Errorf("stored = %v, want the 3 months rate kept at 2.6 and the 1 year rate added at 2.8", stored)
+	}
+}
@@ -0,0 +1,176 @@
+package riksbank
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+const (
+	sweaObservationsURL string = "https://api.riksbank.se/swea/v1/Observations/"
+	swestrURL           string = "https://api.riksbank.se/swestr/v1/all/SWESTR"
+	policyRateSeriesID  string = "SECBREPOEFF"        // Riksbankens styrränta
+	stibor3MSeriesID    string = "SEDP3MSTIBORDELAYC" // 3 months STIBOR, published with a delay
+
+	// lookback is how far back every crawl fetches the series, so a few missed crawls leave no gaps.
+	lookback = 31 * 24 * time.Hour
+)
+
+var _ crawler.ReferenceRateCrawler = &RiksbankCrawler{}
+
+// RiksbankCrawler collects the policy rate, 3 months STIBOR and SWESTR from the Riksbank's public data APIs.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type RiksbankCrawler struct {
+	httpClient http.Client
+	logger     *zap.Logger
+}
+
+// sweaObservation is one day of a series of the SWEA (Sveriges Riksbank Statistics) API.
+type sweaObservation struct {
+	Date  string      `json:"date"`  // YYYY-MM-DD
+	Value *model.Rate `json:"value"` // percent, null on days without a value
+}
+
+// swestrObservation is one day of the SWESTR API. The volume and percentile fields are not needed.
+type swestrObservation struct {
+	Date string      `json:"date"` // YYYY-MM-DD
+	Rate *model.Rate `json:"rate"` // percent
+}
+
+func NewRiksbankCrawler(httpClient http.Client, logger *zap.Logger) *RiksbankCrawler {
+	return &RiksbankCrawler{
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+func (c *RiksbankCrawler) CrawlReferenceRates(channel chan<- model.ReferenceRate) {
+	crawlTime := time.Now().UTC()
+	from := crawlTime.Add(-lookback).Format(time.DateOnly)
+
+	var rates []model.ReferenceRate
+	for _, series := range []struct {
+		series model.ReferenceSeries
+		id     string
+	}{
+		{model.ReferencePolicyRate, policyRateSeriesID},
+		{model.ReferenceSTIBOR3M, stibor3MSeriesID},
+	} {
+		url := sweaObservationsURL + series.id + "/" + from
+		seriesRates, err := c.fetch(url, func(rawJSON string) ([]model.ReferenceRate, error) {
+			return parseSWEAObservations(rawJSON, series.series, crawlTime)
+		})
+		if err != nil {
+			c.logger.Error("failed fetching Riksbank series", zap.String("series", series.id), zap.Error(err))
+		}
+		rates = append(rates, seriesRates...)
+	}
+
+	swestrRates, err := c.fetch(swestrURL+"?fromDate="+from, func(rawJSON string) ([]model.ReferenceRate, error) {
+		return parseSWESTR(rawJSON, crawlTime)
+	})
+	if err != nil {
+		c.logger.Error("failed fetching SWESTR", zap.Error(err))
+	}
+	rates = append(rates, swestrRates...)
+
+	for _, rate := range rates {
+		channel <- rate
+	}
+}
+
+// fetch fetches url, parses it and points the rates to the fetched document.
+func (c *RiksbankCrawler) fetch(url string, parse func(rawJSON string) ([]model.ReferenceRate, error)) ([]model.ReferenceRate, error) {
+	rawJSON, err := c.httpClient.Fetch(url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s: %w", url, err)
+	}
+
+	rates, err := parse(rawJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	document := model.NewSourceRef(url, []byte(rawJSON))
+	for i := range rates {
+		source := document
+		source.Kind, source.Method = rates[i].Source.Kind, rates[i].Source.Method
+		rates[i].Source = &source
+	}
+	return rates, nil
+}
+
+// parseSWEAObservations parses the observations of one series of the SWEA API.
+func parseSWEAObservations(rawJSON string, series model.ReferenceSeries, crawlTime time.Time) ([]model.ReferenceRate, error) {
+	var observations []sweaObservation
+	if err := json.Unmarshal([]byte(rawJSON), &observations); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling SWEA observations of %s: %w", series, err)
+	}
+	if observations == nil {
+		return nil, fmt.Errorf("no SWEA observations of %s in response", series)
+	}
+
+	rates := make([]model.ReferenceRate, 0, len(observations))
+	for _, observation := range observations {
+		rate, err := newReferenceRate(series, observation.Date, observation.Value, crawlTime, "parseSWEAObservations")
+		if err != nil {
+			return nil, err
+		}
+		if rate != nil {
+			rates = append(rates, *rate)
+		}
+	}
+	return rates, nil
+}
+
+// parseSWESTR parses the observations of the SWESTR API.
+func parseSWESTR(rawJSON string, crawlTime time.Time) ([]model.ReferenceRate, error) {
+	var observations []swestrObservation
+	if err := json.Unmarshal([]byte(rawJSON), &observations); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling SWESTR observations: %w", err)
+	}
+	if observations == nil {
+		return nil, errors.New("no SWESTR observations in response")
+	}
+
+	rates := make([]model.ReferenceRate, 0, len(observations))
+	for _, observation := range observations {
+		rate, err := newReferenceRate(model.ReferenceSWESTR, observation.Date, observation.Rate, crawlTime, "parseSWESTR")
+		if err != nil {
+			return nil, err
+		}
+		if rate != nil {
+			rates = append(rates, *rate)
+		}
+	}
+	return rates, nil
+}
+
+// newReferenceRate builds the rate of one observation, or returns nil if the observation has no value.
+func newReferenceRate(
+	series model.ReferenceSeries, date string, value *model.Rate, crawlTime time.Time, method string,
+) (*model.ReferenceRate, error) {
+	if value == nil {
+		return nil, nil //nolint:nilnil // a day without a value is no error
+	}
+
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing %s observation date %q: %w", series, date, err)
+	}
+
+	return &model.ReferenceRate{
+		Series:        series,
+		Date:          day,
+		Rate:          *value,
+		LastCrawledAt: crawlTime,
+		Source:        &model.SourceRef{Kind: model.SourceKindJSON, Method: method},
+	}, nil
+}
@@ -0,0 +1,170 @@
+//nolint:revive,nolintlint // package name matches the package being tested
+package riksbank
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestRiksbankCrawler_CrawlReferenceRates(t *testing.T) {
+	t.Parallel()
+
+	policyJSON := crawlertest.LoadGoldenFile(t, "testdata/riksbank_policy_rate.json")
+	stiborJSON := crawlertest.LoadGoldenFile(t, "testdata/riksbank_stibor3m.json")
+	swestrJSON := crawlertest.LoadGoldenFile(t, "testdata/riksbank_swestr.json")
+
+	tests := []struct {
+		name      string
+		failURL   string
+		wantCount map[model.ReferenceSeries]int
+	}{
+		{
+			name:    "all series",
+			failURL: "",
+			wantCount: map[model.ReferenceSeries]int{
+				model.ReferencePolicyRate: 23, model.ReferenceSTIBOR3M: 23, model.ReferenceSWESTR: 22,
+			},
+		},
+		{
+			name:      "failing series does not stop the others",
+			failURL:   sweaObservationsURL + stibor3MSeriesID,
+			wantCount: map[model.ReferenceSeries]int{model.ReferencePolicyRate: 23, model.ReferenceSWESTR: 22},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fetched []string
+			client := &httpmock.ClientMock{
+				FetchFunc: func(url string, _ map[string]string) (string, error) {
+					fetched = append(fetched, url)
+					switch {
+					case tt.failURL != "" && strings.HasPrefix(url, tt.failURL):
+						return "", errors.New("network error")
+					case strings.HasPrefix(url, sweaObservationsURL+policyRateSeriesID+"/"):
+						return policyJSON, nil
+					case strings.HasPrefix(url, sweaObservationsURL+stibor3MSeriesID+"/"):
+						return stiborJSON, nil
+					case strings.HasPrefix(url, swestrURL+"?fromDate="):
+						return swestrJSON, nil
+					}
+					return "", errors.New("unexpected URL " + url)
+				},
+			}
+
+			rates := runCrawl(NewRiksbankCrawler(client, zap.NewNop()))
+
+			if len(fetched) != 3 {
+				t.Errorf("fetched %d URLs, want 3: %v", len(fetched), fetched)
+			}
+			count := map[model.ReferenceSeries]int{}
+			for _, rate := range rates {
+				count[rate.Series]++
+				if rate.Source == nil || !strings.HasPrefix(rate.Source.URL, "https://api.riksbank.se/") ||
+					rate.Source.Kind != model.SourceKindJSON || rate.Source.Hash == "" {
+					t.Errorf("Source of %s %s = %+v, want the fetched JSON document", rate.Series, rate.Date, rate.Source)
+				}
+			}
+			if len(count) != len(tt.wantCount) {
+				t.Errorf("series = %v, want %v", count, tt.wantCount)
+			}
+			for series, want := range tt.wantCount {
+				if count[series] != want {
+					t.Errorf("%s rates = %d, want %d", series, count[series], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSWEAObservations(t *testing.T) {
+	t.Parallel()
+
+	crawlTime := time.Date(2025, time.October, 16, 6, 0, 0, 0, time.UTC)
+	rates, err := parseSWEAObservations(crawlertest.LoadGoldenFile(t, "testdata/riksbank_policy_rate.json"),
+		model.ReferencePolicyRate, crawlTime)
+	if err != nil {
+		t.Fatalf("parseSWEAObservations() error = %v", err)
+	}
+
+	first, last := rates[0], rates[len(rates)-1]
+	if !first.Date.Equal(time.Date(2025, time.September, 15, 0, 0, 0, 0, time.UTC)) || first.Rate != model.RateFromPercent(2) {
+		t.Errorf("first rate = %s %s, want 2025-09-15 2", first.Date.Format(time.DateOnly), first.Rate)
+	}
+	if !last.Date.Equal(time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)) || last.Rate != model.RateFromPercent(1.75) {
+		t.Errorf("last rate = %s %s, want 2025-10-15 1.75", last.Date.Format(time.DateOnly), last.Rate)
+	}
+	if first.LastCrawledAt != crawlTime || first.Source.Method != "parseSWEAObservations" {
+		t.Errorf("first rate = %+v, want crawl time and extraction method", first)
+	}
+
+	tests := []struct {
+		name    string
+		rawJSON string
+		want    int
+		wantErr bool
+	}{
+		{name: "day without value is skipped", rawJSON: `[{"date":"2025-10-01","value":null},{"date":"2025-10-02","value":1.75}]`, want: 1},
+		{name: "empty series", rawJSON: `[]`, want: 0},
+		{name: "invalid date", rawJSON: `[{"date":"01/10/2025","value":1.75}]`, wantErr: true},
+		{name: "invalid JSON", rawJSON: `{invalid}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseSWEAObservations(tt.rawJSON, model.ReferenceSTIBOR3M, crawlTime)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSWEAObservations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("parseSWEAObservations() returned %d rates, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSWESTR(t *testing.T) {
+	t.Parallel()
+
+	crawlTime := time.Date(2025, time.October, 16, 6, 0, 0, 0, time.UTC)
+	rates, err := parseSWESTR(crawlertest.LoadGoldenFile(t, "testdata/riksbank_swestr.json"), crawlTime)
+	if err != nil {
+		t.Fatalf("parseSWESTR() error = %v", err)
+	}
+	if len(rates) != 22 {
+		t.Fatalf("parseSWESTR() returned %d rates, want 22", len(rates))
+	}
+	for _, rate := range rates {
+		if rate.Series != model.ReferenceSWESTR || rate.Rate < model.RateFromPercent(1.5) || rate.Rate > model.RateFromPercent(2.1) {
+			t.Errorf("rate = %s %s %s, want a SWESTR rate around 1.7-1.9", rate.Series, rate.Date.Format(time.DateOnly), rate.Rate)
+		}
+	}
+
+	crawlertest.TestInvalidJSON(t, func(rawJSON string) error {
+		_, err := parseSWESTR(rawJSON, crawlTime)
+		return err
+	})
+}
+
+func runCrawl(c *RiksbankCrawler) []model.ReferenceRate {
+	resultChan := make(chan model.ReferenceRate, 1000)
+	c.CrawlReferenceRates(resultChan)
+	close(resultChan)
+
+	rates := make([]model.ReferenceRate, 0, len(resultChan))
+	for rate := range resultChan {
+		rates = append(rates, rate)
+	}
+	return rates
+}
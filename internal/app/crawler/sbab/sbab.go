@@ -10,6 +10,7 @@ import (
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 	"go.uber.org/zap"
 )
 
@@ -20,14 +21,18 @@ const (
 )
 
 var (
-	_                    crawler.SiteCrawler = &SBABCrawler{}
-	sbabPeriodYYYYMMDDRx                     = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`) // YYYY-MM-DD
+	_ crawler.SiteCrawler    = &SBABCrawler{}
+	_ crawler.Fingerprinter  = &SBABCrawler{}
+	_ crawler.DocumentParser = &SBABCrawler{}
+
+	sbabPeriodRegex = regexp.MustCompile(`^P_(\d+)_(MONTHS?|YEARS?)$`)
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type SBABCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 // sbabListRatesResponse represents the JSON response for SBAB list rates.
@@ -47,19 +52,28 @@ type sbabAvgRatesResponse struct {
 }
 
 type sbabAvgRatePeriod struct {
-	Period      string   `json:"period"`       // YYYY-MM-DD format (last day of month)
-	ThreeMonths *float32 `json:"three_months"` //nolint:tagliatelle // external API uses snake_case
-	OneYear     *float32 `json:"one_year"`     //nolint:tagliatelle // external API uses snake_case
-	TwoYears    *float32 `json:"two_years"`    //nolint:tagliatelle // external API uses snake_case
-	ThreeYears  *float32 `json:"three_years"`  //nolint:tagliatelle // external API uses snake_case
-	FourYears   *float32 `json:"four_years"`   //nolint:tagliatelle // external API uses snake_case
-	FiveYears   *float32 `json:"five_years"`   //nolint:tagliatelle // external API uses snake_case
-	SevenYears  *float32 `json:"seven_years"`  //nolint:tagliatelle // external API uses snake_case
-	TenYears    *float32 `json:"ten_years"`    //nolint:tagliatelle // external API uses snake_case
+	Period      string      `json:"period"`       // YYYY-MM-DD format (last day of month)
+	ThreeMonths *model.Rate `json:"three_months"` //nolint:tagliatelle // external API uses snake_case
+	OneYear     *model.Rate `json:"one_year"`     //nolint:tagliatelle // external API uses snake_case
+	TwoYears    *model.Rate `json:"two_years"`    //nolint:tagliatelle // external API uses snake_case
+	ThreeYears  *model.Rate `json:"three_years"`  //nolint:tagliatelle // external API uses snake_case
+	FourYears   *model.Rate `json:"four_years"`   //nolint:tagliatelle // external API uses snake_case
+	FiveYears   *model.Rate `json:"five_years"`   //nolint:tagliatelle // external API uses snake_case
+	SevenYears  *model.Rate `json:"seven_years"`  //nolint:tagliatelle // external API uses snake_case
+	TenYears    *model.Rate `json:"ten_years"`    //nolint:tagliatelle // external API uses snake_case
 }
 
 func NewSBABCrawler(httpClient http.Client, logger *zap.Logger) *SBABCrawler {
-	return &SBABCrawler{httpClient: httpClient, logger: logger}
+	return &SBABCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(sbabBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SBABCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *SBABCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -80,12 +94,37 @@ func (c *SBABCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates API response again.
+func (c *SBABCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case sbabListRatesURL:
+		return c.parseListRates(string(content), fetchedAt)
+	case sbabAvgRatesURL:
+		return c.parseAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *SBABCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet, error) {
 	rawJSON, err := c.httpClient.Fetch(sbabListRatesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading SBAB list rates API: %w", err)
 	}
 
+	interestSets, err := c.parseListRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, sbabListRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseListRates parses the list rates API response.
+func (c *SBABCrawler) parseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(sbabListRatesURL, "list rates", rawJSON)
+
 	var response sbabListRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling SBAB list rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -102,7 +141,7 @@ func (c *SBABCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet,
 			continue
 		}
 
-		nominalRate, err := strconv.ParseFloat(rate.InterestRate, 32)
+		nominalRate, err := utils.ParseRate(rate.InterestRate)
 		if err != nil {
 			c.logger.Warn("failed to parse SBAB interest rate",
 				zap.String("interestRate", rate.InterestRate),
@@ -126,7 +165,7 @@ func (c *SBABCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet,
 			Bank:          sbabBankName,
 			Type:          model.TypeListRate,
 			Term:          term,
-			NominalRate:   float32(nominalRate),
+			NominalRate:   nominalRate,
 			ChangedOn:     changedOn,
 			LastCrawledAt: crawlTime,
 
@@ -136,6 +175,7 @@ func (c *SBABCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet,
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
 	return interestSets, nil
 }
 
@@ -145,6 +185,19 @@ func (c *SBABCrawler) fetchAverageRates(crawlTime time.Time) ([]model.InterestSe
 		return nil, fmt.Errorf("failed reading SBAB average rates API: %w", err)
 	}
 
+	interestSets, err := c.parseAverageRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, sbabAvgRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseAverageRates parses the average rates API response.
+func (c *SBABCrawler) parseAverageRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(sbabAvgRatesURL, "average rates", rawJSON)
+
 	var response sbabAvgRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
 		c.logger.Error("failed unmarshalling SBAB average rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -162,7 +215,7 @@ func (c *SBABCrawler) fetchAverageRates(crawlTime time.Time) ([]model.InterestSe
 		// Add rates for each term if available (not null)
 		termRates := []struct {
 			term model.Term
-			rate *float32
+			rate *model.Rate
 		}{
 			{model.Term3months, period.ThreeMonths},
 			{model.Term1year, period.OneYear},
@@ -194,58 +247,27 @@ func (c *SBABCrawler) fetchAverageRates(crawlTime time.Time) ([]model.InterestSe
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseAverageRates")
 	return interestSets, nil
 }
 
 // parseSBABPeriodToTerm converts SBAB's period format to model.Term.
 // Period format examples: "P_3_MONTHS", "P_1_YEAR", "P_2_YEARS", etc.
 func parseSBABPeriodToTerm(period string) (model.Term, error) {
-	switch period {
-	case "P_3_MONTHS":
-		return model.Term3months, nil
-	case "P_1_YEAR":
-		return model.Term1year, nil
-	case "P_2_YEARS":
-		return model.Term2years, nil
-	case "P_3_YEARS":
-		return model.Term3years, nil
-	case "P_4_YEARS":
-		return model.Term4years, nil
-	case "P_5_YEARS":
-		return model.Term5years, nil
-	case "P_7_YEARS":
-		return model.Term7years, nil
-	case "P_10_YEARS":
-		return model.Term10years, nil
-	default:
-		return "", fmt.Errorf("unsupported SBAB period: %s", period)
+	matches := sbabPeriodRegex.FindStringSubmatch(period)
+	if matches == nil {
+		return model.Term{}, fmt.Errorf("unsupported SBAB period: %s", period)
 	}
+
+	count, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return model.Term{}, fmt.Errorf("unsupported SBAB period %s: %w", period, err)
+	}
+	return utils.TermFromCount(count, matches[2]) //nolint:wrapcheck // utils error names the term
 }
 
 // parseSBABAvgPeriod converts a YYYY-MM-DD period string to model.AvgMonth.
 // The day is ignored as SBAB uses the last day of the month.
 func parseSBABAvgPeriod(period string) (model.AvgMonth, error) {
-	matches := sbabPeriodYYYYMMDDRx.FindStringSubmatch(period)
-	if len(matches) != 4 {
-		return model.AvgMonth{}, fmt.Errorf("failed to match period format YYYY-MM-DD: %s", period)
-	}
-
-	year, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %w", err)
-	}
-
-	if month < 1 || month > 12 {
-		return model.AvgMonth{}, fmt.Errorf("invalid month: %d", month)
-	}
-
-	return model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(month),
-	}, nil
+	return utils.ParseMonthYear(period)
 }
@@ -288,8 +288,20 @@ func TestParseSBABPeriodToTerm(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "unsupported period",
-			period:  "P_6_YEARS",
+			name:    "1 month",
+			period:  "P_1_MONTH",
+			want:    model.Term1month,
+			wantErr: false,
+		},
+		{
+			name:    "15 years",
+			period:  "P_15_YEARS",
+			want:    model.Term15years,
+			wantErr: false,
+		},
+		{
+			name:    "zero years",
+			period:  "P_0_YEARS",
 			wantErr: true,
 		},
 		{
@@ -352,9 +364,11 @@ func TestParseSBABAvgPeriod(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:    "invalid format - missing day",
-			input:   "2025-11",
-			wantErr: true,
+			name:      "period without day",
+			input:     "2025-11",
+			wantYear:  2025,
+			wantMonth: time.November,
+			wantErr:   false,
 		},
 		{
 			name:    "invalid format - wrong separator",
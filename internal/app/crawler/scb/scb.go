@@ -0,0 +1,237 @@
+package scb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	scbBankName model.Bank = model.BenchmarkBank
+	// scbAvgRatesURL selects every month and fixation period of the MFI table of new housing loan agreements with
+	// households from the PxWeb API.
+	scbAvgRatesURL string = "https://statistikdatabasen.scb.se/api/v2/tables/TAB1053/data" +
+		"?lang=en&outputFormat=json-stat2&valueCodes[Rantebindningstid]=*&valueCodes[Tid]=*"
+
+	scbTermDimension = "Rantebindningstid"
+	scbTimeDimension = "Tid"
+)
+
+var (
+	_ crawler.SiteCrawler    = &SCBCrawler{}
+	_ crawler.Fingerprinter  = &SCBCrawler{}
+	_ crawler.DocumentParser = &SCBCrawler{}
+
+	// scbTermRegex matches the upper bound of a fixation period, like "Over 1 year and up to 3 years". The open-ended
+	// "Over 10 years" has no term to compare with and is skipped.
+	scbTermRegex  = regexp.MustCompile(`(?i)up to (\d+) (months?|years?)`)
+	scbMonthRegex = regexp.MustCompile(`^(\d{4})M(\d{2})$`)
+)
+
+// SCBCrawler imports the market-wide average rate of new mortgages that Statistics Sweden publishes every month. The
+// rates are stored as average rates of model.BenchmarkBank.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type SCBCrawler struct {
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+// scbDataset is a JSON-stat 2.0 dataset. Value holds one entry per combination of the dimension categories, in
+// row-major order of ID, and null where SCB has no value.
+type scbDataset struct {
+	ID        []string                `json:"id"`
+	Size      []int                   `json:"size"`
+	Dimension map[string]scbDimension `json:"dimension"`
+	Value     []*model.Rate           `json:"value"`
+}
+
+type scbDimension struct {
+	Category struct {
+		Index map[string]int    `json:"index"` // category code → position in the dimension
+		Label map[string]string `json:"label"` // category code → label, e.g. "Over 1 year and up to 3 years"
+	} `json:"category"`
+}
+
+func NewSCBCrawler(httpClient http.Client, logger *zap.Logger) *SCBCrawler {
+	return &SCBCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(scbBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SCBCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func (c *SCBCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	avgRates, err := c.fetchAverageRates(crawlTime)
+	if err != nil {
+		c.logger.Error("failed fetching SCB average rates", zap.Error(err))
+	}
+
+	for _, set := range avgRates {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of the PxWeb API response again.
+func (c *SCBCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	if url != scbAvgRatesURL {
+		return nil, crawler.ErrUnknownSource
+	}
+	return c.parseAverageRates(string(content), fetchedAt)
+}
+
+func (c *SCBCrawler) fetchAverageRates(crawlTime time.Time) ([]model.InterestSet, error) {
+	rawJSON, err := c.httpClient.Fetch(scbAvgRatesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading SCB PxWeb API: %w", err)
+	}
+
+	interestSets, err := c.parseAverageRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, scbAvgRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseAverageRates parses the JSON-stat dataset into one average rate per fixation period and month.
+func (c *SCBCrawler) parseAverageRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(scbAvgRatesURL, "average rates", rawJSON)
+
+	var dataset scbDataset
+	if err := json.Unmarshal([]byte(rawJSON), &dataset); err != nil {
+		c.logger.Error("failed unmarshalling SCB average rates", zap.Error(err), zap.String("rawJSON", rawJSON))
+		return nil, fmt.Errorf("failed unmarshalling SCB average rates: %w", err)
+	}
+	strides, err := dataset.strides()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := c.parseTerms(dataset.Dimension[scbTermDimension])
+	months := c.parseMonths(dataset.Dimension[scbTimeDimension])
+
+	interestSets := []model.InterestSet{}
+	for termPos := range len(dataset.Dimension[scbTermDimension].Category.Index) {
+		term, ok := terms[termPos]
+		if !ok {
+			continue
+		}
+		for monthPos := range len(dataset.Dimension[scbTimeDimension].Category.Index) {
+			month, ok := months[monthPos]
+			rate := dataset.Value[termPos*strides[scbTermDimension]+monthPos*strides[scbTimeDimension]]
+			if !ok || rate == nil {
+				continue // no value published for this month
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				AverageReferenceMonth: &month,
+				Bank:                  scbBankName,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           *rate,
+				LastCrawledAt:         crawlTime,
+
+				RatioDiscountBoundaries: nil,
+				UnionDiscount:           false,
+				ChangedOn:               nil,
+			})
+		}
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseAverageRates")
+	return interestSets, nil
+}
+
+// strides returns the distance in Value between two neighbouring categories of every dimension. All dimensions but
+// the fixation period and the month must hold a single category.
+func (d scbDataset) strides() (map[string]int, error) {
+	if len(d.ID) == 0 || len(d.ID) != len(d.Size) {
+		return nil, errors.New("no dimensions in SCB dataset")
+	}
+
+	strides := make(map[string]int, len(d.ID))
+	stride := 1
+	for i := len(d.ID) - 1; i >= 0; i-- {
+		id := d.ID[i]
+		if id != scbTermDimension && id != scbTimeDimension && d.Size[i] != 1 {
+			return nil, fmt.Errorf("unexpected SCB dimension %s with %d categories", id, d.Size[i])
+		}
+		if len(d.Dimension[id].Category.Index) != d.Size[i] {
+			return nil, fmt.Errorf("SCB dimension %s has %d categories, want %d", id, len(d.Dimension[id].Category.Index), d.Size[i])
+		}
+		strides[id] = stride
+		stride *= d.Size[i]
+	}
+
+	for _, id := range []string{scbTermDimension, scbTimeDimension} {
+		if _, ok := strides[id]; !ok {
+			return nil, fmt.Errorf("dimension %s missing in SCB dataset", id)
+		}
+	}
+	if len(d.Value) != stride {
+		return nil, fmt.Errorf("SCB dataset has %d values, want %d", len(d.Value), stride)
+	}
+	return strides, nil
+}
+
+// parseTerms maps the position of every fixation period with an upper bound to its term.
+func (c *SCBCrawler) parseTerms(dimension scbDimension) map[int]model.Term {
+	terms := map[int]model.Term{}
+	for code, pos := range dimension.Category.Index {
+		label := dimension.Category.Label[code]
+		matches := scbTermRegex.FindStringSubmatch(label)
+		if matches == nil {
+			c.logger.Debug("SCB fixation period without upper bound - skipping", zap.String("label", label))
+			continue
+		}
+
+		count, _ := strconv.Atoi(matches[1]) // the regex only matches digits
+		term, err := utils.TermFromCount(count, matches[2])
+		if err != nil {
+			c.logger.Warn("SCB fixation period not supported - skipping", zap.String("label", label), zap.Error(err))
+			continue
+		}
+		terms[pos] = term
+	}
+	return terms
+}
+
+// parseMonths maps the position of every month, coded like "2025M09", to its month.
+func (c *SCBCrawler) parseMonths(dimension scbDimension) map[int]model.AvgMonth {
+	months := map[int]model.AvgMonth{}
+	for code, pos := range dimension.Category.Index {
+		matches := scbMonthRegex.FindStringSubmatch(code)
+		if matches == nil {
+			c.logger.Warn("failed parsing SCB month - skipping", zap.String("month", code))
+			continue
+		}
+
+		year, _ := strconv.Atoi(matches[1])
+		month, _ := strconv.Atoi(matches[2])
+		if month < 1 || month > 12 {
+			c.logger.Warn("failed parsing SCB month - skipping", zap.String("month", code))
+			continue
+		}
+		months[pos] = model.AvgMonth{Year: uint(year), Month: time.Month(month)} //nolint:gosec // year has four digits
+	}
+	return months
+}
@@ -0,0 +1,195 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package scb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestSCBCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	avgRatesJSON := crawlertest.LoadGoldenFile(t, "testdata/scb_avg_rates.json")
+
+	tests := []struct {
+		name         string
+		mockFetch    func(url string, headers map[string]string) (string, error)
+		wantAvgRates int
+	}{
+		{
+			name: "successful crawl extracts average rates",
+			mockFetch: func(url string, _ map[string]string) (string, error) {
+				if url != scbAvgRatesURL {
+					return "", errors.New("unexpected URL " + url)
+				}
+				return avgRatesJSON, nil
+			},
+			wantAvgRates: 59, // 5 fixation periods with upper bound × 12 months, one value missing
+		},
+		{
+			name: "fetch error returns no results",
+			mockFetch: func(_ string, _ map[string]string) (string, error) {
+				return "", errors.New("network error")
+			},
+			wantAvgRates: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := crawlertest.RunCrawl(t, NewSCBCrawler(&httpmock.ClientMock{FetchFunc: tt.mockFetch}, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != 0 {
+				t.Errorf("list rate count = %d, want 0", listRateCount)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, model.BenchmarkBank)
+			for _, r := range results {
+				if r.Source == nil || r.Source.URL != scbAvgRatesURL || r.Source.Kind != model.SourceKindJSON || r.Source.Hash == "" {
+					t.Errorf("Source of %s = %+v, want the fetched JSON document", r.Key(), r.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestSCBCrawler_parseAverageRates(t *testing.T) {
+	t.Parallel()
+
+	avgRatesJSON := crawlertest.LoadGoldenFile(t, "testdata/scb_avg_rates.json")
+	crawlTime := time.Date(2025, 11, 3, 6, 0, 0, 0, time.UTC)
+	crawler := &SCBCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.parseAverageRates(avgRatesJSON, crawlTime)
+	if err != nil {
+		t.Fatalf("parseAverageRates() error = %v", err)
+	}
+
+	rates := map[model.Term]map[model.AvgMonth]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertAverageRateFields(t, r, model.BenchmarkBank, crawlTime)
+		if rates[r.Term] == nil {
+			rates[r.Term] = map[model.AvgMonth]model.Rate{}
+		}
+		rates[r.Term][*r.AverageReferenceMonth] = r.NominalRate
+	}
+
+	wantTerms := []model.Term{model.Term3months, model.Term1year, model.Term3years, model.Term5years, model.Term10years}
+	if len(rates) != len(wantTerms) {
+		t.Errorf("terms = %d, want %d", len(rates), len(wantTerms))
+	}
+	for _, term := range wantTerms {
+		if rates[term] == nil {
+			t.Errorf("missing term %s", term)
+		}
+	}
+
+	tests := []struct {
+		term  model.Term
+		month model.AvgMonth
+		want  model.Rate
+	}{
+		{term: model.Term3months, month: model.AvgMonth{Year: 2024, Month: time.October}, want: model.RateFromPercent(3.85)},
+		{term: model.Term3months, month: model.AvgMonth{Year: 2025, Month: time.September}, want: model.RateFromPercent(2.83)},
+		{term: model.Term10years, month: model.AvgMonth{Year: 2025, Month: time.January}, want: model.RateFromPercent(3.29)},
+	}
+	for _, tt := range tests {
+		if got := rates[tt.term][tt.month]; got != tt.want {
+			t.Errorf("rate of %s in %d-%02d = %s, want %s", tt.term, tt.month.Year, tt.month.Month, got, tt.want)
+		}
+	}
+	if got, ok := rates[model.Term10years][model.AvgMonth{Year: 2025, Month: time.February}]; ok {
+		t.Errorf("rate of 10y in 2025-02 = %s, want none for the missing value", got)
+	}
+}
+
+func TestSCBCrawler_parseAverageRates_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	crawler := &SCBCrawler{logger: zap.NewNop()}
+	crawlertest.TestInvalidJSON(t, func(rawJSON string) error {
+		_, err := crawler.parseAverageRates(rawJSON, time.Now())
+		return err
+	})
+}
+
+func TestSCBCrawler_parseAverageRates_UnexpectedShape(t *testing.T) {
+	t.Parallel()
+
+	crawler := &SCBCrawler{logger: zap.NewNop()}
+	dimensions := `"dimension": {
+		"Rantebindningstid": {"category": {"index": {"1": 0}, "label": {"1": "Up to 3 months (floating rate)"}}},
+		"ContentsCode": {"category": {"index": {"A": 0, "B": 1}, "label": {"A": "Interest rate", "B": "Volume"}}},
+		"Tid": {"category": {"index": {"2025M09": 0}, "label": {"2025M09": "2025M09"}}}
+	}`
+
+	tests := []struct {
+		name    string
+		rawJSON string
+		wantErr string
+	}{
+		{
+			name:    "additional dimension with several categories",
+			rawJSON: `{"id": ["Rantebindningstid", "ContentsCode", "Tid"], "size": [1, 2, 1], ` + dimensions + `, "value": [2.5, 100]}`,
+			wantErr: "unexpected SCB dimension ContentsCode",
+		},
+		{
+			name:    "missing month dimension",
+			rawJSON: `{"id": ["Rantebindningstid"], "size": [1], ` + dimensions + `, "value": [2.5]}`,
+			wantErr: "dimension Tid missing",
+		},
+		{
+			name:    "too few values",
+			rawJSON: `{"id": ["Rantebindningstid", "Tid"], "size": [1, 1], ` + dimensions + `, "value": []}`,
+			wantErr: "has 0 values, want 1",
+		},
+		{
+			name:    "size differs from categories",
+			rawJSON: `{"id": ["Rantebindningstid", "Tid"], "size": [1, 2], ` + dimensions + `, "value": [2.5, 2.6]}`,
+			wantErr: "has 1 categories, want 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := crawler.parseAverageRates(tt.rawJSON, time.Now())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseAverageRates() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSCBCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 11, 3, 6, 0, 0, 0, time.UTC)
+	crawler := &SCBCrawler{logger: zap.NewNop()}
+	content := crawlertest.LoadGoldenFileBytes(t, "testdata/scb_avg_rates.json")
+
+	results, err := crawler.ParseDocument(scbAvgRatesURL, content, fetchedAt)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	if len(results) != 59 {
+		t.Errorf("ParseDocument() returned %d rates, want 59", len(results))
+	}
+
+	if _, err := crawler.ParseDocument("https://www.scb.se/", content, fetchedAt); !errors.Is(err, crawlertest.ErrUnknownSource) {
+		t.Errorf("ParseDocument(unknown URL) error = %v, want ErrUnknownSource", err)
+	}
+}
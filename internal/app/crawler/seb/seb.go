@@ -25,33 +25,44 @@ const (
 )
 
 var (
-	_                      crawler.SiteCrawler = &SebBankCrawler{}
-	jsFileRegex                                = regexp.MustCompile(`main\.[a-zA-Z0-9]+\.js`)
-	apiKeyRegex                                = regexp.MustCompile(`x-api-key":"(.*?)"`)
-	isoDateRegex                               = regexp.MustCompile(`^((\d{4})-(0[1-9]|1[0-2])-([0-2][1-9]|[1-3]0|3[01])).*$`) // YYYY-MM-DD
-	yearMonthReferenceDate                     = regexp.MustCompile(`^(\d{2})(0[1-9]|1[0-2])$`)                                // YYMM
+	_                      crawler.SiteCrawler    = &SebBankCrawler{}
+	_                      crawler.Fingerprinter  = &SebBankCrawler{}
+	_                      crawler.DocumentParser = &SebBankCrawler{}
+	jsFileRegex                                   = regexp.MustCompile(`main\.[a-zA-Z0-9]+\.js`)
+	apiKeyRegex                                   = regexp.MustCompile(`x-api-key":"(.*?)"`)
+	yearMonthReferenceDate                        = regexp.MustCompile(`^(\d{2})(0[1-9]|1[0-2])$`) // YYMM
 )
 
 //nolint:revive // Bank name prefix is intentional for clarity
 type SebBankCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 type sebListRatesResponseItem struct {
-	AdjustmentTerm string  `json:"adjustmentTerm"`
-	Change         float32 `json:"change"`
-	StartDate      string  `json:"startDate"`
-	Value          float32 `json:"value"`
+	AdjustmentTerm string     `json:"adjustmentTerm"`
+	Change         float32    `json:"change"`
+	StartDate      string     `json:"startDate"`
+	Value          model.Rate `json:"value"`
 }
 
 type sebAverageRatesResponse struct {
-	Period uint               `json:"period"`
-	Rates  map[string]float32 `json:"rates"`
+	Period uint                  `json:"period"`
+	Rates  map[string]model.Rate `json:"rates"`
 }
 
 func NewSebBankCrawler(httpClient http.Client, logger *zap.Logger) *SebBankCrawler {
-	return &SebBankCrawler{httpClient: httpClient, logger: logger}
+	return &SebBankCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(sebBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SebBankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *SebBankCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -78,6 +89,19 @@ func (c *SebBankCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates API response again. The page and script only
+// fetched to find the API key hold no rates.
+func (c *SebBankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case sebListRateURL:
+		return c.parseListRates(string(content), fetchedAt)
+	case sebAverageRatesURL:
+		return c.parseAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *SebBankCrawler) fetchAPIKey() (string, error) {
 	rawHTML, err := c.httpClient.Fetch(sebAvgCurrentHTMLURL, nil)
 	if err != nil {
@@ -110,6 +134,19 @@ func (c *SebBankCrawler) fetchListRates(apiKey string, crawlTime time.Time) ([]m
 		return nil, fmt.Errorf("failed reading SEB list rates API: %w", err)
 	}
 
+	interestSets, err := c.parseListRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, sebListRateURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseListRates parses the list rates API response.
+func (c *SebBankCrawler) parseListRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(sebListRateURL, "list rates", rawJSON)
+
 	var listRates []sebListRatesResponseItem
 	if err := json.Unmarshal([]byte(rawJSON), &listRates); err != nil {
 		c.logger.Error("failed unmarshalling SEB list rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -124,7 +161,7 @@ func (c *SebBankCrawler) fetchListRates(apiKey string, crawlTime time.Time) ([]m
 			continue
 		}
 
-		changeDate, err := parseSEBChangeDate(rate.StartDate, isoDateRegex)
+		changeDate, err := parseSEBChangeDate(rate.StartDate)
 		if err != nil {
 			c.logger.Warn("failed parsing SEB list rate change date", zap.Any("rateObj", rate), zap.Error(err))
 			continue
@@ -144,6 +181,7 @@ func (c *SebBankCrawler) fetchListRates(apiKey string, crawlTime time.Time) ([]m
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseListRates")
 	return interestSets, nil
 }
 
@@ -164,6 +202,19 @@ func (c *SebBankCrawler) fetchAverageRates(apiKey string, crawlTime time.Time) (
 		return nil, fmt.Errorf("failed reading SEB average rates API: %w", err)
 	}
 
+	interestSets, err := c.parseAverageRates(rawJSON, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, sebAverageRatesURL, []byte(rawJSON))
+	return interestSets, nil
+}
+
+// parseAverageRates parses the average rates API response.
+func (c *SebBankCrawler) parseAverageRates(rawJSON string, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordRawJSON(sebAverageRatesURL, "average rates", rawJSON)
+
 	var avgRates []sebAverageRatesResponse
 	if err := json.Unmarshal([]byte(rawJSON), &avgRates); err != nil {
 		c.logger.Error("failed unmarshalling SEB average rates", zap.Error(err), zap.String("rawJSON", rawJSON))
@@ -203,6 +254,7 @@ func (c *SebBankCrawler) fetchAverageRates(apiKey string, crawlTime time.Time) (
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindJSON, "parseAverageRates")
 	return interestSets, nil
 }
 
@@ -222,33 +274,14 @@ func parseReferenceMonth(data uint, regex *regexp.Regexp) (model.AvgMonth, error
 		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %w", err)
 	}
 
-	// assume all double-digit year numbers lower than 40 are from the 21st century, otherwise 20th century. This will
-	// ensure that this function works until the year 2039 and assumes we don't get historical data from before 1940
-	// presented in this format.
-	if year < 40 {
-		year += 2000
-	} else {
-		year += 1900
-	}
-
 	return model.AvgMonth{
-		Year:  uint(year),
+		Year:  uint(utils.ExpandTwoDigitYear(year)),
 		Month: time.Month(month),
 	}, nil
 }
 
-func parseSEBChangeDate(str string, regex *regexp.Regexp) (time.Time, error) {
-	str = utils.NormalizeSpaces(str)
-
-	matches := regex.FindStringSubmatch(str)
-	if len(matches) != 5 {
-		return time.Time{}, fmt.Errorf("failed to match change date")
-	}
-
-	date, err := time.Parse("2006-01-02", matches[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse change date: %w", err)
-	}
-
-	return date, nil
+// parseSEBChangeDate parses the date part of a timestamp like "2025-09-25T04:00:00Z".
+func parseSEBChangeDate(str string) (time.Time, error) {
+	date, _, _ := strings.Cut(utils.NormalizeSpaces(str), "T")
+	return utils.ParseSwedishDate(date)
 }
@@ -26,7 +26,7 @@ func assertSEBListRateFields(t *testing.T, r model.InterestSet, crawlTime time.T
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -47,7 +47,7 @@ func assertSEBAverageRateFields(t *testing.T, r model.InterestSet, crawlTime tim
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
@@ -551,7 +551,7 @@ func TestParseSEBChangeDate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := parseSEBChangeDate(tt.input, isoDateRegex)
+			got, err := parseSEBChangeDate(tt.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseSEBChangeDate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,110 @@
+package crawler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+)
+
+const segmentNumberPattern = `(\d+(?:[.,]\d+)?)`
+
+var (
+	segmentRatioRangeRegex  = regexp.MustCompile(segmentNumberPattern + ` ?%? ?(?:-|–|till) ?` + segmentNumberPattern + ` ?%`)
+	segmentRatioMaxRegex    = regexp.MustCompile(`(?:upp till|under|högst|max(?:imalt)?) ` + segmentNumberPattern + ` ?%`)
+	segmentRatioMinRegex    = regexp.MustCompile(`(?:över|från|minst) ` + segmentNumberPattern + ` ?%`)
+	segmentAmountRangeRegex = regexp.MustCompile(segmentNumberPattern + ` ?(?:mkr|miljoner)? ?(?:-|–|till) ?` + segmentNumberPattern + ` ?(?:mkr|miljoner)`)
+	segmentAmountMaxRegex   = regexp.MustCompile(`(?:upp till|under|högst|max(?:imalt)?) ` + segmentNumberPattern + ` ?(?:mkr|miljoner)`)
+	segmentAmountMinRegex   = regexp.MustCompile(`(?:över|från|minst) ` + segmentNumberPattern + ` ?(?:mkr|miljoner)`)
+	segmentRiskClassRegex   = regexp.MustCompile(`risk ?klass|risk class`)
+)
+
+// ParseAverageSegment returns the segment of an average rate series published under label, e.g. "Belåningsgrad
+// 60–85 %" or "Lån över 3 mkr". Loan-to-value and loan amount boundaries are read from the label where it states them,
+// other labels like "Riskklass A" only name the series.
+func ParseAverageSegment(label string) model.AverageSegment {
+	label = utils.NormalizeSpaces(label)
+	segment := model.AverageSegment{Label: label}
+	text := strings.ToLower(label)
+
+	switch {
+	case segmentRatioRangeRegex.MatchString(text):
+		m := segmentRatioRangeRegex.FindStringSubmatch(text)
+		segment.MinRatio, segment.MaxRatio = segmentRatio(m[1]), segmentRatio(m[2])
+	case segmentRatioMaxRegex.MatchString(text):
+		segment.MaxRatio = segmentRatio(segmentRatioMaxRegex.FindStringSubmatch(text)[1])
+	case segmentRatioMinRegex.MatchString(text):
+		segment.MinRatio = segmentRatio(segmentRatioMinRegex.FindStringSubmatch(text)[1])
+	}
+
+	switch {
+	case segmentAmountRangeRegex.MatchString(text):
+		m := segmentAmountRangeRegex.FindStringSubmatch(text)
+		segment.MinAmount, segment.MaxAmount = segmentAmount(m[1]), segmentAmount(m[2])
+	case segmentAmountMaxRegex.MatchString(text):
+		segment.MaxAmount = segmentAmount(segmentAmountMaxRegex.FindStringSubmatch(text)[1])
+	case segmentAmountMinRegex.MatchString(text):
+		segment.MinAmount = segmentAmount(segmentAmountMinRegex.FindStringSubmatch(text)[1])
+	}
+
+	return segment
+}
+
+// SetAverageSegment assigns the segment parsed from label to every set.
+func SetAverageSegment(sets []model.InterestSet, label string) {
+	for i := range sets {
+		segment := ParseAverageSegment(label)
+		sets[i].AverageSegment = &segment
+	}
+}
+
+// segmentRatio converts a percentage like "85" to the ratio 0.85.
+func segmentRatio(number string) float32 {
+	percent, _ := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 32)
+	return float32(percent / 100)
+}
+
+// segmentAmount converts an amount in millions like "1,5" to SEK.
+func segmentAmount(number string) uint {
+	millions, _ := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+	return uint(millions * 1_000_000)
+}
+
+// SeriesLabels returns the labels of the average rate series a bank publishes under the given headings, nil if there is
+// only one series or the headings don't tell the series apart. Each heading must name a risk class, a loan-to-value
+// band or a loan amount band, and no two the same. Other headings, or labels made up from the order of the tables,
+// would file a series' history under another series as soon as the bank renames, adds or reorders a table.
+func SeriesLabels(headings []string) []string {
+	if len(headings) < 2 {
+		return nil
+	}
+
+	labels := make([]string, len(headings))
+	seen := make(map[string]bool, len(headings))
+	for i, heading := range headings {
+		heading = utils.NormalizeSpaces(heading)
+		if seen[heading] || !isSegmentLabel(heading) {
+			return nil
+		}
+		seen[heading] = true
+		labels[i] = heading
+	}
+	return labels
+}
+
+// isSegmentLabel reports whether label names a risk class or the loan-to-value or loan amount band of a series.
+func isSegmentLabel(label string) bool {
+	return segmentRiskClassRegex.MatchString(strings.ToLower(label)) || ParseAverageSegment(label).Bounded()
+}
+
+// SeriesTableName names the table of the i-th series of a source in its fingerprint, e.g. "average rates 2". The first
+// keeps the plain name, so that a source publishing a single series keeps its fingerprint.
+func SeriesTableName(table string, i int) string {
+	if i == 0 {
+		return table
+	}
+	return fmt.Sprintf("%s %d", table, i+1)
+}
@@ -0,0 +1,70 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestParseAverageSegment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		label string
+		want  model.AverageSegment
+	}{
+		{label: "Riskklass A", want: model.AverageSegment{Label: "Riskklass A"}},
+		{label: "Belåningsgrad 60–85 %", want: model.AverageSegment{Label: "Belåningsgrad 60–85 %", MinRatio: 0.6, MaxRatio: 0.85}},
+		{label: "Belåningsgrad  upp till 60 %", want: model.AverageSegment{Label: "Belåningsgrad upp till 60 %", MaxRatio: 0.6}},
+		{label: "Belåningsgrad över 60 %", want: model.AverageSegment{Label: "Belåningsgrad över 60 %", MinRatio: 0.6}},
+		{label: "Lån 1,5-3 mkr", want: model.AverageSegment{Label: "Lån 1,5-3 mkr", MinAmount: 1_500_000, MaxAmount: 3_000_000}},
+		{label: "Lån över 3 miljoner", want: model.AverageSegment{Label: "Lån över 3 miljoner", MinAmount: 3_000_000}},
+		{
+			label: "Belåningsgrad under 50 %, lån under 2 mkr",
+			want:  model.AverageSegment{Label: "Belåningsgrad under 50 %, lån under 2 mkr", MaxRatio: 0.5, MaxAmount: 2_000_000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ParseAverageSegment(tt.label); got != tt.want {
+				t.Errorf("ParseAverageSegment(%q) = %+v, want %+v", tt.label, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeriesLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		headings []string
+		want     []string
+	}{
+		{name: "single series", headings: []string{"Genomsnittsräntor"}, want: nil},
+		{name: "risk classes", headings: []string{"Riskklass A", " Riskklass B"}, want: []string{"Riskklass A", "Riskklass B"}},
+		{
+			name:     "loan-to-value and amount bands",
+			headings: []string{"Belåningsgrad upp till 60 %", "Lån över 3 mkr"},
+			want:     []string{"Belåningsgrad upp till 60 %", "Lån över 3 mkr"},
+		},
+		{name: "products", headings: []string{"Bolån", "Seniorlån"}, want: nil},
+		{name: "one unrecognised heading", headings: []string{"Riskklass A", "Övriga"}, want: nil},
+		{name: "repeated headings", headings: []string{"Riskklass A", "Riskklass A"}, want: nil},
+		{name: "missing heading", headings: []string{"Riskklass A", ""}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := SeriesLabels(tt.headings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SeriesLabels(%q) = %q, want %q", tt.headings, got, tt.want)
+			}
+		})
+	}
+}
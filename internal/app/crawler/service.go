@@ -2,6 +2,7 @@ package crawler
 
 import (
 	"sync"
+	"time"
 
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
 	"github.com/yama6a/bolan-compare/internal/pkg/store"
@@ -12,41 +13,69 @@ type SiteCrawler interface {
 	Crawl(result chan<- model.InterestSet)
 }
 
-type Service struct {
-	store    store.Store
-	crawlers []SiteCrawler
-	logger   *zap.Logger
+// Report summarizes the outcome of a single crawl run.
+type Report struct {
+	Received      uint
+	Stored        uint
+	Rejected      uint
+	HeldForReview uint
+	Violations    []Violation
+	Anomalies     []Anomaly
+	Consistency   ConsistencyResult
+	// StructureChanges lists the sources whose structure differs from the previous crawl.
+	StructureChanges []StructureChange
 }
 
-func NewService(s store.Store, crawlers []SiteCrawler, logger *zap.Logger) *Service {
-	return &Service{
-		store:    s,
-		crawlers: crawlers,
-		logger:   logger,
-	}
+// StructureChange is a source whose fingerprint differs from the one recorded by the previous crawl.
+type StructureChange struct {
+	Previous model.Fingerprint
+	Current  model.Fingerprint
+	Diff     model.FingerprintDiff
 }
 
-func (s *Service) Crawl() {
-	var wg sync.WaitGroup
-	objChan := make(chan model.InterestSet)
+type Service struct {
+	store     store.Store
+	crawlers  []SiteCrawler
+	validator *Validator
+	detector  *AnomalyDetector
+	checker   *ConsistencyChecker
+	logger    *zap.Logger
+}
 
-	for _, c := range s.crawlers {
-		wg.Add(1)
-		go func(c SiteCrawler) {
-			defer wg.Done()
-			c.Crawl(objChan)
-		}(c)
+func NewService(
+	s store.Store,
+	crawlers []SiteCrawler,
+	validator *Validator,
+	detector *AnomalyDetector,
+	checker *ConsistencyChecker,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		store:     s,
+		crawlers:  crawlers,
+		validator: validator,
+		detector:  detector,
+		checker:   checker,
+		logger:    logger,
 	}
+}
 
-	go s.recv(objChan)
+func (s *Service) Crawl() Report {
+	report := Report{}
+	var accepted []model.InterestSet
+	runCrawlers(s.crawlers, func(c <-chan model.InterestSet) {
+		accepted = s.recv(c, &report)
+	})
+	s.logger.Info("all crawlers finished")
 
-	wg.Wait()
-	s.logger.Info("all crawlers finished, closing channels")
+	s.persist(accepted, &report)
+	report.Consistency = s.checkConsistency(accepted)
+	report.StructureChanges = s.compareFingerprints()
 
 	interestSets, err := s.store.GetInterestSets()
 	if err != nil {
 		s.logger.Error("failed to get interestSets", zap.Error(err))
-		return
+		return report
 	}
 
 	// Build summary by bank and type.
@@ -58,24 +87,211 @@ func (s *Service) Crawl() {
 		summary[is.Bank][is.Type]++
 	}
 
+	// Count violations by bank and severity.
+	violationSummary := make(map[model.Bank]map[Severity]uint)
+	for _, v := range report.Violations {
+		if _, ok := violationSummary[v.Set.Bank]; !ok {
+			violationSummary[v.Set.Bank] = make(map[Severity]uint)
+		}
+		violationSummary[v.Set.Bank][v.Severity]++
+	}
+
 	// Log summary per bank.
 	for bank, types := range summary {
 		s.logger.Info("crawl results",
 			zap.String("bank", string(bank)),
 			zap.Uint("listRates", types[model.TypeListRate]),
 			zap.Uint("avgRates", types[model.TypeAverageRate]),
+			zap.Uint("rejected", violationSummary[bank][SeverityReject]),
+			zap.Uint("flagged", violationSummary[bank][SeverityFlag]),
 		)
 	}
 
+	s.logger.Info("crawl finished",
+		zap.Uint("received", report.Received),
+		zap.Uint("stored", report.Stored),
+		zap.Uint("rejected", report.Rejected),
+		zap.Uint("heldForReview", report.HeldForReview),
+		zap.Int("violations", len(report.Violations)),
+		zap.Uint("crossSourceMatches", report.Consistency.Matched),
+		zap.Int("crossSourceDisagreements", len(report.Consistency.Disagreements)),
+		zap.Int("structureChanges", len(report.StructureChanges)),
+	)
+
+	return report
+}
+
+// runCrawlers runs all crawlers concurrently and returns once they have finished and recv has consumed everything
+// they sent.
+func runCrawlers(crawlers []SiteCrawler, recv func(c <-chan model.InterestSet)) {
+	var wg sync.WaitGroup
+	objChan := make(chan model.InterestSet)
+
+	for _, c := range crawlers {
+		wg.Add(1)
+		go func(c SiteCrawler) {
+			defer wg.Done()
+			c.Crawl(objChan)
+		}(c)
+	}
+
+	recvDone := make(chan struct{})
+	go func() {
+		defer close(recvDone)
+		recv(objChan)
+	}()
+
+	wg.Wait()
 	close(objChan)
+	<-recvDone
 }
 
-func (s *Service) recv(c <-chan model.InterestSet) {
+// recv validates all received InterestSets and returns the ones that may be stored.
+func (s *Service) recv(c <-chan model.InterestSet, report *Report) []model.InterestSet {
 	s.logger.Info("starting crawler receiver")
 
+	var accepted []model.InterestSet
 	for set := range c {
+		report.Received++
+
+		violations := s.validator.Validate(set)
+		for _, v := range violations {
+			s.logger.Warn("implausible interestSet",
+				zap.String("bank", string(v.Set.Bank)),
+				zap.String("rule", string(v.Rule)),
+				zap.String("severity", string(v.Severity)),
+				zap.String("message", v.Message),
+			)
+		}
+		report.Violations = append(report.Violations, violations...)
+
+		if HasRejection(violations) {
+			report.Rejected++
+			continue
+		}
+		accepted = append(accepted, set)
+	}
+
+	return accepted
+}
+
+// persist compares the accepted InterestSets with the stored history, holds back suspicious ones for review and
+// stores the rest.
+func (s *Service) persist(accepted []model.InterestSet, report *Report) {
+	history, err := s.store.GetInterestSets()
+	if err != nil {
+		s.logger.Error("failed to get interestSets for anomaly detection", zap.Error(err))
+	}
+	// The store may hand out its backing slice, so compare against a snapshot taken before any upsert.
+	history = append([]model.InterestSet(nil), history...)
+
+	anomalies := s.detector.Detect(accepted, history)
+	detectedAt := time.Now().UTC()
+
+	for _, set := range accepted {
+		id := ReviewID(set)
+		if anomaly, ok := anomalies[id]; ok {
+			s.logger.Warn("holding back suspicious interestSet for review",
+				zap.String("id", id),
+				zap.Stringer("rate", set.NominalRate),
+				zap.Strings("reasons", anomaly.Reasons),
+			)
+			report.Anomalies = append(report.Anomalies, anomaly)
+
+			err := s.store.AddPendingReview(model.PendingReview{
+				ID:           id,
+				Set:          set,
+				PreviousRate: anomaly.PreviousRate,
+				Reasons:      anomaly.Reasons,
+				DetectedAt:   detectedAt,
+			})
+			if err != nil {
+				s.logger.Error("failed to add pending review", zap.String("id", id), zap.Error(err))
+				continue
+			}
+			report.HeldForReview++
+			continue
+		}
+
 		if err := s.store.UpsertInterestSet(set); err != nil {
 			s.logger.Error("failed to upsert interestSet", zap.Any("interestSet", set), zap.Error(err))
+			continue
+		}
+		report.Stored++
+	}
+}
+
+// checkConsistency compares products published by several crawlers and logs every disagreement.
+func (s *Service) checkConsistency(sets []model.InterestSet) ConsistencyResult {
+	result := s.checker.Check(sets)
+
+	for _, d := range result.Disagreements {
+		s.logger.Warn("cross-source rate disagreement",
+			zap.String("distributor", string(d.Distributor)),
+			zap.String("lender", string(d.Lender)),
+			zap.String("type", string(d.Type)),
+			zap.Stringer("term", d.Term),
+			zap.Stringer("distributorRate", d.DistributorRate),
+			zap.Stringer("lenderRate", d.LenderRate),
+			zap.Float64("diffBps", d.DiffBps),
+		)
+	}
+	for _, set := range result.Unmatched {
+		s.logger.Info("no lender rate to cross-check against",
+			zap.String("distributor", string(set.Bank)),
+			zap.String("lender", string(set.Lender)),
+			zap.String("type", string(set.Type)),
+			zap.Stringer("term", set.Term),
+		)
+	}
+
+	return result
+}
+
+// compareFingerprints compares the fingerprints the crawlers recorded with the stored ones of the previous crawl,
+// logs every changed source and stores the new fingerprints.
+func (s *Service) compareFingerprints() []StructureChange {
+	stored, err := s.store.GetFingerprints()
+	if err != nil {
+		s.logger.Error("failed to get fingerprints", zap.Error(err))
+		return nil
+	}
+
+	type sourceKey struct {
+		bank   model.Bank
+		source string
+	}
+	previous := make(map[sourceKey]model.Fingerprint, len(stored))
+	for _, fingerprint := range stored {
+		previous[sourceKey{bank: fingerprint.Bank, source: fingerprint.Source}] = fingerprint
+	}
+
+	var changes []StructureChange
+	for _, c := range s.crawlers {
+		fingerprinter, ok := c.(Fingerprinter)
+		if !ok {
+			continue
+		}
+
+		for _, current := range fingerprinter.Fingerprints() {
+			if prev, ok := previous[sourceKey{bank: current.Bank, source: current.Source}]; ok {
+				if diff := current.Diff(prev); !diff.Empty() {
+					s.logger.Warn("source structure changed",
+						zap.String("bank", string(current.Bank)),
+						zap.String("source", current.Source),
+						zap.Strings("added", diff.Added),
+						zap.Strings("removed", diff.Removed),
+						zap.Time("previousCapturedAt", prev.CapturedAt),
+					)
+					changes = append(changes, StructureChange{Previous: prev, Current: current, Diff: diff})
+				}
+			}
+
+			if err := s.store.SaveFingerprint(current); err != nil {
+				s.logger.Error("failed to save fingerprint", zap.String("source", current.Source), zap.Error(err))
+			}
 		}
 	}
+
+	return changes
 }
@@ -0,0 +1,165 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/store"
+	"go.uber.org/zap"
+)
+
+// staticCrawler emits a fixed list of InterestSets.
+type staticCrawler struct {
+	sets []model.InterestSet
+}
+
+func (c *staticCrawler) Crawl(result chan<- model.InterestSet) {
+	for _, set := range c.sets {
+		result <- set
+	}
+}
+
+// fingerprintingCrawler emits nothing and reports a table with the given header.
+type fingerprintingCrawler struct {
+	fingerprints *FingerprintRecorder
+	header       []string
+}
+
+func (c *fingerprintingCrawler) Crawl(_ chan<- model.InterestSet) {
+	c.fingerprints.RecordTable("https://example.com/rates", "list rates", c.header)
+}
+
+func (c *fingerprintingCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func TestService_Crawl_ValidatesBeforeStoring(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	valid := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.33), LastCrawledAt: now}
+	implausible := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(344), LastCrawledAt: now}
+	flagged := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term10years, NominalRate: model.RateFromPercent(3.9), LastCrawledAt: now}
+
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	crawlers := []SiteCrawler{
+		&staticCrawler{sets: []model.InterestSet{valid, implausible}},
+		&staticCrawler{sets: []model.InterestSet{flagged}},
+	}
+
+	svc := NewService(memStore, crawlers, newTestValidator(now), NewAnomalyDetector(DefaultAnomalyConfig()), NewConsistencyChecker(1), zap.NewNop())
+	report := svc.Crawl()
+
+	if report.Received != 3 {
+		t.Errorf("Received = %d, want 3", report.Received)
+	}
+	if report.Stored != 2 {
+		t.Errorf("Stored = %d, want 2", report.Stored)
+	}
+	if report.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", report.Rejected)
+	}
+
+	rules := violationRules(report.Violations)
+	if rules[RuleRateRange] != SeverityReject {
+		t.Errorf("expected rate range rejection, got %v", rules)
+	}
+	if rules[RuleUnknownTerm] != SeverityFlag {
+		t.Errorf("expected unknown term flag, got %v", rules)
+	}
+
+	stored, err := memStore.GetInterestSets()
+	if err != nil {
+		t.Fatalf("GetInterestSets() error = %v", err)
+	}
+	for _, set := range stored {
+		if set.NominalRate == implausible.NominalRate {
+			t.Errorf("implausible interestSet was stored: %+v", set)
+		}
+	}
+}
+
+func TestService_Crawl_HoldsBackSuspiciousRates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	previous := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.33), LastCrawledAt: now.AddDate(0, 0, -1)}
+	jumped := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.33), LastCrawledAt: now}
+	unchanged := model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.5), LastCrawledAt: now}
+
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	if err := memStore.UpsertInterestSet(previous); err != nil {
+		t.Fatalf("UpsertInterestSet() error = %v", err)
+	}
+
+	crawlers := []SiteCrawler{&staticCrawler{sets: []model.InterestSet{jumped, unchanged}}}
+	svc := NewService(memStore, crawlers, newTestValidator(now), NewAnomalyDetector(DefaultAnomalyConfig()), NewConsistencyChecker(1), zap.NewNop())
+	report := svc.Crawl()
+
+	if report.Stored != 1 {
+		t.Errorf("Stored = %d, want 1", report.Stored)
+	}
+	if report.HeldForReview != 1 {
+		t.Errorf("HeldForReview = %d, want 1", report.HeldForReview)
+	}
+
+	stored, err := memStore.GetInterestSets()
+	if err != nil {
+		t.Fatalf("GetInterestSets() error = %v", err)
+	}
+	for _, set := range stored {
+		if set.Term == model.Term3months && set.NominalRate != previous.NominalRate {
+			t.Errorf("suspicious rate was published: %+v", set)
+		}
+	}
+
+	reviews, err := memStore.GetPendingReviews()
+	if err != nil {
+		t.Fatalf("GetPendingReviews() error = %v", err)
+	}
+	if len(reviews) != 1 {
+		t.Fatalf("got %d pending reviews, want 1", len(reviews))
+	}
+	if reviews[0].Set.NominalRate != jumped.NominalRate {
+		t.Errorf("pending review rate = %v, want %v", reviews[0].Set.NominalRate, jumped.NominalRate)
+	}
+	if reviews[0].PreviousRate == nil || *reviews[0].PreviousRate != previous.NominalRate {
+		t.Errorf("pending review previous rate = %v, want %v", reviews[0].PreviousRate, previous.NominalRate)
+	}
+}
+
+func TestService_Crawl_ReportsStructureChanges(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	memStore := store.NewMemoryStore(nil, zap.NewNop())
+	c := &fingerprintingCrawler{fingerprints: NewFingerprintRecorder("Prime Bank"), header: []string{"Bindningstid", "Ränta"}}
+	svc := NewService(memStore, []SiteCrawler{c}, newTestValidator(now), NewAnomalyDetector(DefaultAnomalyConfig()), NewConsistencyChecker(1), zap.NewNop())
+
+	// The first crawl has nothing to compare against, the second finds the same structure.
+	for range 2 {
+		if report := svc.Crawl(); len(report.StructureChanges) != 0 {
+			t.Fatalf("StructureChanges = %+v, want none", report.StructureChanges)
+		}
+	}
+
+	c.header = []string{"Bindningstid", "Listränta"}
+	report := svc.Crawl()
+	if len(report.StructureChanges) != 1 {
+		t.Fatalf("got %d structure changes, want 1", len(report.StructureChanges))
+	}
+	want := model.FingerprintDiff{
+		Added:   []string{`list rates: column 1 "Listränta"`},
+		Removed: []string{`list rates: column 1 "Ränta"`},
+	}
+	if got := report.StructureChanges[0].Diff; !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff = %+v, want %+v", got, want)
+	}
+
+	// The changed structure is the new baseline.
+	if report := svc.Crawl(); len(report.StructureChanges) != 0 {
+		t.Errorf("StructureChanges after the change = %+v, want none", report.StructureChanges)
+	}
+}
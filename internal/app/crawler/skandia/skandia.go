@@ -14,6 +14,7 @@ import (
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
 )
 
 const (
@@ -23,43 +24,22 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &SkandiaCrawler{}
+	_ crawler.SiteCrawler    = &SkandiaCrawler{}
+	_ crawler.Fingerprinter  = &SkandiaCrawler{}
+	_ crawler.DocumentParser = &SkandiaCrawler{}
 
 	// Regex to extract SKB.pageContent JSON from HTML.
 	skandiaPageContentRgx = regexp.MustCompile(`SKB\.pageContent\s*=\s*(\{[\s\S]*?\});?\s*(?:SKB\.|</script>)`)
 
-	// Regex to extract rate values like "3,45 %" from HTML content.
-	skandiaRateRgx = regexp.MustCompile(`([0-9]+,[0-9]+)\s*%`)
-
 	// Regex to extract term like "3 mån" or "1 år" from HTML content.
 	skandiaTermRgx = regexp.MustCompile(`([0-9]+)\s*(mån|år)`)
-
-	// Regex to extract month and year from header like "Snitträntor november 2025".
-	skandiaMonthYearRgx = regexp.MustCompile(`(?i)(januari|februari|mars|april|maj|juni|juli|augusti|september|oktober|november|december)\s+(\d{4})`)
 )
 
-// skandiaMonthMap maps Swedish month names to month numbers.
-//
-//nolint:gochecknoglobals // constant map for month name conversion
-var skandiaMonthMap = map[string]int{
-	"januari":   1,
-	"februari":  2,
-	"mars":      3,
-	"april":     4,
-	"maj":       5,
-	"juni":      6,
-	"juli":      7,
-	"augusti":   8,
-	"september": 9,
-	"oktober":   10,
-	"november":  11,
-	"december":  12,
-}
-
 //nolint:revive // Bank name prefix is intentional for clarity
 type SkandiaCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 // skandiaPageContent represents the top-level SKB.pageContent JSON structure.
@@ -127,7 +107,16 @@ type skandiaFAQItemExpanded struct {
 }
 
 func NewSkandiaCrawler(httpClient http.Client, logger *zap.Logger) *SkandiaCrawler {
-	return &SkandiaCrawler{httpClient: httpClient, logger: logger}
+	return &SkandiaCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(skandiaBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SkandiaCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *SkandiaCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -148,12 +137,35 @@ func (c *SkandiaCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates page again.
+func (c *SkandiaCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case skandiaListRatesURL:
+		return c.parseListRates(string(content), fetchedAt)
+	case skandiaAvgRatesURL:
+		return c.parseAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *SkandiaCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSet, error) {
 	html, err := c.httpClient.Fetch(skandiaListRatesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading Skandia list rates page: %w", err)
 	}
 
+	interestSets, err := c.parseListRates(html, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, skandiaListRatesURL, []byte(html))
+	return interestSets, nil
+}
+
+// parseListRates parses the list rates table of the rates page.
+func (c *SkandiaCrawler) parseListRates(html string, crawlTime time.Time) ([]model.InterestSet, error) {
 	pageContent, err := extractSkandiaPageContent(html)
 	if err != nil {
 		return nil, fmt.Errorf("failed extracting Skandia page content: %w", err)
@@ -192,16 +204,29 @@ func (c *SkandiaCrawler) fetchListRates(crawlTime time.Time) ([]model.InterestSe
 		return nil, fmt.Errorf("no list rates found in Skandia page")
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "parseListRates")
 	return interestSets, nil
 }
 
-//nolint:gocognit,cyclop // complex logic for parsing nested JSON structure
 func (c *SkandiaCrawler) fetchAverageRates(crawlTime time.Time) ([]model.InterestSet, error) {
 	html, err := c.httpClient.Fetch(skandiaAvgRatesURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading Skandia average rates page: %w", err)
 	}
 
+	interestSets, err := c.parseAverageRates(html, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, skandiaAvgRatesURL, []byte(html))
+	return interestSets, nil
+}
+
+// parseAverageRates parses the average rates tables of the average rates page.
+//
+//nolint:gocognit,cyclop // complex logic for parsing nested JSON structure
+func (c *SkandiaCrawler) parseAverageRates(html string, crawlTime time.Time) ([]model.InterestSet, error) {
 	pageContent, err := extractSkandiaPageContent(html)
 	if err != nil {
 		return nil, fmt.Errorf("failed extracting Skandia page content: %w", err)
@@ -306,11 +331,13 @@ func (c *SkandiaCrawler) fetchAverageRates(crawlTime time.Time) ([]model.Interes
 		}
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "parseAverageRates")
 	return interestSets, nil
 }
 
 //nolint:cyclop // switch-case for multiple column types
 func (c *SkandiaCrawler) parseSkandiaListRateTable(columns []skandiaColumn, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordTable(skandiaListRatesURL, "list rates", skandiaColumnHeaders(columns))
 	if len(columns) < 2 {
 		return nil, fmt.Errorf("expected at least 2 columns, got %d", len(columns))
 	}
@@ -377,6 +404,7 @@ func (c *SkandiaCrawler) parseSkandiaListRateTable(columns []skandiaColumn, craw
 
 //nolint:cyclop // switch-case for multiple column types
 func (c *SkandiaCrawler) parseSkandiaAvgRateTable(columns []skandiaColumn, avgMonth model.AvgMonth, crawlTime time.Time) ([]model.InterestSet, error) {
+	c.fingerprints.RecordTable(skandiaAvgRatesURL, "average rates", skandiaColumnHeaders(columns))
 	if len(columns) < 2 {
 		return nil, fmt.Errorf("expected at least 2 columns, got %d", len(columns))
 	}
@@ -432,6 +460,19 @@ func (c *SkandiaCrawler) parseSkandiaAvgRateTable(columns []skandiaColumn, avgMo
 	return interestSets, nil
 }
 
+// skandiaColumnHeaders returns the cell header of each column, empty for columns without content.
+func skandiaColumnHeaders(columns []skandiaColumn) []string {
+	headers := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if col.ContentLink.Expanded == nil {
+			headers = append(headers, "")
+			continue
+		}
+		headers = append(headers, col.ContentLink.Expanded.CellHeader)
+	}
+	return headers
+}
+
 func extractSkandiaPageContent(html string) (*skandiaPageContent, error) {
 	matches := skandiaPageContentRgx.FindStringSubmatch(html)
 	if len(matches) < 2 {
@@ -448,112 +489,34 @@ func extractSkandiaPageContent(html string) (*skandiaPageContent, error) {
 	return &pageContent, nil
 }
 
-//nolint:cyclop // switch-case for multiple term types
 func parseSkandiaHTMLTerm(htmlCell string) (model.Term, error) {
 	// Decode HTML entities (e.g., &aring; -> å)
 	decoded := html.UnescapeString(htmlCell)
 	matches := skandiaTermRgx.FindStringSubmatch(decoded)
 	if len(matches) < 3 {
-		return "", fmt.Errorf("could not parse term from %q", htmlCell)
+		return model.Term{}, fmt.Errorf("could not parse term from %q", htmlCell)
 	}
 
 	num, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return "", fmt.Errorf("invalid term number: %w", err)
-	}
-
-	unit := strings.ToLower(matches[2])
-
-	switch unit {
-	case "mån":
-		if num == 3 {
-			return model.Term3months, nil
-		}
-		return "", fmt.Errorf("unsupported month term: %d", num)
-	case "år":
-		switch num {
-		case 1:
-			return model.Term1year, nil
-		case 2:
-			return model.Term2years, nil
-		case 3:
-			return model.Term3years, nil
-		case 4:
-			return model.Term4years, nil
-		case 5:
-			return model.Term5years, nil
-		case 6:
-			return model.Term6years, nil
-		case 7:
-			return model.Term7years, nil
-		case 8:
-			return model.Term8years, nil
-		case 9:
-			return model.Term9years, nil
-		case 10:
-			return model.Term10years, nil
-		default:
-			return "", fmt.Errorf("unsupported year term: %d", num)
-		}
+		return model.Term{}, fmt.Errorf("invalid term number: %w", err)
 	}
 
-	return "", fmt.Errorf("unknown term unit: %s", unit)
+	return utils.TermFromCount(num, matches[2]) //nolint:wrapcheck // utils error names the term
 }
 
-func parseSkandiaHTMLRate(htmlCell string) (float32, error) {
-	matches := skandiaRateRgx.FindStringSubmatch(htmlCell)
-	if len(matches) < 2 {
-		return 0, fmt.Errorf("could not parse rate from %q", htmlCell)
-	}
-
-	// Replace Swedish decimal comma with dot
-	rateStr := strings.ReplaceAll(matches[1], ",", ".")
-
-	rate, err := strconv.ParseFloat(rateStr, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed parsing rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseSkandiaHTMLRate(htmlCell string) (model.Rate, error) {
+	return utils.FindRate(htmlCell)
 }
 
 func parseSkandiaHTMLDate(htmlCell string) (time.Time, error) {
 	// Extract date in format YYYY-MM-DD from HTML like "<p>2025-09-30</p>"
-	dateRgx := regexp.MustCompile(`(\d{4}-\d{2}-\d{2})`)
-	matches := dateRgx.FindStringSubmatch(htmlCell)
-	if len(matches) < 2 {
-		return time.Time{}, fmt.Errorf("could not parse date from %q", htmlCell)
-	}
-
-	parsed, err := time.Parse("2006-01-02", matches[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed parsing date %q: %w", matches[1], err)
-	}
-
-	return parsed, nil
+	return utils.FindSwedishDate(htmlCell)
 }
 
+// parseSkandiaMonthYear extracts the month from a header like "Snitträntor november 2025".
 func parseSkandiaMonthYear(text string) (model.AvgMonth, error) {
-	matches := skandiaMonthYearRgx.FindStringSubmatch(text)
-	if len(matches) < 3 {
-		return model.AvgMonth{}, fmt.Errorf("could not parse month/year from %q", text)
-	}
-
-	monthName := strings.ToLower(matches[1])
-	monthNum, ok := skandiaMonthMap[monthName]
-	if !ok {
-		return model.AvgMonth{}, fmt.Errorf("unknown month: %s", monthName)
-	}
-
-	year, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("invalid year: %w", err)
-	}
-
-	return model.AvgMonth{
-		Year:  uint(year),
-		Month: time.Month(monthNum),
-	}, nil
+	return utils.FindMonthYear(text)
 }
 
 func isTableBlock(contentType []string) bool {
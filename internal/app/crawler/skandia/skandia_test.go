@@ -255,14 +255,16 @@ func TestParseSkandiaHTMLTerm(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "unsupported month term",
+			name:     "6 months",
 			htmlCell: "<p>6 mån</p>",
-			wantErr:  true,
+			want:     model.Term6months,
+			wantErr:  false,
 		},
 		{
-			name:     "unsupported year term",
+			name:     "15 years",
 			htmlCell: "<p>15 år</p>",
-			wantErr:  true,
+			want:     model.Term15years,
+			wantErr:  false,
 		},
 		{
 			name:     "no term found",
@@ -298,7 +300,7 @@ func TestParseSkandiaHTMLRate(t *testing.T) {
 	tests := []struct {
 		name     string
 		htmlCell string
-		want     float32
+		want     float64
 		wantErr  bool
 	}{
 		{
@@ -351,7 +353,7 @@ func TestParseSkandiaHTMLRate(t *testing.T) {
 				t.Errorf("parseSkandiaHTMLRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseSkandiaHTMLRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -476,7 +478,7 @@ func assertSkandiaListRateFields(t *testing.T, r model.InterestSet, crawlTime ti
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
@@ -494,7 +496,7 @@ func assertSkandiaAverageRateFields(t *testing.T, r model.InterestSet, crawlTime
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
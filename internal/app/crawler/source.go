@@ -0,0 +1,29 @@
+package crawler
+
+import "github.com/yama6a/bolan-compare/internal/pkg/model"
+
+// SetSource points every set to the document fetched from url it was parsed from. The extraction recorded by
+// SetExtraction is kept.
+func SetSource(sets []model.InterestSet, url string, content []byte) {
+	document := model.NewSourceRef(url, content)
+	for i := range sets {
+		source := document
+		if sets[i].Source != nil {
+			source.Kind, source.Method = sets[i].Source.Kind, sets[i].Source.Method
+		}
+		sets[i].Source = &source
+	}
+}
+
+// SetExtraction records the format of the document every set was parsed from and the crawler function that extracted
+// it. Parsers call it, so that a crawl and a reparse of the same document yield the same source.
+func SetExtraction(sets []model.InterestSet, kind model.SourceKind, method string) {
+	for i := range sets {
+		var source model.SourceRef
+		if sets[i].Source != nil {
+			source = *sets[i].Source
+		}
+		source.Kind, source.Method = kind, method
+		sets[i].Source = &source
+	}
+}
@@ -0,0 +1,48 @@
+package crawler
+
+import (
+	"testing"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestSetSource(t *testing.T) {
+	t.Parallel()
+
+	sets := []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term1year},
+	}
+	SetSource(sets, "https://seb.example/rates", []byte(`{"rates":[]}`))
+
+	want := model.SourceRef{URL: "https://seb.example/rates", Hash: model.HashContent([]byte(`{"rates":[]}`))}
+	for _, set := range sets {
+		if set.Source == nil || *set.Source != want {
+			t.Errorf("Source of %s = %+v, want %+v", set.Term, set.Source, want)
+		}
+	}
+}
+
+func TestSetExtraction(t *testing.T) {
+	t.Parallel()
+
+	discounted := []model.InterestSet{{Bank: "Landshypotek", Type: model.TypeRatioDiscounted, Term: model.Term3months}}
+	list := []model.InterestSet{{Bank: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months}}
+	SetExtraction(discounted, model.SourceKindHTML, "extractDiscountedRates")
+	SetExtraction(list, model.SourceKindHTML, "extractListRates")
+
+	// The document is stamped after all tables of the page are extracted.
+	sets := append(discounted, list...)
+	SetSource(sets, "https://landshypotek.example/rates", []byte("<html></html>"))
+
+	hash := model.HashContent([]byte("<html></html>"))
+	want := []model.SourceRef{
+		{URL: "https://landshypotek.example/rates", Kind: model.SourceKindHTML, Method: "extractDiscountedRates", Hash: hash},
+		{URL: "https://landshypotek.example/rates", Kind: model.SourceKindHTML, Method: "extractListRates", Hash: hash},
+	}
+	for i, set := range sets {
+		if set.Source == nil || *set.Source != want[i] {
+			t.Errorf("Source of %s = %+v, want %+v", set.Type, set.Source, want[i])
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package sparbanker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"github.com/yama6a/bolan-compare/internal/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	// TemplateSwedbank is the site platform of the savings banks cooperating with Swedbank. URL is the site root; the
+	// list rates are on swedbankListRatesPath in the table after "Aktuella bolåneräntor – listpris", the average rates
+	// on swedbankAvgRatesPath in the table captioned "Våra historiska genomsnittsräntor", one row per month.
+	TemplateSwedbank Template = "swedbank"
+	// TemplateRateTable is the single rates page of the independent savings banks. URL is that page; its first table
+	// holds one row per term with the list rate and the average rate of the latest month, e.g.
+	// "Bindningstid | Listränta | Snittränta september 2025".
+	TemplateRateTable Template = "rateTable"
+
+	swedbankListRatesPath = "/privat/boende-och-bolan/bolanerantor.html"
+	swedbankAvgRatesPath  = "/privat/boende-och-bolan/bolanerantor/historiska-genomsnittsrantor.html"
+)
+
+var (
+	_ crawler.SiteCrawler    = &SparbankCrawler{}
+	_ crawler.Fingerprinter  = &SparbankCrawler{}
+	_ crawler.DocumentParser = &SparbankCrawler{}
+
+	// Header dates like "senast ändrad 25 september 2025" and months like "Snittränta september 2025" change every
+	// month and must not count as a layout change.
+	sparbankDateRegex  = regexp.MustCompile(`\d{1,2} \p{L}+ \d{4}`)
+	sparbankMonthRegex = regexp.MustCompile(`\p{L}+\.? \d{4}`)
+)
+
+// Template is the page layout a savings bank publishes its rates in.
+type Template string
+
+// Sparbank is one savings bank and where it publishes its rates.
+type Sparbank struct {
+	Bank     model.Bank
+	URL      string // site root for TemplateSwedbank, rates page for TemplateRateTable
+	Template Template
+}
+
+// DefaultSparbanker returns the crawled savings banks. Most share Swedbank's site platform, the independent ones publish
+// a single rates table.
+func DefaultSparbanker() []Sparbank {
+	return []Sparbank{
+		{Bank: "Bergslagens Sparbank", URL: "https://www.bergslagenssparbank.se", Template: TemplateSwedbank},
+		{Bank: "Falkenbergs Sparbank", URL: "https://www.falkenbergssparbank.se/privat/lana/bolan/bolanerantor", Template: TemplateRateTable},
+		{Bank: "Sala Sparbank", URL: "https://www.salasparbank.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Alingsås", URL: "https://www.sparbankenalingsas.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Eken", URL: "https://www.sparbankeneken.se/privat/lana/bolan/bolanerantor", Template: TemplateRateTable},
+		{Bank: "Sparbanken Lidköping", URL: "https://www.sparbankenlidkoping.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Nord", URL: "https://www.sparbankennord.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Rekarne", URL: "https://www.sparbankenrekarne.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Sjuhärad", URL: "https://www.sparbankensjuharad.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Skaraborg", URL: "https://www.sparbankenskaraborg.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Skåne", URL: "https://www.sparbankenskane.se", Template: TemplateSwedbank},
+		{Bank: "Sparbanken Syd", URL: "https://www.sparbankensyd.se/privat/lana/bolan/bolanerantor", Template: TemplateRateTable},
+		{Bank: "Sörmlands Sparbank", URL: "https://www.sormlandssparbank.se", Template: TemplateSwedbank},
+		{Bank: "Varbergs Sparbank", URL: "https://www.varbergssparbank.se", Template: TemplateSwedbank},
+		{Bank: "Vimmerby Sparbank", URL: "https://www.vimmerbysparbank.se", Template: TemplateSwedbank},
+	}
+}
+
+// SparbankCrawler crawls the rates of one savings bank according to its template.
+//
+//nolint:revive // Bank name prefix is intentional for clarity
+type SparbankCrawler struct {
+	bank         Sparbank
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
+}
+
+func NewSparbankCrawler(bank Sparbank, httpClient http.Client, logger *zap.Logger) *SparbankCrawler {
+	return &SparbankCrawler{
+		bank:         bank,
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(bank.Bank),
+	}
+}
+
+// NewSparbankCrawlers returns one crawler per savings bank, each logging under its bank name.
+func NewSparbankCrawlers(banks []Sparbank, httpClient http.Client, logger *zap.Logger) []crawler.SiteCrawler {
+	crawlers := make([]crawler.SiteCrawler, 0, len(banks))
+	for _, bank := range banks {
+		crawlers = append(crawlers, NewSparbankCrawler(bank, httpClient, logger.With(zap.String("bank", string(bank.Bank)))))
+	}
+	return crawlers
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SparbankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
+}
+
+func (c *SparbankCrawler) Crawl(channel chan<- model.InterestSet) {
+	crawlTime := time.Now().UTC()
+
+	var sources []string
+	switch c.bank.Template {
+	case TemplateSwedbank:
+		sources = []string{c.bank.URL + swedbankListRatesPath, c.bank.URL + swedbankAvgRatesPath}
+	case TemplateRateTable:
+		sources = []string{c.bank.URL}
+	default:
+		c.logger.Error("unknown savings bank template", zap.String("template", string(c.bank.Template)))
+		return
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, url := range sources {
+		rawHTML, err := c.httpClient.Fetch(url, nil)
+		if err != nil {
+			c.logger.Error("failed reading savings bank rates page", zap.String("url", url), zap.Error(err))
+			continue
+		}
+
+		sets, err := c.ParseDocument(url, []byte(rawHTML), crawlTime)
+		if err != nil {
+			c.logger.Error("failed parsing savings bank rates page", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		crawler.SetSource(sets, url, []byte(rawHTML))
+		interestSets = append(interestSets, sets...)
+	}
+
+	for _, set := range interestSets {
+		channel <- set
+	}
+}
+
+// ParseDocument parses an archived copy of one of the bank's rates pages again.
+func (c *SparbankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch {
+	case c.bank.Template == TemplateSwedbank && url == c.bank.URL+swedbankListRatesPath:
+		return c.extractSwedbankListRates(url, string(content), fetchedAt)
+	case c.bank.Template == TemplateSwedbank && url == c.bank.URL+swedbankAvgRatesPath:
+		return c.extractSwedbankAverageRates(url, string(content), fetchedAt)
+	case c.bank.Template == TemplateRateTable && url == c.bank.URL:
+		return c.extractRateTable(url, string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
+// extractSwedbankListRates parses the list rates table: Bindningstid | Ränta, senast ändrad 25 september 2025.
+func (c *SparbankCrawler) extractSwedbankListRates(url, rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{TextBefore: "Aktuella bolåneräntor – listpris"},
+		Columns: []utils.TableColumn{
+			{Name: "term", Index: 0},
+			{Name: "rate", Match: utils.HeaderContains("ränta")},
+		},
+	})
+	c.fingerprints.RecordTable(url, "list rates", maskHeader(table.Header, sparbankDateRegex, "<date>"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract list rates table: %w", err)
+	}
+
+	var changedOn *time.Time
+	if cells := tableCells(table, "rate"); len(cells) > 0 {
+		if date, err := utils.FindSwedishDate(cells[0].Header); err == nil {
+			changedOn = &date
+		} else {
+			c.logger.Warn("failed to parse change date from header", zap.String("header", cells[0].Header), zap.Error(err))
+		}
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		if strings.Contains(strings.ToLower(record.Get("term")), "banklån") {
+			continue
+		}
+		term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("rate"))
+		if !ok {
+			continue
+		}
+
+		interestSets = append(interestSets, model.InterestSet{
+			Bank:          c.bank.Bank,
+			Type:          model.TypeListRate,
+			Term:          term,
+			NominalRate:   rate,
+			ChangedOn:     changedOn,
+			LastCrawledAt: crawlTime,
+		})
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no list rates found in table")
+	}
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractSwedbankListRates")
+	return interestSets, nil
+}
+
+// extractSwedbankAverageRates parses the historic average rates table: Månad | 3 månader | 1 år | ... | 10 år.
+func (c *SparbankCrawler) extractSwedbankAverageRates(url, rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Locate: utils.TableLocator{Caption: "Våra historiska genomsnittsräntor"},
+		Columns: []utils.TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: utils.HeaderIsTerm, Repeated: true},
+		},
+	})
+	c.fingerprints.RecordTable(url, "average rates", table.Header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract average rates table: %w", err)
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		month, err := utils.ParseMonthYear(record.Get("month"))
+		if err != nil {
+			c.logger.Warn("failed to parse average rate month", zap.String("month", record.Get("month")), zap.Error(err))
+			continue
+		}
+
+		for _, cell := range record.Cells("rates") {
+			term, rate, ok := c.parseTermRate(cell.Header, cell.Text)
+			if !ok {
+				continue
+			}
+
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  c.bank.Bank,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: &month,
+			})
+		}
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no average rates found in table")
+	}
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractSwedbankAverageRates")
+	return interestSets, nil
+}
+
+// extractRateTable parses the single rates table: Bindningstid | Listränta | Snittränta september 2025.
+func (c *SparbankCrawler) extractRateTable(url, rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
+	table, err := utils.ExtractTable(rawHTML, utils.TableSpec{
+		Columns: []utils.TableColumn{
+			{Name: "term", Match: utils.HeaderContains("bindningstid")},
+			{Name: "list", Match: utils.HeaderContains("listränta")},
+			{Name: "average", Match: utils.HeaderContains("snittränta"), Optional: true},
+		},
+	})
+	c.fingerprints.RecordTable(url, "rates", maskHeader(table.Header, sparbankMonthRegex, "<month>"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract rates table: %w", err)
+	}
+
+	var avgMonth *model.AvgMonth
+	if cells := tableCells(table, "average"); len(cells) > 0 {
+		if month, err := utils.FindMonthYear(cells[0].Header); err == nil {
+			avgMonth = &month
+		} else {
+			c.logger.Warn("failed to parse average rate month from header", zap.String("header", cells[0].Header), zap.Error(err))
+		}
+	}
+
+	interestSets := []model.InterestSet{}
+	for _, record := range table.Records {
+		if term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("list")); ok {
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:          c.bank.Bank,
+				Type:          model.TypeListRate,
+				Term:          term,
+				NominalRate:   rate,
+				LastCrawledAt: crawlTime,
+			})
+		}
+		if avgMonth == nil {
+			continue
+		}
+		if term, rate, ok := c.parseTermRate(record.Get("term"), record.Get("average")); ok {
+			interestSets = append(interestSets, model.InterestSet{
+				Bank:                  c.bank.Bank,
+				Type:                  model.TypeAverageRate,
+				Term:                  term,
+				NominalRate:           rate,
+				LastCrawledAt:         crawlTime,
+				AverageReferenceMonth: avgMonth,
+			})
+		}
+	}
+
+	if len(interestSets) == 0 {
+		return nil, errors.New("no rates found in table")
+	}
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractRateTable")
+	return interestSets, nil
+}
+
+// parseTermRate parses a term and its rate. A missing rate, shown as "-", is skipped silently.
+func (c *SparbankCrawler) parseTermRate(termStr, rateStr string) (model.Term, model.Rate, bool) {
+	term, err := utils.ParseTerm(termStr)
+	if err != nil {
+		c.logger.Warn("failed to parse term", zap.String("term", termStr), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+
+	rate, err := utils.ParseRate(rateStr)
+	if errors.Is(err, utils.ErrEmptyRate) {
+		return model.Term{}, 0, false
+	}
+	if err != nil {
+		c.logger.Warn("failed to parse rate", zap.String("rate", rateStr), zap.Stringer("term", term), zap.Error(err))
+		return model.Term{}, 0, false
+	}
+	return term, rate, true
+}
+
+// tableCells returns the cells of the named column of the first record.
+func tableCells(table utils.ExtractedTable, name string) []utils.TableCell {
+	if len(table.Records) == 0 {
+		return nil
+	}
+	return table.Records[0].Cells(name)
+}
+
+// maskHeader replaces the volatile part of the header texts matched by re.
+func maskHeader(header []string, re *regexp.Regexp, mask string) []string {
+	masked := make([]string, 0, len(header))
+	for _, text := range header {
+		masked = append(masked, re.ReplaceAllString(text, mask))
+	}
+	return masked
+}
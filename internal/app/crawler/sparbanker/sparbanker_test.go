@@ -0,0 +1,285 @@
+//nolint:revive,nolintlint,dupl // package name matches the package being tested; test patterns intentionally similar across crawlers
+package sparbanker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	crawlertest "github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+var (
+	testSwedbankTemplateBank = Sparbank{Bank: "Sparbanken Rekarne", URL: "https://www.sparbankenrekarne.se", Template: TemplateSwedbank}
+	testRateTableBank        = Sparbank{Bank: "Sparbanken Syd", URL: "https://www.sparbankensyd.se/privat/lana/bolan/bolanerantor", Template: TemplateRateTable}
+)
+
+func TestSparbankCrawler_Crawl(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		testSwedbankTemplateBank.URL + swedbankListRatesPath: crawlertest.LoadGoldenFile(t, "testdata/sparbank_swedbank_list_rates.html"),
+		testSwedbankTemplateBank.URL + swedbankAvgRatesPath:  crawlertest.LoadGoldenFile(t, "testdata/sparbank_swedbank_avg_rates.html"),
+		testRateTableBank.URL:                                crawlertest.LoadGoldenFile(t, "testdata/sparbank_rate_table.html"),
+	}
+
+	tests := []struct {
+		name          string
+		bank          Sparbank
+		failURL       string
+		wantListRates int
+		wantAvgRates  int
+	}{
+		{
+			name:          "swedbank template",
+			bank:          testSwedbankTemplateBank,
+			wantListRates: 11, // 3 mån to 10 år, Banklån skipped
+			wantAvgRates:  47, // 6 months × 8 terms with rates, one missing
+		},
+		{
+			name:          "swedbank template without average rates page",
+			bank:          testSwedbankTemplateBank,
+			failURL:       testSwedbankTemplateBank.URL + swedbankAvgRatesPath,
+			wantListRates: 11,
+			wantAvgRates:  0,
+		},
+		{
+			name:          "rate table template",
+			bank:          testRateTableBank,
+			wantListRates: 8,
+			wantAvgRates:  6, // 4 år and 7 år have no average rate
+		},
+		{
+			name:    "rate table template fetch error",
+			bank:    testRateTableBank,
+			failURL: testRateTableBank.URL,
+		},
+		{
+			name: "unknown template",
+			bank: Sparbank{Bank: "Sparbanken Okänd", URL: "https://www.example.se", Template: "wordpress"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &httpmock.ClientMock{
+				FetchFunc: func(url string, _ map[string]string) (string, error) {
+					page, ok := pages[url]
+					if !ok || url == tt.failURL {
+						return "", errors.New("network error")
+					}
+					return page, nil
+				},
+			}
+
+			results := crawlertest.RunCrawl(t, NewSparbankCrawler(tt.bank, client, zap.NewNop()))
+
+			listRateCount, avgRateCount := crawlertest.CountRatesByType(results)
+			if listRateCount != tt.wantListRates {
+				t.Errorf("list rate count = %d, want %d", listRateCount, tt.wantListRates)
+			}
+			if avgRateCount != tt.wantAvgRates {
+				t.Errorf("average rate count = %d, want %d", avgRateCount, tt.wantAvgRates)
+			}
+			crawlertest.AssertBankName(t, results, tt.bank.Bank)
+			for _, r := range results {
+				if r.Source == nil || r.Source.Kind != model.SourceKindHTML || r.Source.Hash == "" {
+					t.Errorf("Source of %s = %+v, want the fetched HTML page", r.Key(), r.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestSparbankCrawler_extractSwedbankListRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/sparbank_swedbank_list_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &SparbankCrawler{bank: testSwedbankTemplateBank, logger: zap.NewNop()}
+
+	results, err := crawler.extractSwedbankListRates(testSwedbankTemplateBank.URL+swedbankListRatesPath, rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractSwedbankListRates() error = %v", err)
+	}
+
+	wantChangedOn := time.Date(2025, 9, 29, 0, 0, 0, 0, time.UTC)
+	rates := map[model.Term]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: testSwedbankTemplateBank.Bank, ExpectChangeOn: true}, crawlTime)
+		if r.ChangedOn != nil && !r.ChangedOn.Equal(wantChangedOn) {
+			t.Errorf("ChangedOn = %v, want %v", r.ChangedOn, wantChangedOn)
+		}
+		rates[r.Term] = r.NominalRate
+	}
+
+	if got := rates[model.Term3months]; got != model.RateFromPercent(3.84) {
+		t.Errorf("3m rate = %s, want 3.84", got)
+	}
+	if got := rates[model.Term10years]; got != model.RateFromPercent(4.09) {
+		t.Errorf("10y rate = %s, want 4.09", got)
+	}
+}
+
+func TestSparbankCrawler_extractSwedbankAverageRates(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/sparbank_swedbank_avg_rates.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &SparbankCrawler{bank: testSwedbankTemplateBank, logger: zap.NewNop()}
+
+	results, err := crawler.extractSwedbankAverageRates(testSwedbankTemplateBank.URL+swedbankAvgRatesPath, rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractSwedbankAverageRates() error = %v", err)
+	}
+
+	rates := map[model.AvgMonth]map[model.Term]model.Rate{}
+	for _, r := range results {
+		crawlertest.AssertAverageRateFields(t, r, testSwedbankTemplateBank.Bank, crawlTime)
+		if rates[*r.AverageReferenceMonth] == nil {
+			rates[*r.AverageReferenceMonth] = map[model.Term]model.Rate{}
+		}
+		rates[*r.AverageReferenceMonth][r.Term] = r.NominalRate
+	}
+
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	april := model.AvgMonth{Year: 2025, Month: time.April}
+	if got := rates[september][model.Term3months]; got != model.RateFromPercent(2.91) {
+		t.Errorf("3m rate of September = %s, want 2.91", got)
+	}
+	if got := rates[april][model.Term10years]; got != model.RateFromPercent(3.72) {
+		t.Errorf("10y rate of April = %s, want 3.72", got)
+	}
+	if got, ok := rates[september][model.Term4years]; ok {
+		t.Errorf("4y rate of September = %s, want none for \"-\"", got)
+	}
+	if len(rates) != 6 {
+		t.Errorf("months = %d, want 6", len(rates))
+	}
+}
+
+func TestSparbankCrawler_extractRateTable(t *testing.T) {
+	t.Parallel()
+
+	rawHTML := crawlertest.LoadGoldenFile(t, "testdata/sparbank_rate_table.html")
+	crawlTime := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+	crawler := &SparbankCrawler{bank: testRateTableBank, logger: zap.NewNop()}
+
+	results, err := crawler.extractRateTable(testRateTableBank.URL, rawHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractRateTable() error = %v", err)
+	}
+
+	wantMonth := model.AvgMonth{Year: 2025, Month: time.September}
+	for _, r := range results {
+		switch r.Type {
+		case model.TypeListRate:
+			crawlertest.AssertListRateFields(t, r, crawlertest.ListRateConfig{Bank: testRateTableBank.Bank}, crawlTime)
+			if r.Term == model.Term3months && r.NominalRate != model.RateFromPercent(3.79) {
+				t.Errorf("3m list rate = %s, want 3.79", r.NominalRate)
+			}
+		case model.TypeAverageRate:
+			crawlertest.AssertAverageRateFields(t, r, testRateTableBank.Bank, crawlTime)
+			if *r.AverageReferenceMonth != wantMonth {
+				t.Errorf("AverageReferenceMonth = %+v, want %+v", *r.AverageReferenceMonth, wantMonth)
+			}
+			if r.Term == model.Term3months && r.NominalRate != model.RateFromPercent(2.89) {
+				t.Errorf("3m average rate = %s, want 2.89", r.NominalRate)
+			}
+		default:
+			t.Errorf("unexpected type %q", r.Type)
+		}
+	}
+
+	if _, err := crawler.extractRateTable(testRateTableBank.URL, "<html><table><tr><th>Produkt</th></tr></table></html>", crawlTime); err == nil {
+		t.Error("extractRateTable() without rates table error = nil, want error")
+	}
+}
+
+func TestSparbankCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 10, 6, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		bank      Sparbank
+		url       string
+		file      string
+		wantType  model.Type
+		wantError error
+	}{
+		{
+			name: "swedbank template list rates", bank: testSwedbankTemplateBank, url: testSwedbankTemplateBank.URL + swedbankListRatesPath,
+			file: "testdata/sparbank_swedbank_list_rates.html", wantType: model.TypeListRate,
+		},
+		{
+			name: "swedbank template average rates", bank: testSwedbankTemplateBank, url: testSwedbankTemplateBank.URL + swedbankAvgRatesPath,
+			file: "testdata/sparbank_swedbank_avg_rates.html", wantType: model.TypeAverageRate,
+		},
+		{
+			name: "other bank's page", bank: testSwedbankTemplateBank, url: "https://www.sparbankenskane.se" + swedbankListRatesPath,
+			file: "testdata/sparbank_swedbank_list_rates.html", wantError: crawlertest.ErrUnknownSource,
+		},
+		{
+			name: "rate table page of swedbank template bank", bank: testSwedbankTemplateBank, url: testSwedbankTemplateBank.URL,
+			file: "testdata/sparbank_rate_table.html", wantError: crawlertest.ErrUnknownSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			crawler := &SparbankCrawler{bank: tt.bank, logger: zap.NewNop()}
+			results, err := crawler.ParseDocument(tt.url, crawlertest.LoadGoldenFileBytes(t, tt.file), fetchedAt)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ParseDocument() error = %v, want %v", err, tt.wantError)
+			}
+			if tt.wantError != nil {
+				return
+			}
+
+			if len(results) == 0 {
+				t.Fatal("ParseDocument() returned no rates")
+			}
+			for _, r := range results {
+				if r.Type != tt.wantType || !r.LastCrawledAt.Equal(fetchedAt) {
+					t.Errorf("rate %s crawled at %v, want %s crawled at %v", r.Key(), r.LastCrawledAt, tt.wantType, fetchedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultSparbanker(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[model.Bank]bool{}
+	for _, p := range model.BankProfiles() {
+		profiles[p.Bank] = true
+	}
+
+	seen := map[model.Bank]bool{}
+	for _, bank := range DefaultSparbanker() {
+		if seen[bank.Bank] {
+			t.Errorf("savings bank %q listed twice", bank.Bank)
+		}
+		seen[bank.Bank] = true
+		if !profiles[bank.Bank] {
+			t.Errorf("savings bank %q is not in the bank catalogue", bank.Bank)
+		}
+		if bank.Template != TemplateSwedbank && bank.Template != TemplateRateTable {
+			t.Errorf("savings bank %q has unknown template %q", bank.Bank, bank.Template)
+		}
+	}
+
+	if crawlers := NewSparbankCrawlers(DefaultSparbanker(), &httpmock.ClientMock{}, zap.NewNop()); len(crawlers) != len(seen) {
+		t.Errorf("NewSparbankCrawlers() returned %d crawlers, want %d", len(crawlers), len(seen))
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/ledongthuc/pdf"
 	"github.com/yama6a/bolan-compare/internal/app/crawler"
+	"github.com/yama6a/bolan-compare/internal/pkg/embedded"
 	"github.com/yama6a/bolan-compare/internal/pkg/http"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
 	"github.com/yama6a/bolan-compare/internal/pkg/utils"
@@ -24,7 +25,13 @@ const (
 	stabeloAvgRatesURL  string     = "https://www.stabelo.se/bolanerantor"
 )
 
-var _ crawler.SiteCrawler = &StabeloCrawler{}
+var (
+	_ crawler.SiteCrawler    = &StabeloCrawler{}
+	_ crawler.Fingerprinter  = &StabeloCrawler{}
+	_ crawler.DocumentParser = &StabeloCrawler{}
+
+	stabeloRateFixationRegex = regexp.MustCompile(`^(\d+)([MY])$`)
+)
 
 // StabeloCrawler crawls Stabelo mortgage rates from their rate table and PDF documents.
 // Stabelo is a digital mortgage bank using a Remix.js framework with turbo-stream data.
@@ -36,13 +43,23 @@ var _ crawler.SiteCrawler = &StabeloCrawler{}
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type StabeloCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 // NewStabeloCrawler creates a new StabeloCrawler instance.
 func NewStabeloCrawler(httpClient http.Client, logger *zap.Logger) *StabeloCrawler {
-	return &StabeloCrawler{httpClient: httpClient, logger: logger}
+	return &StabeloCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(stabeloBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *StabeloCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 // Crawl fetches and parses Stabelo mortgage rates from all sources.
@@ -70,6 +87,19 @@ func (c *StabeloCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the rate table or the average rates PDF again. The PDF is linked from the
+// rates page under a changing file name, so any PDF with Stabelo in its URL is taken for it.
+func (c *StabeloCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch {
+	case url == stabeloRateTableURL:
+		return c.extractRates(string(content), fetchedAt)
+	case strings.Contains(strings.ToLower(url), "stabelo") && strings.HasSuffix(strings.ToLower(url), ".pdf"):
+		return c.parsePDF(content, fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 // fetchRates fetches the rate table and extracts list rates and LTV-discounted rates.
 func (c *StabeloCrawler) fetchRates(crawlTime time.Time) ([]model.InterestSet, error) {
 	rawHTML, err := c.httpClient.Fetch(stabeloRateTableURL, nil)
@@ -77,7 +107,13 @@ func (c *StabeloCrawler) fetchRates(crawlTime time.Time) ([]model.InterestSet, e
 		return nil, fmt.Errorf("failed reading Stabelo rate table: %w", err)
 	}
 
-	return c.extractRates(rawHTML, crawlTime)
+	interestSets, err := c.extractRates(rawHTML, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(interestSets, stabeloRateTableURL, []byte(rawHTML))
+	return interestSets, nil
 }
 
 // extractRates parses the HTML and extracts both list rates and LTV-discounted rates.
@@ -108,38 +144,36 @@ func (c *StabeloCrawler) extractRates(rawHTML string, crawlTime time.Time) ([]mo
 	return results, nil
 }
 
-// extractListRatesFromTurboStream attempts to extract true list rates from the turbo-stream data.
+// extractListRatesFromTurboStream extracts true list rates from the turbo-stream data.
 // List rates are the worst-case rates (no LTV discount, no green loan discount, no amount discount).
 // These are identified in the turbo-stream by entries with no LTV, no EPC, and product_amount=0.
 func (c *StabeloCrawler) extractListRatesFromTurboStream(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
-	// The turbo-stream format uses a flat array with reference-based encoding.
-	// We extract rates by finding patterns in the raw data.
-	//
-	// From analysis:
-	// - List rate entries have no "ltv" field
-	// - Rate fixations: "3M", "1Y", "2Y", "3Y", "5Y", "10Y"
-	// - Rates are in basis points (333 = 3.33%)
-
-	// Extract all rate basis point values near rate fixation strings
+	data, err := embedded.DecodeRemixContext(rawHTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode turbo-stream: %w", err)
+	}
+
 	results := []model.InterestSet{}
+	seen := make(map[model.Term]bool)
+	for _, entry := range embedded.FindObjects(data, "interest_rate") {
+		c.fingerprints.RecordJSON(stabeloRateTableURL, "rate table", entry)
 
-	// Pattern to find rate fixation followed by associated bps value in the turbo-stream
-	// The structure is: "rate_fixation" ... "3M" ... bps value nearby
-	// We look for patterns like: ,"3M", ... followed by numbers that could be rates
+		termStr, bps, ok := listRateFromEntry(entry)
+		if !ok {
+			continue
+		}
 
-	termRates := c.findListRatesInStream(rawHTML)
-	if len(termRates) == 0 {
-		return nil, fmt.Errorf("could not find list rates in turbo-stream")
-	}
+		modelTerm, err := parseStabeloTerm(termStr)
+		if err != nil || seen[modelTerm] {
+			continue
+		}
 
-	for term, rateBps := range termRates {
-		modelTerm, err := parseStabeloTerm(term)
+		rate, err := utils.ConvertRate(bps, utils.UnitBasisPoints)
 		if err != nil {
 			continue
 		}
 
-		rate := float32(rateBps) / 100.0
-
+		seen[modelTerm] = true
 		results = append(results, model.InterestSet{
 			Bank:          stabeloBankName,
 			Type:          model.TypeListRate,
@@ -149,42 +183,37 @@ func (c *StabeloCrawler) extractListRatesFromTurboStream(rawHTML string, crawlTi
 		})
 	}
 
-	return results, nil
-}
-
-// findListRatesInStream searches the turbo-stream data for list rate values.
-// Based on analysis, list rates (no LTV) are typically the highest rates for each term.
-func (c *StabeloCrawler) findListRatesInStream(rawHTML string) map[string]int {
-	result := make(map[string]int)
-	terms := []string{"3M", "1Y", "2Y", "3Y", "5Y", "10Y"}
-
-	for _, term := range terms {
-		maxRate := c.findMaxRateForTerm(rawHTML, term)
-		if maxRate > 0 {
-			result[term] = maxRate
-		}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("could not find list rates in turbo-stream")
 	}
 
-	return result
+	crawler.SetExtraction(results, model.SourceKindHTML, "extractListRatesFromTurboStream")
+	return results, nil
 }
 
-// findMaxRateForTerm finds the highest rate value near a term string in the turbo-stream.
-func (c *StabeloCrawler) findMaxRateForTerm(rawHTML, term string) int {
-	termPattern := regexp.MustCompile(fmt.Sprintf(`"%s"[^"]*?(\d{3})`, term))
-	termMatches := termPattern.FindAllStringSubmatch(rawHTML, -1)
+// listRateFromEntry returns the rate fixation and basis points of a rate table entry, if the entry is a list rate.
+func listRateFromEntry(entry map[string]any) (string, float64, bool) {
+	config, err := embedded.Object(entry, "product_configuration")
+	if err != nil {
+		return "", 0, false
+	}
+	if config["ltv"] != nil || config["epc_classification"] != nil {
+		return "", 0, false
+	}
+	if amount, err := embedded.Number(config, "product_amount.value"); err != nil || amount != 0 {
+		return "", 0, false
+	}
 
-	maxRate := 0
-	for _, match := range termMatches {
-		val, err := strconv.Atoi(match[1])
-		if err != nil {
-			continue
-		}
-		if val >= 200 && val <= 600 && val > maxRate {
-			maxRate = val
-		}
+	term, err := embedded.String(config, "rate_fixation")
+	if err != nil {
+		return "", 0, false
+	}
+	bps, err := embedded.Number(entry, "interest_rate.bps")
+	if err != nil {
+		return "", 0, false
 	}
 
-	return maxRate
+	return term, bps, true
 }
 
 // extractLTVRatesFromHTML extracts rates from the HTML buttons.
@@ -240,6 +269,7 @@ func (c *StabeloCrawler) extractLTVRatesFromHTML(rawHTML string, crawlTime time.
 		})
 	}
 
+	crawler.SetExtraction(results, model.SourceKindHTML, "extractLTVRatesFromHTML")
 	return results, nil
 }
 
@@ -266,7 +296,13 @@ func (c *StabeloCrawler) fetchAverageRates(crawlTime time.Time) ([]model.Interes
 	}
 
 	// Parse the PDF
-	return c.parsePDF(pdfContent, crawlTime)
+	avgRates, err := c.parsePDF(pdfContent, crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetSource(avgRates, pdfURL, pdfContent)
+	return avgRates, nil
 }
 
 // findPDFLink searches the HTML for a link to the average rates PDF.
@@ -355,7 +391,13 @@ func (c *StabeloCrawler) parsePDF(pdfContent []byte, crawlTime time.Time) ([]mod
 		allText.WriteString("\n")
 	}
 
-	return c.parseAverageRatesText(allText.String(), crawlTime)
+	interestSets, err := c.parseAverageRatesText(allText.String(), crawlTime)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler.SetExtraction(interestSets, model.SourceKindPDF, "parsePDF")
+	return interestSets, nil
 }
 
 // parseAverageRatesText extracts average rates from the PDF text content.
@@ -363,6 +405,12 @@ func (c *StabeloCrawler) parsePDF(pdfContent []byte, crawlTime time.Time) ([]mod
 func (c *StabeloCrawler) parseAverageRatesText(text string, crawlTime time.Time) ([]model.InterestSet, error) {
 	// Extract terms from the PDF header
 	terms := extractTermsFromHeader(text)
+	tokens := make([]string, 0, len(terms))
+	for _, term := range terms {
+		tokens = append(tokens, term.String())
+	}
+	// The PDF is linked from the average rates page under a changing name, so the page is the source.
+	c.fingerprints.RecordTokens(stabeloAvgRatesURL, "average rates PDF header", tokens)
 	if len(terms) == 0 {
 		return nil, fmt.Errorf("could not find terms in PDF header")
 	}
@@ -446,11 +494,11 @@ func findAllRateData(text string) []rateData {
 		yearStr := remaining[match[4]:match[5]]
 		ratePart := strings.TrimSpace(remaining[match[6]:match[7]])
 
-		month := parseSwedishMonth(monthStr)
+		month, err := utils.ParseSwedishMonth(monthStr)
 		year, _ := strconv.Atoi(yearStr)
 
 		// Only add if we have valid rate data (contains at least one percentage)
-		if strings.Contains(ratePart, "%") {
+		if err == nil && strings.Contains(ratePart, "%") {
 			results = append(results, rateData{
 				month:    month,
 				year:     year,
@@ -466,25 +514,6 @@ func findAllRateData(text string) []rateData {
 	return results
 }
 
-// parseSwedishMonth converts a Swedish month name to time.Month.
-func parseSwedishMonth(name string) time.Month {
-	monthMap := map[string]time.Month{
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-	}
-	return monthMap[name]
-}
-
 // buildAverageRateResults creates InterestSet results from parsed rate data.
 func (c *StabeloCrawler) buildAverageRateResults(
 	dataLine string, month time.Month, year int, crawlTime time.Time, terms []model.Term,
@@ -552,7 +581,7 @@ func isRateFixationValue(s string) bool {
 
 // extractRateFromButton extracts the interest rate percentage from a button element.
 // The rate is in a span element with format "X,XX %".
-func extractRateFromButton(btn *html.Node) (float32, error) {
+func extractRateFromButton(btn *html.Node) (model.Rate, error) {
 	var spans []string
 
 	var collectSpans func(*html.Node)
@@ -607,43 +636,22 @@ func getAttr(n *html.Node, key string) string {
 	return ""
 }
 
-// parseStabeloRate parses a Swedish-format rate string like "2,54 %" to float32.
-func parseStabeloRate(s string) (float32, error) {
-	// Trim surrounding whitespace first
-	s = strings.TrimSpace(s)
-
-	// Remove "%" suffix (with or without space before it)
-	s = strings.TrimSuffix(s, "%")
-	s = strings.TrimSpace(s)
-
-	// Replace Swedish decimal comma with period
-	s = strings.Replace(s, ",", ".", 1)
-
-	rate, err := strconv.ParseFloat(s, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", s, err)
-	}
-
-	return float32(rate), nil
+// parseStabeloRate parses a Swedish-format rate string like "2,54 %".
+func parseStabeloRate(s string) (model.Rate, error) {
+	return utils.ParseRate(s)
 }
 
 // parseStabeloTerm converts Stabelo's rate fixation format to model.Term.
 // Rate fixation format: "3M", "1Y", "2Y", "3Y", "5Y", "10Y".
 func parseStabeloTerm(rateFixation string) (model.Term, error) {
-	switch rateFixation {
-	case "3M":
-		return model.Term3months, nil
-	case "1Y":
-		return model.Term1year, nil
-	case "2Y":
-		return model.Term2years, nil
-	case "3Y":
-		return model.Term3years, nil
-	case "5Y":
-		return model.Term5years, nil
-	case "10Y":
-		return model.Term10years, nil
-	default:
-		return "", fmt.Errorf("unsupported Stabelo rate fixation: %s", rateFixation)
+	matches := stabeloRateFixationRegex.FindStringSubmatch(rateFixation)
+	if matches == nil {
+		return model.Term{}, fmt.Errorf("unsupported Stabelo rate fixation: %s", rateFixation)
+	}
+
+	count, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return model.Term{}, fmt.Errorf("unsupported Stabelo rate fixation %s: %w", rateFixation, err)
 	}
+	return utils.TermFromCount(count, matches[2]) //nolint:wrapcheck // utils error names the term
 }
@@ -174,6 +174,48 @@ func TestStabeloCrawler_fetchRates(t *testing.T) {
 	}
 }
 
+func TestStabeloCrawler_extractListRatesFromTurboStream(t *testing.T) {
+	t.Parallel()
+
+	rateTableHTML := crawlertest.LoadGoldenFile(t, "testdata/stabelo_rate_table.html")
+	crawlTime := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	crawler := &StabeloCrawler{logger: zap.NewNop()}
+
+	results, err := crawler.extractListRatesFromTurboStream(rateTableHTML, crawlTime)
+	if err != nil {
+		t.Fatalf("extractListRatesFromTurboStream() error = %v", err)
+	}
+
+	// Entries without LTV, EPC class and loan amount; the 3M entry with EPC class B is 3.23 %.
+	want := map[model.Term]float64{
+		model.Term3months: 3.33,
+		model.Term1year:   2.94,
+		model.Term2years:  3.08,
+		model.Term3years:  3.16,
+		model.Term5years:  3.41,
+		model.Term10years: 4.21,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("extractListRatesFromTurboStream() returned %d results, want %d", len(results), len(want))
+	}
+
+	for _, r := range results {
+		if r.Type != model.TypeListRate {
+			t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
+		}
+		if r.NominalRate != model.RateFromPercent(want[r.Term]) {
+			t.Errorf("%s NominalRate = %v, want %v", r.Term, r.NominalRate, want[r.Term])
+		}
+		if !r.LastCrawledAt.Equal(crawlTime) {
+			t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
+		}
+	}
+
+	if _, err := crawler.extractListRatesFromTurboStream("<html></html>", crawlTime); err == nil {
+		t.Error("extractListRatesFromTurboStream() without turbo-stream returned no error")
+	}
+}
+
 func TestStabeloCrawler_extractLTVRatesFromHTML(t *testing.T) {
 	t.Parallel()
 
@@ -396,8 +438,9 @@ func TestParseStabeloTerm(t *testing.T) {
 		{name: "3 years", term: "3Y", want: model.Term3years, wantErr: false},
 		{name: "5 years", term: "5Y", want: model.Term5years, wantErr: false},
 		{name: "10 years", term: "10Y", want: model.Term10years, wantErr: false},
-		{name: "unsupported 4 years", term: "4Y", wantErr: true},
-		{name: "unsupported 6 months", term: "6M", wantErr: true},
+		{name: "4 years", term: "4Y", want: model.Term4years, wantErr: false},
+		{name: "18 months", term: "18M", want: model.FixedTerm(18), wantErr: false},
+		{name: "zero months", term: "0M", wantErr: true},
 		{name: "empty string", term: "", wantErr: true},
 		{name: "invalid format", term: "3 months", wantErr: true},
 	}
@@ -424,7 +467,7 @@ func TestParseStabeloRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{name: "rate 2,54%", input: "2,54 %", want: 2.54, wantErr: false},
@@ -445,7 +488,7 @@ func TestParseStabeloRate(t *testing.T) {
 				t.Errorf("parseStabeloRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseStabeloRate() = %v, want %v", got, tt.want)
 			}
 		})
@@ -489,7 +532,7 @@ func assertStabeloLTVRateFields(t *testing.T, r model.InterestSet, crawlTime tim
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeRatioDiscounted)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", r.LastCrawledAt, crawlTime)
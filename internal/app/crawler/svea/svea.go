@@ -3,7 +3,6 @@ package svea
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -22,28 +21,13 @@ const (
 )
 
 var (
-	_              crawler.SiteCrawler = &SveaCrawler{}
-	sveaRateRegex                      = regexp.MustCompile(`^(\d+[,.]?\d*)\s*%?$`)
-	sveaMonthRegex                     = regexp.MustCompile(`(?i)^(\w+)\s+(\d{4})$`)
+	_ crawler.SiteCrawler    = &SveaCrawler{}
+	_ crawler.Fingerprinter  = &SveaCrawler{}
+	_ crawler.DocumentParser = &SveaCrawler{}
 	// Regex to find the table containing "Månad för utbetalning" text.
 	sveaTableRegex = regexp.MustCompile(`(?s)<table[^>]*>.*?Månad för utbetalning.*?</table>`)
 	// Regex to extract list rate from "Bolån från X,XX %" (handles &nbsp; as well).
 	sveaListRateRegex = regexp.MustCompile(`Bolån från (\d+[,.]?\d*)\s*(?:&nbsp;)?%`)
-
-	sveaSwedishMonthMap = map[string]time.Month{ //nolint: gochecknoglobals
-		"januari":   time.January,
-		"februari":  time.February,
-		"mars":      time.March,
-		"april":     time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"juli":      time.July,
-		"augusti":   time.August,
-		"september": time.September,
-		"oktober":   time.October,
-		"november":  time.November,
-		"december":  time.December,
-	}
 )
 
 // SveaCrawler crawls Svea Bank's rates page.
@@ -52,12 +36,22 @@ var (
 //
 //nolint:revive // Bank name prefix is intentional for clarity
 type SveaCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewSveaCrawler(httpClient http.Client, logger *zap.Logger) *SveaCrawler {
-	return &SveaCrawler{httpClient: httpClient, logger: logger}
+	return &SveaCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(sveaBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SveaCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *SveaCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -72,7 +66,9 @@ func (c *SveaCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Svea list rate", zap.Error(err))
 		} else {
-			channel <- listRate
+			listRates := []model.InterestSet{listRate}
+			crawler.SetSource(listRates, sveaListRatesURL, []byte(listHTML))
+			channel <- listRates[0]
 		}
 	}
 
@@ -89,22 +85,43 @@ func (c *SveaCrawler) Crawl(channel chan<- model.InterestSet) {
 		return
 	}
 
+	crawler.SetSource(interestSets, sveaAvgRatesURL, []byte(avgHTML))
 	for _, set := range interestSets {
 		channel <- set
 	}
 }
 
+// ParseDocument parses an archived copy of the list or average rates page again.
+func (c *SveaCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case sveaListRatesURL:
+		listRate, err := c.extractListRate(string(content), fetchedAt)
+		if err != nil {
+			return nil, err
+		}
+		return []model.InterestSet{listRate}, nil
+	case sveaAvgRatesURL:
+		return c.extractAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 // extractListRate parses the list rate from Svea's main bolån page.
 // The rate is displayed in the page header as "Bolån från X,XX %".
 // Svea only offers variable rate (rörlig ränta) mortgages.
 func (c *SveaCrawler) extractListRate(rawHTML string, crawlTime time.Time) (model.InterestSet, error) {
 	matches := sveaListRateRegex.FindStringSubmatch(rawHTML)
+	tokens := []string{}
+	if len(matches) == 2 {
+		tokens = append(tokens, "Bolån från")
+	}
+	c.fingerprints.RecordTokens(sveaListRatesURL, "list rate", tokens)
 	if len(matches) != 2 {
 		return model.InterestSet{}, fmt.Errorf("failed to find list rate in page")
 	}
 
-	rateStr := strings.ReplaceAll(matches[1], ",", ".")
-	rate, err := strconv.ParseFloat(rateStr, 32)
+	rate, err := utils.ParseRate(matches[1])
 	if err != nil {
 		return model.InterestSet{}, fmt.Errorf("failed to parse list rate: %w", err)
 	}
@@ -112,14 +129,15 @@ func (c *SveaCrawler) extractListRate(rawHTML string, crawlTime time.Time) (mode
 	return model.InterestSet{
 		Bank:          sveaBankName,
 		Type:          model.TypeListRate,
-		Term:          model.Term3months, // Svea only offers variable rate (3 månader).
-		NominalRate:   float32(rate),
+		Term:          model.TermVariable, // Svea only offers a variable rate.
+		NominalRate:   rate,
 		LastCrawledAt: crawlTime,
 
 		ChangedOn:               nil,
 		AverageReferenceMonth:   nil,
 		RatioDiscountBoundaries: nil,
 		UnionDiscount:           false,
+		Source:                  &model.SourceRef{Kind: model.SourceKindHTML, Method: "extractListRate"},
 	}, nil
 }
 
@@ -140,6 +158,7 @@ func (c *SveaCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse avg rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(sveaAvgRatesURL, "average rates", table.Header)
 
 	interestSets := make([]model.InterestSet, 0, len(table.Rows))
 
@@ -166,7 +185,7 @@ func (c *SveaCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) (
 		interestSets = append(interestSets, model.InterestSet{
 			Bank:                  sveaBankName,
 			Type:                  model.TypeAverageRate,
-			Term:                  model.Term3months, // Svea only offers variable rate (3 månader).
+			Term:                  model.TermVariable, // Svea only offers a variable rate.
 			NominalRate:           rate,
 			LastCrawledAt:         crawlTime,
 			AverageReferenceMonth: &refMonthCopy,
@@ -181,56 +200,16 @@ func (c *SveaCrawler) extractAverageRates(rawHTML string, crawlTime time.Time) (
 		return nil, fmt.Errorf("no average rates extracted from Svea page")
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractAverageRates")
 	return interestSets, nil
 }
 
 // parseSveaRate parses a rate string like "6,10 %" or "6.10%".
-func (c *SveaCrawler) parseSveaRate(rateStr string) (float32, error) {
-	rateStr = utils.NormalizeSpaces(rateStr)
-	rateStr = strings.ReplaceAll(rateStr, ",", ".")
-	rateStr = strings.ReplaceAll(rateStr, " ", "")
-	rateStr = strings.TrimSuffix(rateStr, "%")
-	rateStr = strings.TrimSpace(rateStr)
-
-	if rateStr == "" || rateStr == "-" {
-		return 0, fmt.Errorf("empty or missing rate")
-	}
-
-	matches := sveaRateRegex.FindStringSubmatch(rateStr)
-	if len(matches) != 2 {
-		return 0, fmt.Errorf("failed to match rate regex: %s", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(matches[1], 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate: %w", err)
-	}
-
-	return float32(rate), nil
+func (c *SveaCrawler) parseSveaRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 // parseSveaMonth parses a month string like "November 2025" to AvgMonth.
 func (c *SveaCrawler) parseSveaMonth(monthStr string) (model.AvgMonth, error) {
-	monthStr = utils.NormalizeSpaces(monthStr)
-
-	matches := sveaMonthRegex.FindStringSubmatch(monthStr)
-	if len(matches) != 3 {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse month: %s", monthStr)
-	}
-
-	monthName := strings.ToLower(matches[1])
-	year, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return model.AvgMonth{}, fmt.Errorf("failed to parse year: %w", err)
-	}
-
-	month, ok := sveaSwedishMonthMap[monthName]
-	if !ok {
-		return model.AvgMonth{}, fmt.Errorf("unknown Swedish month: %s", monthName)
-	}
-
-	return model.AvgMonth{
-		Month: month,
-		Year:  uint(year),
-	}, nil
+	return utils.ParseMonthYear(monthStr)
 }
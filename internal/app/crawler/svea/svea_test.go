@@ -138,11 +138,11 @@ func TestSveaCrawler_extractListRate(t *testing.T) {
 	if result.Type != model.TypeListRate {
 		t.Errorf("Type = %q, want TypeListRate", result.Type)
 	}
-	if result.Term != model.Term3months {
-		t.Errorf("Term = %q, want Term3months", result.Term)
+	if result.Term != model.TermVariable {
+		t.Errorf("Term = %q, want TermVariable", result.Term)
 	}
 	if result.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", result.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", result.NominalRate)
 	}
 	if result.LastCrawledAt != crawlTime {
 		t.Errorf("LastCrawledAt = %v, want %v", result.LastCrawledAt, crawlTime)
@@ -178,6 +178,49 @@ func TestSveaCrawler_extractAverageRates(t *testing.T) {
 	}
 }
 
+func TestSveaCrawler_ParseDocument(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2025, 11, 3, 6, 0, 0, 0, time.UTC)
+	crawler := &SveaCrawler{logger: zap.NewNop()}
+
+	tests := []struct {
+		name      string
+		url       string
+		file      string
+		wantType  model.Type
+		wantError error
+	}{
+		{name: "list rates page", url: sveaListRatesURL, file: "testdata/svea_list_rates.html", wantType: model.TypeListRate, wantError: nil},
+		{name: "average rates page", url: sveaAvgRatesURL, file: "testdata/svea_avg_rates.html", wantType: model.TypeAverageRate, wantError: nil},
+		{name: "unknown page", url: "https://www.svea.com/sv-se", file: "testdata/svea_list_rates.html", wantType: "", wantError: crawlertest.ErrUnknownSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content := crawlertest.LoadGoldenFileBytes(t, tt.file)
+			results, err := crawler.ParseDocument(tt.url, content, fetchedAt)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("ParseDocument() error = %v, want %v", err, tt.wantError)
+			}
+			if tt.wantError != nil {
+				return
+			}
+
+			if len(results) == 0 {
+				t.Fatal("ParseDocument() returned no rates")
+			}
+			for _, r := range results {
+				if r.Type != tt.wantType || !r.LastCrawledAt.Equal(fetchedAt) {
+					t.Errorf("rate %s crawled at %v, want %s crawled at %v", r.Key(), r.LastCrawledAt, tt.wantType, fetchedAt)
+				}
+			}
+		})
+	}
+}
+
 func TestSveaCrawler_parseSveaRate(t *testing.T) {
 	t.Parallel()
 
@@ -187,7 +230,7 @@ func TestSveaCrawler_parseSveaRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -244,8 +287,7 @@ func TestSveaCrawler_parseSveaRate(t *testing.T) {
 				return
 			}
 			if !tt.wantErr {
-				diff := got - tt.want
-				if diff > 0.001 || diff < -0.001 {
+				if got != model.RateFromPercent(tt.want) {
 					t.Errorf("parseSveaRate() = %v, want %v", got, tt.want)
 				}
 			}
@@ -302,9 +344,11 @@ func TestSveaCrawler_parseSveaMonth(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:    "invalid format",
-			input:   "2025-11",
-			wantErr: true,
+			name:      "numeric format",
+			input:     "2025-11",
+			wantYear:  2025,
+			wantMonth: time.November,
+			wantErr:   false,
 		},
 		{
 			name:    "unknown month",
@@ -375,12 +419,12 @@ func assertSveaAvgRateFields(t *testing.T, r model.InterestSet, crawlTime time.T
 	if r.Type != model.TypeAverageRate {
 		t.Errorf("Type = %q, want TypeAverageRate", r.Type)
 	}
-	// Svea only offers variable rate (3 months).
-	if r.Term != model.Term3months {
-		t.Errorf("Term = %q, want Term3months", r.Term)
+	// Svea only offers a variable rate.
+	if r.Term != model.TermVariable {
+		t.Errorf("Term = %q, want TermVariable", r.Term)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil for average rate")
@@ -3,7 +3,6 @@ package swedbank
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -21,54 +20,34 @@ const (
 )
 
 var (
-	_ crawler.SiteCrawler = &SwedbankCrawler{}
+	_ crawler.SiteCrawler    = &SwedbankCrawler{}
+	_ crawler.Fingerprinter  = &SwedbankCrawler{}
+	_ crawler.DocumentParser = &SwedbankCrawler{}
 
 	// Swedbank date format in list rates header: "senast ändrad 25 september 2025".
-	swedbankListDateRegex = regexp.MustCompile(`senast ändrad (\d{1,2}) (\w+) (\d{4})`)
+	swedbankListDateRegex = regexp.MustCompile(`senast ändrad (\d{1,2} \S+ \d{4})`)
 	// Swedbank month format in average rates header: "november 2025".
-	swedbankAvgMonthRegex = regexp.MustCompile(`(\w+) (\d{4})$`)
+	swedbankAvgMonthRegex = regexp.MustCompile(`(\S+ \d{4})$`)
 )
 
-// swedbankSwedishMonthToTime converts Swedish month names to time.Month.
-// Supports both full names (januari) and abbreviated names (jan.).
-func swedbankSwedishMonthToTime(monthName string) (time.Month, bool) {
-	months := map[string]time.Month{
-		"januari":   time.January,
-		"jan":       time.January,
-		"februari":  time.February,
-		"feb":       time.February,
-		"mars":      time.March,
-		"mar":       time.March,
-		"april":     time.April,
-		"apr":       time.April,
-		"maj":       time.May,
-		"juni":      time.June,
-		"jun":       time.June,
-		"juli":      time.July,
-		"jul":       time.July,
-		"augusti":   time.August,
-		"aug":       time.August,
-		"september": time.September,
-		"sep":       time.September,
-		"oktober":   time.October,
-		"okt":       time.October,
-		"november":  time.November,
-		"nov":       time.November,
-		"december":  time.December,
-		"dec":       time.December,
-	}
-	m, ok := months[monthName]
-	return m, ok
-}
-
 //nolint:revive // Bank name prefix is intentional for clarity
 type SwedbankCrawler struct {
-	httpClient http.Client
-	logger     *zap.Logger
+	httpClient   http.Client
+	logger       *zap.Logger
+	fingerprints *crawler.FingerprintRecorder
 }
 
 func NewSwedbankCrawler(httpClient http.Client, logger *zap.Logger) *SwedbankCrawler {
-	return &SwedbankCrawler{httpClient: httpClient, logger: logger}
+	return &SwedbankCrawler{
+		httpClient:   httpClient,
+		logger:       logger,
+		fingerprints: crawler.NewFingerprintRecorder(swedbankBankName),
+	}
+}
+
+// Fingerprints returns the structure of the sources parsed since the last call.
+func (c *SwedbankCrawler) Fingerprints() []model.Fingerprint {
+	return c.fingerprints.Fingerprints()
 }
 
 func (c *SwedbankCrawler) Crawl(channel chan<- model.InterestSet) {
@@ -84,6 +63,7 @@ func (c *SwedbankCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Swedbank List Rates", zap.Error(err))
 		} else {
+			crawler.SetSource(listRates, swedbankListRatesURL, []byte(listHTML))
 			interestSets = append(interestSets, listRates...)
 		}
 	}
@@ -97,6 +77,7 @@ func (c *SwedbankCrawler) Crawl(channel chan<- model.InterestSet) {
 		if err != nil {
 			c.logger.Error("failed parsing Swedbank Historic Average Rates", zap.Error(err))
 		} else {
+			crawler.SetSource(avgRates, swedbankHistoricRatesURL, []byte(historicHTML))
 			interestSets = append(interestSets, avgRates...)
 		}
 	}
@@ -106,6 +87,18 @@ func (c *SwedbankCrawler) Crawl(channel chan<- model.InterestSet) {
 	}
 }
 
+// ParseDocument parses an archived copy of the list or historic rates page again.
+func (c *SwedbankCrawler) ParseDocument(url string, content []byte, fetchedAt time.Time) ([]model.InterestSet, error) {
+	switch url {
+	case swedbankListRatesURL:
+		return c.extractListRates(string(content), fetchedAt)
+	case swedbankHistoricRatesURL:
+		return c.extractHistoricAverageRates(string(content), fetchedAt)
+	default:
+		return nil, crawler.ErrUnknownSource
+	}
+}
+
 func (c *SwedbankCrawler) extractListRates(rawHTML string, crawlTime time.Time) ([]model.InterestSet, error) {
 	// Find the table that follows the heading "Aktuella bolåneräntor – listpris"
 	tokenizer, err := utils.FindTokenizedTableByTextBeforeTable(rawHTML, "Aktuella bolåneräntor – listpris")
@@ -117,6 +110,12 @@ func (c *SwedbankCrawler) extractListRates(rawHTML string, crawlTime time.Time)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse list rates table: %w", err)
 	}
+	// The rate column header carries the change date, which must not count as a layout change.
+	header := make([]string, 0, len(table.Header))
+	for _, text := range table.Header {
+		header = append(header, swedbankListDateRegex.ReplaceAllString(text, "senast ändrad <date>"))
+	}
+	c.fingerprints.RecordTable(swedbankListRatesURL, "list rates", header)
 
 	// Extract the change date from the header (e.g., "Ränta, senast ändrad 25 september 2025")
 	var changedOn *time.Time
@@ -164,6 +163,7 @@ func (c *SwedbankCrawler) extractListRates(rawHTML string, crawlTime time.Time)
 		})
 	}
 
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractListRates")
 	return interestSets, nil
 }
 
@@ -178,6 +178,7 @@ func (c *SwedbankCrawler) extractHistoricAverageRates(rawHTML string, crawlTime
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse historic average rates table: %w", err)
 	}
+	c.fingerprints.RecordTable(swedbankHistoricRatesURL, "historic average rates", table.Header)
 
 	// Parse term columns from header: Bindningstid, 3 månader, 1 år, 2 år, ..., 10 år, Banklån*
 	termColumns, err := c.parseHistoricTableHeader(table.Header)
@@ -185,7 +186,9 @@ func (c *SwedbankCrawler) extractHistoricAverageRates(rawHTML string, crawlTime
 		return nil, err
 	}
 
-	return c.parseHistoricRateRows(table.Rows, termColumns, crawlTime), nil
+	interestSets := c.parseHistoricRateRows(table.Rows, termColumns, crawlTime)
+	crawler.SetExtraction(interestSets, model.SourceKindHTML, "extractHistoricAverageRates")
+	return interestSets, nil
 }
 
 // parseHistoricTableHeader parses the header row and returns a mapping of column index to term.
@@ -257,22 +260,8 @@ func (c *SwedbankCrawler) parseHistoricRateRows(rows [][]string, termColumns map
 	return interestSets
 }
 
-func parseSwedbankRate(rateStr string) (float32, error) {
-	str := utils.NormalizeSpaces(rateStr)
-	str = strings.ReplaceAll(str, "%", "")
-	str = strings.ReplaceAll(str, ",", ".")
-	str = strings.TrimSpace(str)
-
-	if str == "" || str == "-" {
-		return 0, fmt.Errorf("empty or invalid rate: %q", rateStr)
-	}
-
-	rate, err := strconv.ParseFloat(str, 32)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate %q: %w", rateStr, err)
-	}
-
-	return float32(rate), nil
+func parseSwedbankRate(rateStr string) (model.Rate, error) {
+	return utils.ParseRate(rateStr)
 }
 
 func parseSwedbankListDate(headerStr string) (time.Time, error) {
@@ -284,62 +273,31 @@ func parseSwedbankListDate(headerStr string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("date in %q does not match expected format 'senast ändrad DD month YYYY'", headerStr)
 	}
 
-	day, _ := strconv.Atoi(matches[1])
-	monthName := matches[2]
-	year, _ := strconv.Atoi(matches[3])
-
-	month, ok := swedbankSwedishMonthToTime(monthName)
-	if !ok {
-		return time.Time{}, fmt.Errorf("unknown Swedish month: %q", monthName)
-	}
-
-	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	return utils.ParseSwedishDate(matches[1])
 }
 
 func parseSwedbankAvgMonth(headerStr string) (*model.AvgMonth, error) {
 	str := utils.NormalizeSpaces(headerStr)
-	str = strings.ToLower(str)
 
 	matches := swedbankAvgMonthRegex.FindStringSubmatch(str)
 	if matches == nil {
 		return nil, fmt.Errorf("month in %q does not match expected format 'month YYYY'", headerStr)
 	}
 
-	monthName := matches[1]
-	year, _ := strconv.Atoi(matches[2])
-
-	month, ok := swedbankSwedishMonthToTime(monthName)
-	if !ok {
-		return nil, fmt.Errorf("unknown Swedish month: %q", monthName)
+	month, err := utils.ParseMonthYear(matches[1])
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: month,
-	}, nil
+	return &month, nil
 }
 
 // parseSwedbankHistoricMonth parses abbreviated Swedish month format like "nov. 2025" or "okt. 2025".
 func parseSwedbankHistoricMonth(monthStr string) (*model.AvgMonth, error) {
-	str := utils.NormalizeSpaces(monthStr)
-	str = strings.ToLower(str)
-	str = strings.ReplaceAll(str, ".", "") // Remove period after abbreviation
-
-	matches := swedbankAvgMonthRegex.FindStringSubmatch(str)
-	if matches == nil {
-		return nil, fmt.Errorf("month in %q does not match expected format 'mon. YYYY'", monthStr)
-	}
-
-	monthName := matches[1]
-	year, _ := strconv.Atoi(matches[2])
-
-	month, ok := swedbankSwedishMonthToTime(monthName)
-	if !ok {
-		return nil, fmt.Errorf("unknown Swedish month: %q", monthName)
+	month, err := utils.ParseMonthYear(monthStr)
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.AvgMonth{
-		Year:  uint(year),
-		Month: month,
-	}, nil
+	return &month, nil
 }
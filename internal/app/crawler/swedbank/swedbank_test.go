@@ -3,6 +3,7 @@ package swedbank
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -23,7 +24,7 @@ func assertSwedbankListRateFields(t *testing.T, r model.InterestSet, crawlTime t
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -44,7 +45,7 @@ func assertSwedbankAverageRateFields(t *testing.T, r model.InterestSet, crawlTim
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
@@ -195,6 +196,31 @@ func TestSwedbankCrawler_extractListRates(t *testing.T) {
 	}
 }
 
+func TestSwedbankCrawler_extractListRates_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	goldenHTML := crawlertest.LoadGoldenFile(t, "testdata/swedbank.html")
+	crawler := NewSwedbankCrawler(nil, zap.NewNop())
+
+	if _, err := crawler.extractListRates(goldenHTML, time.Now()); err != nil {
+		t.Fatalf("extractListRates() error = %v", err)
+	}
+
+	fingerprints := crawler.Fingerprints()
+	if len(fingerprints) != 1 {
+		t.Fatalf("Fingerprints() returned %d fingerprints, want 1", len(fingerprints))
+	}
+
+	// The change date in the header must be masked so that a rate change is not reported as a layout change.
+	want := []string{
+		`list rates: column 0 "Bindningstid"`,
+		`list rates: column 1 "Ränta, senast ändrad <date>"`,
+	}
+	if !reflect.DeepEqual(fingerprints[0].Features, want) {
+		t.Errorf("Features = %q, want %q", fingerprints[0].Features, want)
+	}
+}
+
 func TestSwedbankCrawler_extractHistoricAverageRates(t *testing.T) {
 	t.Parallel()
 
@@ -247,7 +273,7 @@ func TestParseSwedbankRate(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		want    float32
+		want    float64
 		wantErr bool
 	}{
 		{
@@ -300,7 +326,7 @@ func TestParseSwedbankRate(t *testing.T) {
 				t.Errorf("parseSwedbankRate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !tt.wantErr && got != tt.want {
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
 				t.Errorf("parseSwedbankRate() = %v, want %v", got, tt.want)
 			}
 		})
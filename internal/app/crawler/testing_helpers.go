@@ -119,7 +119,7 @@ func AssertListRateFields(t *testing.T, r model.InterestSet, cfg ListRateConfig,
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeListRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if cfg.ExpectChangeOn && r.ChangedOn == nil {
 		t.Error("ChangedOn is nil, want non-nil")
@@ -143,7 +143,7 @@ func AssertAverageRateFields(t *testing.T, r model.InterestSet, bank model.Bank,
 		t.Errorf("Type = %q, want %q", r.Type, model.TypeAverageRate)
 	}
 	if r.NominalRate <= 0 {
-		t.Errorf("NominalRate = %f, want positive value", r.NominalRate)
+		t.Errorf("NominalRate = %v, want positive value", r.NominalRate)
 	}
 	if r.AverageReferenceMonth == nil {
 		t.Error("AverageReferenceMonth is nil, want non-nil")
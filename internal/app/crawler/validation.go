@@ -0,0 +1,187 @@
+package crawler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+const (
+	// SeverityReject marks a violation that prevents the InterestSet from being stored.
+	SeverityReject Severity = "reject"
+	// SeverityFlag marks a violation that is reported, but the InterestSet is still stored.
+	SeverityFlag Severity = "flag"
+
+	RuleRateRange         Rule = "rateRange"
+	RuleFutureAvgMonth    Rule = "futureAverageReferenceMonth"
+	RuleChangedAfterCrawl Rule = "changedOnAfterLastCrawledAt"
+	RuleTypeFields        Rule = "typeSpecificFields"
+	RuleUnknownTerm       Rule = "unknownTerm"
+	RuleUnknownBank       Rule = "unknownBank"
+)
+
+type (
+	Severity string
+	Rule     string
+)
+
+// Violation describes a single failed plausibility check for an InterestSet.
+type Violation struct {
+	Set      model.InterestSet
+	Rule     Rule
+	Severity Severity
+	Message  string
+}
+
+// RateRange is the inclusive range of nominal rates considered plausible.
+type RateRange struct {
+	Min model.Rate
+	Max model.Rate
+}
+
+// Validator checks crawled InterestSets for plausibility before they are stored.
+type Validator struct {
+	profiles   map[model.Bank]model.BankProfile
+	rateRanges map[model.BankCategory]RateRange
+	now        func() time.Time
+}
+
+// DefaultRateRanges returns the plausible nominal rate range per lender category.
+func DefaultRateRanges() map[model.BankCategory]RateRange {
+	return map[model.BankCategory]RateRange{
+		model.BankCategoryStandard:  {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(15)},
+		model.BankCategorySpecialty: {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(25)},
+		model.BankCategoryBenchmark: {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(15)},
+	}
+}
+
+// HistoricRateRanges returns the plausible nominal rate range per lender category for average rates back to 1990,
+// which includes the rates above 15 % of the early 1990s.
+func HistoricRateRanges() map[model.BankCategory]RateRange {
+	return map[model.BankCategory]RateRange{
+		model.BankCategoryStandard:  {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(25)},
+		model.BankCategorySpecialty: {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(30)},
+		model.BankCategoryBenchmark: {Min: model.RateFromPercent(0.1), Max: model.RateFromPercent(25)},
+	}
+}
+
+func NewValidator(profiles []model.BankProfile, rateRanges map[model.BankCategory]RateRange, now func() time.Time) *Validator {
+	profileMap := make(map[model.Bank]model.BankProfile, len(profiles))
+	for _, p := range profiles {
+		profileMap[p.Bank] = p
+	}
+
+	return &Validator{
+		profiles:   profileMap,
+		rateRanges: rateRanges,
+		now:        now,
+	}
+}
+
+// Validate returns all violations for the given InterestSet. An empty result means the set is plausible.
+func (v *Validator) Validate(set model.InterestSet) []Violation {
+	var violations []Violation
+	add := func(rule Rule, severity Severity, format string, args ...any) {
+		violations = append(violations, Violation{
+			Set:      set,
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	profile, knownBank := v.profiles[set.Bank]
+	if !knownBank {
+		add(RuleUnknownBank, SeverityFlag, "bank %q is not in the bank catalogue", set.Bank)
+		profile = model.BankProfile{Bank: set.Bank, Category: model.BankCategoryStandard}
+	}
+
+	if rateRange, ok := v.rateRanges[profile.Category]; ok {
+		if set.NominalRate < rateRange.Min || set.NominalRate > rateRange.Max {
+			add(RuleRateRange, SeverityReject, "nominal rate %s outside plausible range [%s, %s] for %s lenders",
+				set.NominalRate, rateRange.Min, rateRange.Max, profile.Category)
+		}
+	}
+
+	if knownBank && !profile.HasTerm(set.Term) {
+		add(RuleUnknownTerm, SeverityFlag, "term %q is not known for bank %q", set.Term, set.Bank)
+	}
+
+	if set.ChangedOn != nil && set.ChangedOn.After(set.LastCrawledAt) {
+		add(RuleChangedAfterCrawl, SeverityFlag, "changedOn %s is after lastCrawledAt %s",
+			set.ChangedOn.Format(time.DateOnly), set.LastCrawledAt.Format(time.RFC3339))
+	}
+
+	violations = append(violations, v.validateTypeFields(set)...)
+
+	return violations
+}
+
+func (v *Validator) validateTypeFields(set model.InterestSet) []Violation {
+	reject := func(format string, args ...any) []Violation {
+		return []Violation{{Set: set, Rule: RuleTypeFields, Severity: SeverityReject, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	if set.MaxEnergyClass != "" && !set.MaxEnergyClass.Valid() {
+		return reject("maxEnergyClass %q is not an energy class from A to G", set.MaxEnergyClass)
+	}
+
+	switch set.Type {
+	case model.TypeListRate:
+		return nil
+
+	case model.TypeAverageRate:
+		if set.AverageReferenceMonth == nil {
+			return reject("averageReferenceMonth is missing for type %q", set.Type)
+		}
+		if isFutureMonth(*set.AverageReferenceMonth, v.now()) {
+			return []Violation{{
+				Set:      set,
+				Rule:     RuleFutureAvgMonth,
+				Severity: SeverityReject,
+				Message:  fmt.Sprintf("averageReferenceMonth %d-%02d is in the future", set.AverageReferenceMonth.Year, set.AverageReferenceMonth.Month),
+			}}
+		}
+		return nil
+
+	case model.TypeRatioDiscounted:
+		boundaries := set.RatioDiscountBoundaries
+		if boundaries == nil {
+			return reject("ratioDiscountBoundaries is missing for type %q", set.Type)
+		}
+		if boundaries.MinRatio < 0 || boundaries.MaxRatio > 1 || boundaries.MinRatio >= boundaries.MaxRatio {
+			return reject("ratioDiscountBoundaries [%.2f, %.2f] are not a valid ratio interval", boundaries.MinRatio, boundaries.MaxRatio)
+		}
+		return nil
+
+	case model.TypeUnionDiscounted:
+		if !set.UnionDiscount {
+			return reject("unionDiscount is not set for type %q", set.Type)
+		}
+		if len(set.UnionOrganisations) == 0 {
+			return reject("unionOrganisations is missing for type %q", set.Type)
+		}
+		return nil
+	}
+
+	return reject("unknown type %q", set.Type)
+}
+
+func isFutureMonth(month model.AvgMonth, now time.Time) bool {
+	nowYear := uint(now.Year()) //nolint:gosec // years are always positive
+	if month.Year != nowYear {
+		return month.Year > nowYear
+	}
+	return month.Month > now.Month()
+}
+
+// HasRejection reports whether any of the violations prevents storing the InterestSet.
+func HasRejection(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityReject {
+			return true
+		}
+	}
+	return false
+}
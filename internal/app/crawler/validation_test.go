@@ -0,0 +1,187 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func newTestValidator(now time.Time) *Validator {
+	profiles := []model.BankProfile{
+		{Bank: "Prime Bank", Category: model.BankCategoryStandard, Terms: []model.Term{model.Term3months, model.Term1year}},
+		{Bank: "Specialty Bank", Category: model.BankCategorySpecialty, Terms: []model.Term{model.Term3months}},
+	}
+	return NewValidator(profiles, DefaultRateRanges(), func() time.Time { return now })
+}
+
+func violationRules(violations []Violation) map[Rule]Severity {
+	rules := make(map[Rule]Severity, len(violations))
+	for _, v := range violations {
+		rules[v.Rule] = v.Severity
+	}
+	return rules
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	changedOn := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	futureChangedOn := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		set       model.InterestSet
+		wantRules map[Rule]Severity
+	}{
+		{
+			name:      "valid list rate",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.33), ChangedOn: &changedOn, LastCrawledAt: now},
+			wantRules: map[Rule]Severity{},
+		},
+		{
+			name:      "basis points instead of percent are rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(333), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleRateRange: SeverityReject},
+		},
+		{
+			name:      "zero rate is rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: 0, LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleRateRange: SeverityReject},
+		},
+		{
+			name:      "specialty lender allows higher rates",
+			set:       model.InterestSet{Bank: "Specialty Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(18.5), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{},
+		},
+		{
+			name:      "standard lender rejects specialty rate",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(18.5), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleRateRange: SeverityReject},
+		},
+		{
+			name:      "unknown term is flagged",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term7years, NominalRate: model.RateFromPercent(3.5), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleUnknownTerm: SeverityFlag},
+		},
+		{
+			name:      "unknown bank is flagged",
+			set:       model.InterestSet{Bank: "New Bank", Type: model.TypeListRate, Term: model.Term7years, NominalRate: model.RateFromPercent(3.5), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleUnknownBank: SeverityFlag},
+		},
+		{
+			name:      "changedOn after crawl time is flagged",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.5), ChangedOn: &futureChangedOn, LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleChangedAfterCrawl: SeverityFlag},
+		},
+		{
+			name: "average rate for current month is valid",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				AverageReferenceMonth: &model.AvgMonth{Month: time.December, Year: 2025},
+			},
+			wantRules: map[Rule]Severity{},
+		},
+		{
+			name: "average rate for future month is rejected",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				AverageReferenceMonth: &model.AvgMonth{Month: time.January, Year: 2026},
+			},
+			wantRules: map[Rule]Severity{RuleFutureAvgMonth: SeverityReject},
+		},
+		{
+			name:      "average rate without reference month is rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name:      "ratio discounted rate without boundaries is rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name: "ratio discounted rate with percent boundaries is rejected",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 60},
+			},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name: "valid ratio discounted rate",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+			},
+			wantRules: map[Rule]Severity{},
+		},
+		{
+			name:      "union discounted rate without union flag is rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: model.TypeUnionDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name: "union discounted rate without organisations is rejected",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeUnionDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				UnionDiscount: true,
+			},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name: "valid union discounted rate",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeUnionDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				UnionDiscount: true, UnionOrganisations: []string{"Saco", "TCO"},
+			},
+			wantRules: map[Rule]Severity{},
+		},
+		{
+			name: "invalid energy class is rejected",
+			set: model.InterestSet{
+				Bank: "Prime Bank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now,
+				MaxEnergyClass: "H",
+			},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+		{
+			name:      "unknown type is rejected",
+			set:       model.InterestSet{Bank: "Prime Bank", Type: "bogus", Term: model.Term1year, NominalRate: model.RateFromPercent(2.9), LastCrawledAt: now},
+			wantRules: map[Rule]Severity{RuleTypeFields: SeverityReject},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := newTestValidator(now)
+			got := violationRules(v.Validate(tt.set))
+
+			if len(got) != len(tt.wantRules) {
+				t.Errorf("Validate() returned rules %v, want %v", got, tt.wantRules)
+			}
+			for rule, severity := range tt.wantRules {
+				if got[rule] != severity {
+					t.Errorf("rule %q severity = %q, want %q", rule, got[rule], severity)
+				}
+			}
+		})
+	}
+}
+
+func TestBankProfiles_RateRangesCoverAllCategories(t *testing.T) {
+	t.Parallel()
+
+	ranges := DefaultRateRanges()
+	for _, p := range model.BankProfiles() {
+		if _, ok := ranges[p.Category]; !ok {
+			t.Errorf("no rate range for category %q of bank %q", p.Category, p.Bank)
+		}
+		if len(p.Terms) == 0 {
+			t.Errorf("bank %q has no known terms", p.Bank)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package archive keeps a copy of every document the crawlers fetch, so that a wrong rate can be traced back to what
+// the bank served and past documents can be parsed again by improved parsers.
+//
+// Documents are content-addressed: the bytes are stored once under their SHA-256 (see model.HashContent), and every
+// fetch adds a small record with the URL and time pointing to them. Retention removes old records and then the
+// documents no record points to anymore.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+const (
+	documentPrefix = "documents/"
+	recordPrefix   = "fetches/"
+
+	// recordTimeFormat sorts lexically in time order, so that listing the records lists them chronologically.
+	recordTimeFormat = "20060102T150405.000000000Z"
+)
+
+// Record is a single fetch of a document.
+type Record struct {
+	URL       string    `json:"url"`
+	Hash      string    `json:"hash"`
+	Size      int       `json:"size"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// Ref returns the reference an InterestSet parsed from the fetched document carries.
+func (r Record) Ref() model.SourceRef {
+	return model.SourceRef{URL: r.URL, Hash: r.Hash}
+}
+
+// RetentionPolicy decides which fetch records Prune keeps.
+type RetentionPolicy struct {
+	// MaxAge is how long records are kept. Zero keeps records forever.
+	MaxAge time.Duration
+	// KeepLatest is the number of newest records per URL kept regardless of their age, so that the last copy of a
+	// source that stopped being crawled is not lost.
+	KeepLatest int
+}
+
+// PruneResult counts what Prune removed.
+type PruneResult struct {
+	Records   int
+	Documents int
+}
+
+// Archive stores fetched documents in a BlobStore.
+type Archive struct {
+	blobs BlobStore
+	now   func() time.Time
+}
+
+func New(blobs BlobStore, now func() time.Time) *Archive {
+	return &Archive{blobs: blobs, now: now}
+}
+
+// Put archives a document fetched from url and records the fetch.
+func (a *Archive) Put(url string, content []byte) (Record, error) {
+	record := Record{
+		URL:       url,
+		Hash:      model.HashContent(content),
+		Size:      len(content),
+		FetchedAt: a.now().UTC(),
+	}
+
+	if err := a.blobs.Put(documentPrefix+record.Hash, content); err != nil {
+		return Record{}, fmt.Errorf("failed to archive document from %s: %w", url, err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to encode fetch record: %w", err)
+	}
+	if err := a.blobs.Put(recordKey(record), data); err != nil {
+		return Record{}, fmt.Errorf("failed to record fetch of %s: %w", url, err)
+	}
+
+	return record, nil
+}
+
+// Get returns the archived document with the given hash, or ErrNotFound.
+func (a *Archive) Get(hash string) ([]byte, error) {
+	content, err := a.blobs.Get(documentPrefix + hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document %s: %w", hash, err)
+	}
+	return content, nil
+}
+
+// Records returns all fetch records, oldest first.
+func (a *Archive) Records() ([]Record, error) {
+	keys, err := a.blobs.List(recordPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fetch records: %w", err)
+	}
+
+	records := make([]Record, 0, len(keys))
+	for _, key := range keys {
+		data, err := a.blobs.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fetch record: %w", err)
+		}
+
+		var record Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("failed to decode fetch record %s: %w", key, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// Prune removes the fetch records the policy does not keep, then the documents no remaining record points to. It must
+// not run concurrently with Put, which could otherwise lose a document stored before its record.
+func (a *Archive) Prune(policy RetentionPolicy) (PruneResult, error) {
+	records, err := a.Records()
+	if err != nil {
+		return PruneResult{}, err
+	}
+
+	var result PruneResult
+	kept, expired := partition(records, policy, a.now())
+	for _, record := range expired {
+		if err := a.blobs.Delete(recordKey(record)); err != nil {
+			return result, fmt.Errorf("failed to delete fetch record: %w", err)
+		}
+		result.Records++
+	}
+
+	referenced := map[string]bool{}
+	for _, record := range kept {
+		referenced[record.Hash] = true
+	}
+
+	keys, err := a.blobs.List(documentPrefix)
+	if err != nil {
+		return result, fmt.Errorf("failed to list documents: %w", err)
+	}
+	for _, key := range keys {
+		if referenced[strings.TrimPrefix(key, documentPrefix)] {
+			continue
+		}
+		if err := a.blobs.Delete(key); err != nil {
+			return result, fmt.Errorf("failed to delete document: %w", err)
+		}
+		result.Documents++
+	}
+
+	return result, nil
+}
+
+// partition splits records, oldest first, into the ones the policy keeps and the expired ones.
+func partition(records []Record, policy RetentionPolicy, now time.Time) (kept, expired []Record) {
+	newer := map[string]int{} // number of newer records per URL
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if policy.MaxAge == 0 || newer[record.URL] < policy.KeepLatest || now.Sub(record.FetchedAt) <= policy.MaxAge {
+			kept = append(kept, record)
+		} else {
+			expired = append(expired, record)
+		}
+		newer[record.URL]++
+	}
+	return kept, expired
+}
+
+func recordKey(record Record) string {
+	return fmt.Sprintf("%s%s-%s.json", recordPrefix, record.FetchedAt.UTC().Format(recordTimeFormat),
+		model.HashContent([]byte(record.URL))[:12])
+}
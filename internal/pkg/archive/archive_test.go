@@ -0,0 +1,135 @@
+package archive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// clock returns a time function that starts at start and is moved with the returned advance function.
+func clock(start time.Time) (now func() time.Time, advance func(time.Duration)) {
+	current := start
+	return func() time.Time { return current }, func(d time.Duration) { current = current.Add(d) }
+}
+
+func TestArchive_PutAndGet(t *testing.T) {
+	t.Parallel()
+
+	now, advance := clock(time.Date(2025, 11, 1, 8, 0, 0, 0, time.UTC))
+	blobs := NewMemoryStore()
+	archive := New(blobs, now)
+
+	first, err := archive.Put("https://bank.example/rates", []byte("<table>3,5</table>"))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	advance(time.Hour)
+	second, err := archive.Put("https://bank.example/rates", []byte("<table>3,5</table>"))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if first.Hash != model.HashContent([]byte("<table>3,5</table>")) || first.Hash != second.Hash {
+		t.Errorf("hashes = %q, %q, want the SHA-256 of the content twice", first.Hash, second.Hash)
+	}
+	if want := (model.SourceRef{URL: "https://bank.example/rates", Hash: first.Hash}); first.Ref() != want {
+		t.Errorf("Ref() = %+v, want %+v", first.Ref(), want)
+	}
+
+	// Identical content is stored once.
+	documents, _ := blobs.List(documentPrefix)
+	if len(documents) != 1 {
+		t.Errorf("stored %d documents, want 1", len(documents))
+	}
+
+	content, err := archive.Get(first.Hash)
+	if err != nil || string(content) != "<table>3,5</table>" {
+		t.Errorf("Get() = %q, %v, want the archived content", content, err)
+	}
+	if _, err := archive.Get("unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of unknown hash error = %v, want ErrNotFound", err)
+	}
+
+	records, err := archive.Records()
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	if want := []Record{first, second}; !reflect.DeepEqual(records, want) {
+		t.Errorf("Records() = %+v, want %+v", records, want)
+	}
+}
+
+func TestArchive_Prune(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		policy        RetentionPolicy
+		wantResult    PruneResult
+		wantDocuments []string
+	}{
+		{
+			name:          "no max age keeps everything",
+			policy:        RetentionPolicy{MaxAge: 0, KeepLatest: 0},
+			wantResult:    PruneResult{Records: 0, Documents: 0},
+			wantDocuments: []string{"old list", "new list", "avg"},
+		},
+		{
+			name:          "old records and their documents are removed",
+			policy:        RetentionPolicy{MaxAge: 30 * 24 * time.Hour, KeepLatest: 0},
+			wantResult:    PruneResult{Records: 2, Documents: 2},
+			wantDocuments: []string{"new list"},
+		},
+		{
+			name:          "latest record per URL is kept regardless of age",
+			policy:        RetentionPolicy{MaxAge: 30 * 24 * time.Hour, KeepLatest: 1},
+			wantResult:    PruneResult{Records: 1, Documents: 1},
+			wantDocuments: []string{"new list", "avg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			now, advance := clock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			archive := New(NewMemoryStore(), now)
+			for _, put := range []struct{ url, content string }{
+				{"https://bank.example/list", "old list"},
+				{"https://bank.example/avg", "avg"},
+			} {
+				if _, err := archive.Put(put.url, []byte(put.content)); err != nil {
+					t.Fatalf("Put() error = %v", err)
+				}
+			}
+			advance(60 * 24 * time.Hour)
+			if _, err := archive.Put("https://bank.example/list", []byte("new list")); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			result, err := archive.Prune(tt.policy)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if result != tt.wantResult {
+				t.Errorf("Prune() = %+v, want %+v", result, tt.wantResult)
+			}
+
+			records, err := archive.Records()
+			if err != nil {
+				t.Fatalf("Records() error = %v", err)
+			}
+			if len(records) != len(tt.wantDocuments) {
+				t.Errorf("Records() returned %d records, want %d", len(records), len(tt.wantDocuments))
+			}
+			for _, content := range tt.wantDocuments {
+				if _, err := archive.Get(model.HashContent([]byte(content))); err != nil {
+					t.Errorf("Get(%q) error = %v, want document kept", content, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a blob or document is not in the archive.
+var ErrNotFound = errors.New("not found")
+
+// BlobStore is a flat key-value store for archived documents. Keys are slash-separated paths like
+// "documents/<hash>". An S3-compatible bucket can implement it with one object per key.
+type BlobStore interface {
+	// Put stores data under key, replacing an existing blob.
+	Put(key string, data []byte) error
+	// Get returns the blob under key, or ErrNotFound.
+	Get(key string) ([]byte, error)
+	// Delete removes the blob under key. Deleting a missing blob is not an error.
+	Delete(key string) error
+	// List returns the sorted keys starting with prefix.
+	List(prefix string) ([]string, error)
+}
+
+// Compile-time interface compliance checks.
+var (
+	_ BlobStore = &DirStore{}
+	_ BlobStore = &MemoryStore{}
+)
+
+// DirStore implements BlobStore with one file per key below a local directory.
+type DirStore struct {
+	root string
+}
+
+// NewDirStore returns a store that keeps its blobs below root, creating the directory if needed.
+func NewDirStore(root string) (*DirStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return &DirStore{root: root}, nil
+}
+
+func (s *DirStore) Put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	// Write to a temporary file first, so that readers never see a partially written blob.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", key, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *DirStore) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 // path is validated to stay below the archive root
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+func (s *DirStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *DirStore) List(prefix string) ([]string, error) {
+	keys := []string{}
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archive directory: %w", err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it.
+func (s *DirStore) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// MemoryStore implements BlobStore in memory. It stands in for a remote blob store in tests and short-lived runs and
+// is safe for concurrent use.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryStore) Put(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = append([]byte{}, data...)
+	return nil
+}
+
+func (s *MemoryStore) Get(key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("blob %s: %w", key, ErrNotFound)
+	}
+	return append([]byte{}, data...), nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *MemoryStore) List(prefix string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := []string{}
+	for key := range s.blobs {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
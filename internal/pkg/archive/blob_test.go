@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBlobStores(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) BlobStore{
+		"dir": func(t *testing.T) BlobStore {
+			t.Helper()
+			store, err := NewDirStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewDirStore() error = %v", err)
+			}
+			return store
+		},
+		"memory": func(_ *testing.T) BlobStore { return NewMemoryStore() },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			store := newStore(t)
+
+			for key, data := range map[string]string{"documents/b": "second", "documents/a": "first", "fetches/x.json": "{}"} {
+				if err := store.Put(key, []byte(data)); err != nil {
+					t.Fatalf("Put(%q) error = %v", key, err)
+				}
+			}
+			if err := store.Put("documents/a", []byte("replaced")); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+
+			got, err := store.Get("documents/a")
+			if err != nil || string(got) != "replaced" {
+				t.Errorf("Get() = %q, %v, want %q", got, err, "replaced")
+			}
+
+			keys, err := store.List("documents/")
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if want := []string{"documents/a", "documents/b"}; !reflect.DeepEqual(keys, want) {
+				t.Errorf("List() = %v, want %v", keys, want)
+			}
+
+			if err := store.Delete("documents/a"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if err := store.Delete("documents/a"); err != nil {
+				t.Errorf("Delete() of missing blob error = %v, want nil", err)
+			}
+			if _, err := store.Get("documents/a"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() of deleted blob error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestDirStore_RejectsKeysOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewDirStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirStore() error = %v", err)
+	}
+
+	for _, key := range []string{"", "../escape", "/etc/passwd", "documents/../../escape"} {
+		if err := store.Put(key, []byte("data")); err == nil {
+			t.Errorf("Put(%q) error = nil, want error", key)
+		}
+	}
+}
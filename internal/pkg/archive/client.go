@@ -0,0 +1,57 @@
+package archive
+
+import (
+	"github.com/yama6a/bolan-compare/internal/pkg/http"
+	"go.uber.org/zap"
+)
+
+// Compile-time interface compliance check.
+var _ http.Client = &Client{}
+
+// Client wraps an http.Client and archives every document it fetches successfully. A document that cannot be
+// archived is logged and still returned, so that the archive never breaks a crawl.
+type Client struct {
+	client  http.Client
+	archive *Archive
+	logger  *zap.Logger
+}
+
+func NewClient(client http.Client, archive *Archive, logger *zap.Logger) *Client {
+	return &Client{
+		client:  client,
+		archive: archive,
+		logger:  logger,
+	}
+}
+
+// Fetch retrieves content from a URL like the wrapped client and archives it.
+func (c *Client) Fetch(url string, headers map[string]string) (string, error) {
+	content, err := c.client.Fetch(url, headers)
+	if err != nil {
+		return "", err //nolint:wrapcheck // the wrapped client's error is passed on unchanged
+	}
+
+	c.put(url, []byte(content))
+	return content, nil
+}
+
+// FetchRaw retrieves raw binary content from a URL like the wrapped client and archives it.
+func (c *Client) FetchRaw(url string, headers map[string]string) ([]byte, error) {
+	content, err := c.client.FetchRaw(url, headers)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the wrapped client's error is passed on unchanged
+	}
+
+	c.put(url, content)
+	return content, nil
+}
+
+func (c *Client) put(url string, content []byte) {
+	record, err := c.archive.Put(url, content)
+	if err != nil {
+		c.logger.Error("failed to archive fetched document", zap.String("url", url), zap.Error(err))
+		return
+	}
+	c.logger.Debug("archived fetched document", zap.String("url", url), zap.String("hash", record.Hash),
+		zap.Int("size", record.Size))
+}
@@ -0,0 +1,55 @@
+package archive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/http/httpmock"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	errFetch := errors.New("connection refused")
+	mock := &httpmock.ClientMock{
+		FetchFunc: func(url string, _ map[string]string) (string, error) {
+			if url == "https://bank.example/down" {
+				return "", errFetch
+			}
+			return "<html>rates</html>", nil
+		},
+		FetchRawFunc: func(_ string, _ map[string]string) ([]byte, error) {
+			return []byte("%PDF-1.7"), nil
+		},
+	}
+	archive := New(NewMemoryStore(), func() time.Time { return time.Date(2025, 11, 1, 8, 0, 0, 0, time.UTC) })
+	client := NewClient(mock, archive, zap.NewNop())
+
+	html, err := client.Fetch("https://bank.example/rates", nil)
+	if err != nil || html != "<html>rates</html>" {
+		t.Errorf("Fetch() = %q, %v, want the fetched content", html, err)
+	}
+	pdf, err := client.FetchRaw("https://bank.example/avg.pdf", nil)
+	if err != nil || string(pdf) != "%PDF-1.7" {
+		t.Errorf("FetchRaw() = %q, %v, want the fetched content", pdf, err)
+	}
+	if _, err := client.Fetch("https://bank.example/down", nil); !errors.Is(err, errFetch) {
+		t.Errorf("Fetch() error = %v, want %v", err, errFetch)
+	}
+
+	records, err := archive.Records()
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Records() returned %d records, want 2 (failed fetches are not archived)", len(records))
+	}
+	for _, content := range []string{"<html>rates</html>", "%PDF-1.7"} {
+		if _, err := archive.Get(model.HashContent([]byte(content))); err != nil {
+			t.Errorf("Get(%q) error = %v, want archived document", content, err)
+		}
+	}
+}
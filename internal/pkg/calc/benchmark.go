@@ -0,0 +1,117 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// BenchmarkRate is one bank's average rate of a month and how far it lies from the market average.
+type BenchmarkRate struct {
+	Bank        model.Bank `json:"bank"`
+	Lender      model.Bank `json:"lender,omitempty"`  // only if Bank distributes another lender's loan
+	Segment     string     `json:"segment,omitempty"` // only if the bank publishes several average rate series
+	AverageRate model.Rate `json:"averageRate"`
+	DiffBps     float64    `json:"diffBps"` // positive if the bank was more expensive than the market
+}
+
+// BenchmarkMonth compares the average rates all banks charged in one month with the market average.
+type BenchmarkMonth struct {
+	Month  model.AvgMonth  `json:"month"`
+	Market model.Rate      `json:"market"`
+	Banks  []BenchmarkRate `json:"banks"` // cheapest first
+}
+
+// BenchmarkReport compares every bank's average rates for one term with the market average, latest month first.
+type BenchmarkReport struct {
+	Term model.Term `json:"term"`
+	// MarketTerm is the fixation period of the market average the term falls in. The market average is published for
+	// periods like "over 1 year and up to 3 years", stored as their upper bound, so 2y is compared with 3y.
+	MarketTerm model.Term       `json:"marketTerm"`
+	Months     []BenchmarkMonth `json:"months"`
+}
+
+// CompareWithBenchmark compares the average rates of all banks for the term with the average rate of model.BenchmarkBank
+// for the same month. Months without a market average are left out. It returns ErrNoRate if there is no market average
+// for the term.
+func CompareWithBenchmark(sets []model.InterestSet, term model.Term) (BenchmarkReport, error) {
+	if _, err := termMonths(term); err != nil {
+		return BenchmarkReport{}, err
+	}
+
+	marketTerm, ok := benchmarkTerm(sets, term)
+	if !ok {
+		return BenchmarkReport{}, fmt.Errorf("%w: no market average for term %s", ErrNoRate, term)
+	}
+
+	months := map[model.AvgMonth]*BenchmarkMonth{}
+	for _, set := range sets {
+		if isAverageRate(set, model.BenchmarkBank, marketTerm) {
+			months[*set.AverageReferenceMonth] = &BenchmarkMonth{
+				Month:  *set.AverageReferenceMonth,
+				Market: set.NominalRate,
+				Banks:  []BenchmarkRate{},
+			}
+		}
+	}
+	for _, set := range sets {
+		if set.Bank == model.BenchmarkBank || !isAverageRate(set, set.Bank, term) {
+			continue
+		}
+		month, ok := months[*set.AverageReferenceMonth]
+		if !ok {
+			continue
+		}
+		rate := BenchmarkRate{
+			Bank:        set.Bank,
+			Lender:      set.Lender,
+			AverageRate: set.NominalRate,
+			DiffBps:     (set.NominalRate - month.Market).BasisPoints(),
+		}
+		if set.AverageSegment != nil {
+			rate.Segment = set.AverageSegment.Label
+		}
+		month.Banks = append(month.Banks, rate)
+	}
+
+	report := BenchmarkReport{Term: term, MarketTerm: marketTerm, Months: make([]BenchmarkMonth, 0, len(months))}
+	for _, month := range months {
+		sort.SliceStable(month.Banks, func(i, j int) bool {
+			a, b := month.Banks[i], month.Banks[j]
+			if a.AverageRate != b.AverageRate {
+				return a.AverageRate < b.AverageRate
+			}
+			if a.Bank != b.Bank {
+				return a.Bank < b.Bank
+			}
+			if a.Lender != b.Lender {
+				return a.Lender < b.Lender
+			}
+			return a.Segment < b.Segment
+		})
+		report.Months = append(report.Months, *month)
+	}
+	sort.Slice(report.Months, func(i, j int) bool {
+		return laterMonth(report.Months[i].Month, report.Months[j].Month)
+	})
+	return report, nil
+}
+
+// benchmarkTerm returns the shortest term of the market average that is at least as long as term.
+func benchmarkTerm(sets []model.InterestSet, term model.Term) (model.Term, bool) {
+	var result model.Term
+	for _, set := range sets {
+		if !isAverageRate(set, model.BenchmarkBank, set.Term) || set.Term.Compare(term) < 0 {
+			continue
+		}
+		if result.IsZero() || set.Term.Compare(result) < 0 {
+			result = set.Term
+		}
+	}
+	return result, !result.IsZero()
+}
+
+func isAverageRate(set model.InterestSet, bank model.Bank, term model.Term) bool {
+	return set.Type == model.TypeAverageRate && set.Bank == bank && set.Term == term && set.AverageReferenceMonth != nil
+}
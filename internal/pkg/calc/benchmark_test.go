@@ -0,0 +1,97 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestCompareWithBenchmark(t *testing.T) {
+	t.Parallel()
+
+	august := model.AvgMonth{Year: 2025, Month: time.August}
+	september := model.AvgMonth{Year: 2025, Month: time.September}
+	october := model.AvgMonth{Year: 2025, Month: time.October}
+	avg := func(bank, lender model.Bank, term model.Term, month model.AvgMonth, percent float64) model.InterestSet {
+		return model.InterestSet{
+			Bank: bank, Lender: lender, Type: model.TypeAverageRate, Term: term,
+			NominalRate: model.RateFromPercent(percent), AverageReferenceMonth: &month,
+		}
+	}
+	sets := append(testSets(),
+		avg(model.BenchmarkBank, "", model.Term3months, august, 2.9),
+		avg(model.BenchmarkBank, "", model.Term3months, september, 2.83),
+		avg(model.BenchmarkBank, "", model.Term3years, september, 3.1),
+		avg("SEB", "", model.Term3months, august, 2.95),
+		avg("SEB", "", model.Term3months, september, 2.8),
+		avg("SEB", "", model.Term3months, october, 2.7), // no market average yet
+		avg("SEB", "", model.Term2years, september, 3.0),
+		avg("Nordea", "", model.Term3months, september, 2.88),
+		avg("Avanza", "Stabelo", model.Term3months, september, 2.8),
+	)
+
+	tests := []struct {
+		name           string
+		term           model.Term
+		wantMarketTerm model.Term
+		want           []string // month: market, bank/lender=diffBps in order
+		wantErr        error
+	}{
+		{
+			name:           "latest month first, cheapest bank first",
+			term:           model.Term3months,
+			wantMarketTerm: model.Term3months,
+			want: []string{
+				"2025-09: 2.83 Avanza/Stabelo=-3 SEB/=-3 Nordea/=5",
+				"2025-08: 2.9 SEB/=5",
+			},
+		},
+		{
+			name:           "term within a market period",
+			term:           model.Term2years,
+			wantMarketTerm: model.Term3years,
+			want:           []string{"2025-09: 3.1 SEB/=-10"},
+		},
+		{
+			name:           "variable rate compared with 3 months",
+			term:           model.TermVariable,
+			wantMarketTerm: model.Term3months,
+			want:           []string{"2025-09: 2.83", "2025-08: 2.9"},
+		},
+		{name: "no market average", term: model.Term5years, wantErr: ErrNoRate},
+		{name: "unknown term", term: model.Term{}, wantErr: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := CompareWithBenchmark(sets, tt.term)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompareWithBenchmark() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if report.Term != tt.term || report.MarketTerm != tt.wantMarketTerm {
+				t.Errorf("terms = %s, %s; want %s, %s", report.Term, report.MarketTerm, tt.term, tt.wantMarketTerm)
+			}
+			var got []string
+			for _, month := range report.Months {
+				line := fmt.Sprintf("%d-%02d: %s", month.Month.Year, month.Month.Month, month.Market)
+				for _, rate := range month.Banks {
+					line += fmt.Sprintf(" %s/%s=%.0f", rate.Bank, rate.Lender, rate.DiffBps)
+				}
+				got = append(got, line)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompareWithBenchmark() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
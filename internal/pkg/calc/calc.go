@@ -0,0 +1,260 @@
+// Package calc computes the cost of a mortgage from stored interest rates.
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrNoRate         = errors.New("no applicable rate")
+)
+
+// AmortizationTier requires AnnualRate amortization (as a fraction of the loan) when the loan-to-value ratio is above
+// MinLoanToValue.
+type AmortizationTier struct {
+	MinLoanToValue float64
+	AnnualRate     float64
+}
+
+// Rules holds the regulatory and tax parameters the calculation is based on.
+type Rules struct {
+	MaxLoanToValue float64
+	// AmortizationTiers must be sorted by MinLoanToValue in descending order.
+	AmortizationTiers []AmortizationTier
+	// DebtToIncomeLimit is the debt-to-income ratio above which DebtToIncomeExtraRate is amortized additionally.
+	DebtToIncomeLimit     float64
+	DebtToIncomeExtraRate float64
+	// InterestDeductionRate applies to yearly interest up to ReducedDeductionLimit, ReducedDeductionRate above it.
+	InterestDeductionRate float64
+	ReducedDeductionRate  float64
+	ReducedDeductionLimit float64
+}
+
+// DefaultRules returns the Swedish amortization requirements (amorteringskrav and skärpt amorteringskrav), the
+// bolånetak and the ränteavdrag for private individuals.
+func DefaultRules() Rules {
+	return Rules{
+		MaxLoanToValue: 0.85,
+		AmortizationTiers: []AmortizationTier{
+			{MinLoanToValue: 0.70, AnnualRate: 0.02},
+			{MinLoanToValue: 0.50, AnnualRate: 0.01},
+		},
+		DebtToIncomeLimit:     4.5,
+		DebtToIncomeExtraRate: 0.01,
+		InterestDeductionRate: 0.30,
+		ReducedDeductionRate:  0.21,
+		ReducedDeductionLimit: 100_000,
+	}
+}
+
+// Borrower describes the loan and the borrower independently of the bank.
+type Borrower struct {
+	LoanAmount    float64           // SEK
+	PropertyValue float64           // SEK
+	AnnualIncome  float64           // gross yearly household income in SEK, 0 if unknown
+	Union         string            // union organisation the borrower is a member of, empty if none
+	EnergyClass   model.EnergyClass // energy class of the property, empty if unknown
+}
+
+// LoanToValue returns the loan-to-value ratio of the borrower.
+func (b Borrower) LoanToValue() float64 {
+	return b.LoanAmount / b.PropertyValue
+}
+
+// Request describes the loan to calculate the cost for.
+type Request struct {
+	Bank model.Bank
+	Term model.Term
+	Borrower
+}
+
+// Result is the cost breakdown of a loan at one bank over its binding period.
+type Result struct {
+	Bank         model.Bank        `json:"bank"`
+	Term         model.Term        `json:"term"`
+	AppliedSet   model.InterestSet `json:"appliedSet"`
+	NominalRate  model.Rate        `json:"nominalRate"`
+	LoanToValue  float64           `json:"loanToValue"`
+	DebtToIncome *float64          `json:"debtToIncome"`
+
+	// AmortizationRate is the required yearly amortization as a fraction of the loan amount.
+	AmortizationRate    float64 `json:"amortizationRate"`
+	MonthlyInterest     float64 `json:"monthlyInterest"`
+	MonthlyAmortization float64 `json:"monthlyAmortization"`
+	MonthlyTaxDeduction float64 `json:"monthlyTaxDeduction"`
+	// MonthlyPayment is interest plus amortization in the first month, before the tax deduction.
+	MonthlyPayment float64 `json:"monthlyPayment"`
+
+	BindingPeriodMonths int     `json:"bindingPeriodMonths"`
+	TotalInterest       float64 `json:"totalInterest"`
+	TotalAmortization   float64 `json:"totalAmortization"`
+	TotalTaxDeduction   float64 `json:"totalTaxDeduction"`
+	// TotalCost is the interest paid over the binding period minus the tax deduction. Amortization is not a cost.
+	TotalCost float64 `json:"totalCost"`
+}
+
+// Calculate picks the applicable InterestSet for the request from sets and computes the cost of the loan.
+func Calculate(sets []model.InterestSet, req Request, rules Rules) (Result, error) {
+	if err := validateBorrower(req.Borrower, rules); err != nil {
+		return Result{}, err
+	}
+
+	months, err := termMonths(req.Term)
+	if err != nil {
+		return Result{}, err
+	}
+
+	set, err := SelectInterestSet(sets, req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ltv := req.LoanToValue()
+
+	result := Result{
+		Bank:                req.Bank,
+		Term:                req.Term,
+		AppliedSet:          set,
+		NominalRate:         set.NominalRate,
+		LoanToValue:         ltv,
+		BindingPeriodMonths: months,
+	}
+
+	result.AmortizationRate = amortizationRate(ltv, rules)
+	if req.AnnualIncome > 0 {
+		dti := req.LoanAmount / req.AnnualIncome
+		result.DebtToIncome = &dti
+		if dti > rules.DebtToIncomeLimit {
+			result.AmortizationRate += rules.DebtToIncomeExtraRate
+		}
+	}
+
+	// Amortization is a fixed amount based on the original loan, so interest decreases month by month.
+	monthlyRate := set.NominalRate.Percent() / 100 / 12
+	result.MonthlyAmortization = req.LoanAmount * result.AmortizationRate / 12
+	balance := req.LoanAmount
+	yearInterest := 0.0
+	for month := 1; month <= months; month++ {
+		interest := balance * monthlyRate
+		if month == 1 {
+			result.MonthlyInterest = interest
+			result.MonthlyTaxDeduction = taxDeduction(interest*12, rules) / 12
+		}
+
+		amortization := math.Min(result.MonthlyAmortization, balance)
+		balance -= amortization
+		result.TotalInterest += interest
+		result.TotalAmortization += amortization
+
+		yearInterest += interest
+		if month%12 == 0 || month == months {
+			result.TotalTaxDeduction += taxDeduction(yearInterest, rules)
+			yearInterest = 0
+		}
+	}
+
+	result.MonthlyPayment = result.MonthlyInterest + result.MonthlyAmortization
+	result.TotalCost = result.TotalInterest - result.TotalTaxDeduction
+
+	return result, nil
+}
+
+func validateBorrower(b Borrower, rules Rules) error {
+	if b.LoanAmount <= 0 || b.PropertyValue <= 0 {
+		return fmt.Errorf("%w: loan amount and property value must be positive", ErrInvalidRequest)
+	}
+	if b.AnnualIncome < 0 {
+		return fmt.Errorf("%w: annual income must not be negative", ErrInvalidRequest)
+	}
+	if b.EnergyClass != "" && !b.EnergyClass.Valid() {
+		return fmt.Errorf("%w: energy class %q must be one of A to G", ErrInvalidRequest, b.EnergyClass)
+	}
+	if ltv := b.LoanToValue(); ltv > rules.MaxLoanToValue {
+		return fmt.Errorf("%w: loan-to-value %.2f exceeds the maximum of %.2f", ErrInvalidRequest, ltv, rules.MaxLoanToValue)
+	}
+	return nil
+}
+
+// SelectInterestSet returns the lowest rate of the bank for the term that applies to the borrower. List rates always
+// apply, ratio and amount discounted rates only if the loan falls within their boundaries, union discounted rates
+// only for members of one of the set's organisations and green loan discounts only for properties with a good enough
+// energy class.
+func SelectInterestSet(sets []model.InterestSet, req Request) (model.InterestSet, error) {
+	var best *model.InterestSet
+	for i, set := range sets {
+		if set.Bank != req.Bank || set.Term != req.Term || !applies(set, req.Borrower) {
+			continue
+		}
+		if best == nil || set.NominalRate < best.NominalRate {
+			best = &sets[i]
+		}
+	}
+
+	if best == nil {
+		return model.InterestSet{}, fmt.Errorf("%w: bank %q has no rate for term %q", ErrNoRate, req.Bank, req.Term)
+	}
+	return *best, nil
+}
+
+func applies(set model.InterestSet, borrower Borrower) bool {
+	if b := set.LoanAmountBoundaries; b != nil {
+		if borrower.LoanAmount < float64(b.MinAmount) || (b.MaxAmount > 0 && borrower.LoanAmount > float64(b.MaxAmount)) {
+			return false
+		}
+	}
+	if set.MaxEnergyClass != "" && !borrower.EnergyClass.Qualifies(set.MaxEnergyClass) {
+		return false
+	}
+
+	switch set.Type {
+	case model.TypeListRate:
+		return true
+	case model.TypeRatioDiscounted:
+		b := set.RatioDiscountBoundaries
+		ltv := borrower.LoanToValue()
+		return b != nil && ltv >= float64(b.MinRatio) && ltv <= float64(b.MaxRatio)
+	case model.TypeUnionDiscounted:
+		return set.UnionDiscount && borrower.Union != "" && slices.ContainsFunc(set.UnionOrganisations, func(org string) bool {
+			return strings.EqualFold(org, borrower.Union)
+		})
+	case model.TypeAverageRate:
+		return false
+	}
+	return false
+}
+
+func amortizationRate(ltv float64, rules Rules) float64 {
+	for _, tier := range rules.AmortizationTiers {
+		if ltv > tier.MinLoanToValue {
+			return tier.AnnualRate
+		}
+	}
+	return 0
+}
+
+// taxDeduction returns the ränteavdrag for the interest paid within one year.
+func taxDeduction(yearInterest float64, rules Rules) float64 {
+	if yearInterest <= rules.ReducedDeductionLimit {
+		return yearInterest * rules.InterestDeductionRate
+	}
+	return rules.ReducedDeductionLimit*rules.InterestDeductionRate +
+		(yearInterest-rules.ReducedDeductionLimit)*rules.ReducedDeductionRate
+}
+
+func termMonths(term model.Term) (int, error) {
+	switch {
+	case term.Variable:
+		// A variable rate can change at any time, in practice banks review it every 3 months like the 3 months rate.
+		return 3, nil
+	case term.Months > 0:
+		return term.Months, nil
+	}
+	return 0, fmt.Errorf("%w: unknown term %q", ErrInvalidRequest, term)
+}
@@ -0,0 +1,257 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func testSets() []model.InterestSet {
+	return []model.InterestSet{
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.6)},
+		{
+			Bank: "SEB", Type: model.TypeRatioDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.5),
+			RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+		},
+		{
+			Bank: "SEB", Type: model.TypeRatioDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.8),
+			RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0.6, MaxRatio: 0.75},
+		},
+		{Bank: "SEB", Type: model.TypeUnionDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.7), UnionDiscount: true, UnionOrganisations: []string{"Saco", "TCO"}},
+		{
+			Bank: "SEB", Type: model.TypeRatioDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(3.3),
+			RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+			LoanAmountBoundaries:    &model.LoanAmountBoundary{MinAmount: 5_000_000},
+		},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.6), MaxEnergyClass: model.EnergyClassB},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.9)},
+	}
+}
+
+func TestSelectInterestSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		bank        model.Bank
+		term        model.Term
+		ltv         float64
+		loanAmount  float64
+		union       string
+		energyClass model.EnergyClass
+		wantRate    float64
+		wantType    model.Type
+		wantErr     error
+	}{
+		{name: "list rate above all tiers", bank: "SEB", term: model.Term3months, ltv: 0.8, loanAmount: 2_000_000, wantRate: 4.0, wantType: model.TypeListRate},
+		{name: "union discount for members", bank: "SEB", term: model.Term3months, ltv: 0.8, loanAmount: 2_000_000, union: "tco", wantRate: 3.7, wantType: model.TypeUnionDiscounted},
+		{name: "no union discount for other unions", bank: "SEB", term: model.Term3months, ltv: 0.8, loanAmount: 2_000_000, union: "LO", wantRate: 4.0, wantType: model.TypeListRate},
+		{name: "middle LTV tier", bank: "SEB", term: model.Term3months, ltv: 0.7, loanAmount: 2_000_000, wantRate: 3.8, wantType: model.TypeRatioDiscounted},
+		{name: "lowest LTV tier", bank: "SEB", term: model.Term3months, ltv: 0.5, loanAmount: 2_000_000, wantRate: 3.5, wantType: model.TypeRatioDiscounted},
+		{name: "amount discount for large loans", bank: "SEB", term: model.Term3months, ltv: 0.5, loanAmount: 6_000_000, wantRate: 3.3, wantType: model.TypeRatioDiscounted},
+		{name: "green discount for good energy class", bank: "SEB", term: model.Term3months, ltv: 0.8, loanAmount: 2_000_000, energyClass: model.EnergyClassA, wantRate: 3.6, wantType: model.TypeListRate},
+		{name: "no green discount for poor energy class", bank: "SEB", term: model.Term3months, ltv: 0.8, loanAmount: 2_000_000, energyClass: model.EnergyClassD, wantRate: 4.0, wantType: model.TypeListRate},
+		{name: "only list rate for term", bank: "SEB", term: model.Term1year, ltv: 0.5, loanAmount: 2_000_000, wantRate: 3.6, wantType: model.TypeListRate},
+		{name: "unknown term", bank: "SEB", term: model.Term5years, ltv: 0.5, loanAmount: 2_000_000, wantErr: ErrNoRate},
+		{name: "unknown bank", bank: "Swedbank", term: model.Term3months, ltv: 0.5, loanAmount: 2_000_000, wantErr: ErrNoRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SelectInterestSet(testSets(), Request{Bank: tt.bank, Term: tt.term, Borrower: Borrower{
+				LoanAmount: tt.loanAmount, PropertyValue: tt.loanAmount / tt.ltv, Union: tt.union, EnergyClass: tt.energyClass,
+			}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SelectInterestSet() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.NominalRate != model.RateFromPercent(tt.wantRate) || got.Type != tt.wantType {
+				t.Errorf("SelectInterestSet() = %v %v, want %v %v", got.Type, got.NominalRate, tt.wantType, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		req                  Request
+		wantErr              error
+		wantRate             float64
+		wantAmortizationRate float64
+		wantMonthlyInterest  float64
+		wantMonthlyAmort     float64
+		wantMonths           int
+	}{
+		{
+			name:                 "high LTV amortizes 2 percent",
+			req:                  Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 3_200_000, PropertyValue: 4_000_000}},
+			wantRate:             4.0,
+			wantAmortizationRate: 0.02,
+			wantMonthlyInterest:  3_200_000 * 0.04 / 12,
+			wantMonthlyAmort:     3_200_000 * 0.02 / 12,
+			wantMonths:           3,
+		},
+		{
+			name:                 "medium LTV amortizes 1 percent",
+			req:                  Request{Bank: "SEB", Term: model.Term1year, Borrower: Borrower{LoanAmount: 2_400_000, PropertyValue: 4_000_000}},
+			wantRate:             3.6,
+			wantAmortizationRate: 0.01,
+			wantMonthlyInterest:  2_400_000 * 0.036 / 12,
+			wantMonthlyAmort:     2_400_000 * 0.01 / 12,
+			wantMonths:           12,
+		},
+		{
+			name:                 "low LTV without amortization",
+			req:                  Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}},
+			wantRate:             3.5,
+			wantAmortizationRate: 0,
+			wantMonthlyInterest:  2_000_000 * 0.035 / 12,
+			wantMonthlyAmort:     0,
+			wantMonths:           3,
+		},
+		{
+			name:                 "high debt-to-income amortizes an extra percent",
+			req:                  Request{Bank: "SEB", Term: model.Term1year, Borrower: Borrower{LoanAmount: 2_400_000, PropertyValue: 4_000_000, AnnualIncome: 500_000}},
+			wantRate:             3.6,
+			wantAmortizationRate: 0.02,
+			wantMonthlyInterest:  2_400_000 * 0.036 / 12,
+			wantMonthlyAmort:     2_400_000 * 0.02 / 12,
+			wantMonths:           12,
+		},
+		{
+			name:    "LTV above bolånetak",
+			req:     Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 3_600_000, PropertyValue: 4_000_000}},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name:    "missing property value",
+			req:     Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 3_600_000}},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name:    "negative income",
+			req:     Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000, AnnualIncome: -1}},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name:    "invalid energy class",
+			req:     Request{Bank: "SEB", Term: model.Term3months, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000, EnergyClass: "X"}},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name:    "unknown term",
+			req:     Request{Bank: "SEB", Term: model.Term{}, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name:    "no rate for term",
+			req:     Request{Bank: "SEB", Term: model.Term15years, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}},
+			wantErr: ErrNoRate,
+		},
+		{
+			name:    "no rate for bank",
+			req:     Request{Bank: "Swedbank", Term: model.Term3months, Borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}},
+			wantErr: ErrNoRate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Calculate(testSets(), tt.req, DefaultRules())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Calculate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.NominalRate != model.RateFromPercent(tt.wantRate) {
+				t.Errorf("NominalRate = %v, want %v", got.NominalRate, tt.wantRate)
+			}
+			if !almostEqual(got.AmortizationRate, tt.wantAmortizationRate) {
+				t.Errorf("AmortizationRate = %v, want %v", got.AmortizationRate, tt.wantAmortizationRate)
+			}
+			if !almostEqual(got.MonthlyInterest, tt.wantMonthlyInterest) {
+				t.Errorf("MonthlyInterest = %v, want %v", got.MonthlyInterest, tt.wantMonthlyInterest)
+			}
+			if !almostEqual(got.MonthlyAmortization, tt.wantMonthlyAmort) {
+				t.Errorf("MonthlyAmortization = %v, want %v", got.MonthlyAmortization, tt.wantMonthlyAmort)
+			}
+			if got.BindingPeriodMonths != tt.wantMonths {
+				t.Errorf("BindingPeriodMonths = %d, want %d", got.BindingPeriodMonths, tt.wantMonths)
+			}
+			if !almostEqual(got.MonthlyPayment, got.MonthlyInterest+got.MonthlyAmortization) {
+				t.Errorf("MonthlyPayment = %v, want interest + amortization", got.MonthlyPayment)
+			}
+			if !almostEqual(got.TotalAmortization, got.MonthlyAmortization*float64(tt.wantMonths)) {
+				t.Errorf("TotalAmortization = %v, want %v", got.TotalAmortization, got.MonthlyAmortization*float64(tt.wantMonths))
+			}
+			if got.TotalInterest > got.MonthlyInterest*float64(tt.wantMonths)+0.01 {
+				t.Errorf("TotalInterest = %v, must not exceed first month interest times months", got.TotalInterest)
+			}
+			if !almostEqual(got.TotalCost, got.TotalInterest-got.TotalTaxDeduction) {
+				t.Errorf("TotalCost = %v, want interest minus deduction", got.TotalCost)
+			}
+		})
+	}
+}
+
+func TestValidateBorrower_Messages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		borrower Borrower
+		want     string
+	}{
+		{name: "missing loan amount", borrower: Borrower{PropertyValue: 4_000_000}, want: "loan amount and property value must be positive"},
+		{name: "negative income", borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000, AnnualIncome: -1}, want: "annual income must not be negative"},
+	}
+	for _, tt := range tests {
+		err := validateBorrower(tt.borrower, DefaultRules())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: validateBorrower() error = %v, want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestTaxDeduction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		interest float64
+		want     float64
+	}{
+		{name: "below limit", interest: 60_000, want: 18_000},
+		{name: "at limit", interest: 100_000, want: 30_000},
+		{name: "above limit", interest: 150_000, want: 30_000 + 10_500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := taxDeduction(tt.interest, DefaultRules()); !almostEqual(got, tt.want) {
+				t.Errorf("taxDeduction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
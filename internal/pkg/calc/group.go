@@ -0,0 +1,77 @@
+package calc
+
+import (
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// CollapseRanking keeps only the best offer of each bank group per term, so that brands lending from the same balance
+// sheet don't appear as competing offers. The group's other offers become the Alternatives of the best one, best
+// first, and keep their rank of the full ranking. The remaining offers are ranked again.
+func CollapseRanking(ranking Ranking, groups map[model.Bank]model.BankGroup) Ranking {
+	collapsed := Ranking{LoanToValue: ranking.LoanToValue, Terms: make([]TermRanking, 0, len(ranking.Terms))}
+	for _, term := range ranking.Terms {
+		offers := collapseByGroup(term.Offers,
+			func(offer *Offer) model.BankGroup {
+				offer.Group = model.GroupOf(groups, offer.Bank, offer.AppliedSet.Lender)
+				return offer.Group
+			},
+			func(best *Offer, alternative Offer) { best.Alternatives = append(best.Alternatives, alternative) },
+		)
+		for i := range offers {
+			offers[i].Rank = i + 1
+		}
+		collapsed.Terms = append(collapsed.Terms, TermRanking{Term: term.Term, Offers: offers})
+	}
+	return collapsed
+}
+
+// CollapseSpreads keeps only the smallest spread of each bank group. The group's other spreads become the Alternatives
+// of the smallest one, smallest first.
+func CollapseSpreads(report SpreadReport, groups map[model.Bank]model.BankGroup) SpreadReport {
+	report.Spreads = collapseByGroup(report.Spreads,
+		func(spread *Spread) model.BankGroup {
+			spread.Group = model.GroupOf(groups, spread.Bank, spread.Lender)
+			return spread.Group
+		},
+		func(best *Spread, alternative Spread) { best.Alternatives = append(best.Alternatives, alternative) },
+	)
+	return report
+}
+
+// CollapseRates keeps only the lowest rate of each bank group for the same product, i.e. the same type, term and
+// boundaries. The group's other rates for the product become the Alternatives of the lowest one, lowest first. rates
+// must be ordered like ListRates orders them.
+func CollapseRates(rates []ListedRate, groups map[model.Bank]model.BankGroup) []ListedRate {
+	type groupProduct struct {
+		group   model.BankGroup
+		product string
+	}
+	return collapseByGroup(rates,
+		func(rate *ListedRate) groupProduct {
+			rate.Group = model.GroupOf(groups, rate.Bank, rate.Lender)
+			product := rate.InterestSet
+			product.Bank, product.Lender = "", ""
+			return groupProduct{group: rate.Group, product: product.Key()}
+		},
+		func(best *ListedRate, alternative ListedRate) {
+			best.Alternatives = append(best.Alternatives, alternative)
+		},
+	)
+}
+
+// collapseByGroup keeps the first item of each group, which is the best one since the items are sorted best first, and
+// hands the others to addAlternative. group may annotate the item with its group.
+func collapseByGroup[T any, K comparable](items []T, group func(*T) K, addAlternative func(best *T, alternative T)) []T {
+	first := make(map[K]int)
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		g := group(&item)
+		if i, ok := first[g]; ok {
+			addAlternative(&result[i], item)
+			continue
+		}
+		first[g] = len(result)
+		result = append(result, item)
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package calc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func groupSets() []model.InterestSet {
+	return []model.InterestSet{
+		{Bank: "Nordax Bank", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.2)},
+		{Bank: "Svensk Hypotekspension", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.0)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.0)},
+		{Bank: "Avanza", Lender: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.8)},
+		{Bank: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.9)},
+	}
+}
+
+var testGroups = map[model.Bank]model.BankGroup{
+	"Nordax Bank":            "NOBA Bank Group",
+	"Svensk Hypotekspension": "NOBA Bank Group",
+}
+
+func TestCollapseRanking(t *testing.T) {
+	t.Parallel()
+
+	ranking, err := Rank(groupSets(), Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}, nil, DefaultRules())
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+	got := CollapseRanking(ranking, testGroups)
+
+	if len(got.Terms) != 1 {
+		t.Fatalf("CollapseRanking() returned %d terms, want 1", len(got.Terms))
+	}
+	var offers []string
+	for _, offer := range got.Terms[0].Offers {
+		var alternatives []string
+		for _, alternative := range offer.Alternatives {
+			alternatives = append(alternatives, fmt.Sprintf("#%d %s", alternative.Rank, alternative.Bank))
+		}
+		offers = append(offers, fmt.Sprintf("#%d %s (%s) %v", offer.Rank, offer.Bank, offer.Group, alternatives))
+	}
+	want := []string{
+		"#1 Avanza (Landshypotek) [#2 Landshypotek]",
+		"#2 SEB (SEB) []",
+		"#3 Svensk Hypotekspension (NOBA Bank Group) [#5 Nordax Bank]",
+	}
+	if !reflect.DeepEqual(offers, want) {
+		t.Errorf("CollapseRanking() = %v, want %v", offers, want)
+	}
+	if len(ranking.Terms[0].Offers) != 5 || ranking.Terms[0].Offers[0].Alternatives != nil {
+		t.Errorf("CollapseRanking() modified the full ranking: %+v", ranking.Terms[0].Offers)
+	}
+}
+
+func TestCollapseSpreads(t *testing.T) {
+	t.Parallel()
+
+	policyRate := model.ReferenceRate{
+		Series: model.ReferencePolicyRate, Date: time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), Rate: model.RateFromPercent(2),
+	}
+	report, err := Spreads(groupSets(), policyRate, model.Term3months)
+	if err != nil {
+		t.Fatalf("Spreads() error = %v", err)
+	}
+	got := CollapseSpreads(report, testGroups)
+
+	var spreads []string
+	for _, spread := range got.Spreads {
+		var alternatives []string
+		for _, alternative := range spread.Alternatives {
+			alternatives = append(alternatives, fmt.Sprintf("%s=%.0f", alternative.Bank, alternative.SpreadBps))
+		}
+		spreads = append(spreads, fmt.Sprintf("%s=%.0f (%s) %v", spread.Bank, spread.SpreadBps, spread.Group, alternatives))
+	}
+	want := []string{
+		"Avanza=180 (Landshypotek) [Landshypotek=190]",
+		"SEB=200 (SEB) []",
+		"Svensk Hypotekspension=300 (NOBA Bank Group) [Nordax Bank=320]",
+	}
+	if !reflect.DeepEqual(spreads, want) {
+		t.Errorf("CollapseSpreads() = %v, want %v", spreads, want)
+	}
+}
+
+func TestBankProfiles_Groups(t *testing.T) {
+	t.Parallel()
+
+	groups := model.BankGroups(model.BankProfiles())
+	tests := []struct {
+		bank, lender model.Bank
+		want         model.BankGroup
+	}{
+		{bank: "Nordax Bank", want: "NOBA Bank Group"},
+		{bank: "Svensk Hypotekspension", want: "NOBA Bank Group"},
+		{bank: "Bluestep", want: "Enity Bank Group"},
+		{bank: "Avanza", lender: "Landshypotek", want: "Landshypotek"},
+		{bank: "Landshypotek", want: "Landshypotek"},
+		{bank: "SEB", want: "SEB"},
+	}
+	for _, tt := range tests {
+		if got := model.GroupOf(groups, tt.bank, tt.lender); got != tt.want {
+			t.Errorf("GroupOf(%s, %s) = %q, want %q", tt.bank, tt.lender, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package calc
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// ListedRate is a stored rate as listed by ListRates, including the source it was parsed from.
+type ListedRate struct {
+	model.InterestSet
+	// Group and Alternatives are only set if the list is collapsed by bank group, see CollapseRates.
+	Group        model.BankGroup `json:"group,omitempty"`
+	Alternatives []ListedRate    `json:"alternatives,omitempty"`
+}
+
+// ListRates returns the sets of bank for term, ordered by type and term with the lowest rate first. An empty bank or a
+// zero term matches every bank or term.
+func ListRates(sets []model.InterestSet, bank model.Bank, term model.Term) []ListedRate {
+	rates := make([]ListedRate, 0, len(sets))
+	for _, set := range sets {
+		if (bank != "" && set.Bank != bank) || (term != model.Term{} && set.Term != term) {
+			continue
+		}
+		rates = append(rates, ListedRate{InterestSet: set})
+	}
+
+	slices.SortFunc(rates, func(a, b ListedRate) int {
+		return cmp.Or(
+			cmp.Compare(a.Type, b.Type),
+			a.Term.Compare(b.Term),
+			cmp.Compare(a.NominalRate, b.NominalRate),
+			cmp.Compare(a.Key(), b.Key()),
+		)
+	})
+	return rates
+}
@@ -0,0 +1,183 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// Offer is the best rate one bank offers the borrower for one term.
+type Offer struct {
+	Rank        int        `json:"rank"`
+	Bank        model.Bank `json:"bank"`
+	Term        model.Term `json:"term"`
+	NominalRate model.Rate `json:"nominalRate"`
+	// EffectiveRate is the yearly rate in percent including monthly compounding. Fees are not included since the
+	// banks don't publish them in a comparable way.
+	EffectiveRate float64           `json:"effectiveRate"`
+	AppliedSet    model.InterestSet `json:"appliedSet"`
+	Explanation   string            `json:"explanation"`
+	// LatestAverageRate is the most recent average rate of the bank for the term, which shows what borrowers
+	// actually paid. Nil if the bank doesn't publish one. Of a bank publishing several series, only a series whose
+	// loan-to-value and loan amount boundaries contain the borrower's loan is used.
+	LatestAverageRate *model.InterestSet `json:"latestAverageRate"`
+	// Group and Alternatives are only set if the ranking is collapsed by bank group, see CollapseRanking.
+	Group        model.BankGroup `json:"group,omitempty"`
+	Alternatives []Offer         `json:"alternatives,omitempty"`
+}
+
+// TermRanking lists the offers for one term, best offer first.
+type TermRanking struct {
+	Term   model.Term `json:"term"`
+	Offers []Offer    `json:"offers"`
+}
+
+// Ranking is the result of Rank.
+type Ranking struct {
+	LoanToValue float64       `json:"loanToValue"`
+	Terms       []TermRanking `json:"terms"`
+}
+
+// Rank ranks all banks by the lowest rate that applies to the borrower, separately for each term. If terms is empty,
+// all terms any bank offers are ranked. Banks without an applicable rate for a term are left out of its ranking.
+func Rank(sets []model.InterestSet, borrower Borrower, terms []model.Term, rules Rules) (Ranking, error) {
+	if err := validateBorrower(borrower, rules); err != nil {
+		return Ranking{}, err
+	}
+	for _, term := range terms {
+		if _, err := termMonths(term); err != nil {
+			return Ranking{}, err
+		}
+	}
+	if len(terms) == 0 {
+		terms = offeredTerms(sets)
+	}
+
+	ranking := Ranking{LoanToValue: borrower.LoanToValue(), Terms: make([]TermRanking, 0, len(terms))}
+	for _, term := range terms {
+		offers := []Offer{}
+		for _, bank := range banks(sets) {
+			set, err := SelectInterestSet(sets, Request{Bank: bank, Term: term, Borrower: borrower})
+			if err != nil {
+				continue
+			}
+			offers = append(offers, Offer{
+				Bank:              bank,
+				Term:              term,
+				NominalRate:       set.NominalRate,
+				EffectiveRate:     EffectiveRate(set.NominalRate),
+				AppliedSet:        set,
+				Explanation:       explain(set),
+				LatestAverageRate: latestAverageRate(sets, bank, term, set.Lender, borrower),
+			})
+		}
+
+		sort.SliceStable(offers, func(i, j int) bool {
+			if offers[i].NominalRate != offers[j].NominalRate {
+				return offers[i].NominalRate < offers[j].NominalRate
+			}
+			return offers[i].Bank < offers[j].Bank
+		})
+		for i := range offers {
+			offers[i].Rank = i + 1
+		}
+		ranking.Terms = append(ranking.Terms, TermRanking{Term: term, Offers: offers})
+	}
+
+	return ranking, nil
+}
+
+// EffectiveRate converts a nominal yearly rate in percent to the effective yearly rate in percent, assuming interest
+// is paid monthly.
+func EffectiveRate(nominalRate model.Rate) float64 {
+	return (math.Pow(1+nominalRate.Percent()/100/12, 12) - 1) * 100
+}
+
+// explain describes in words why the set applies to the borrower.
+func explain(set model.InterestSet) string {
+	var explanation string
+	switch set.Type {
+	case model.TypeRatioDiscounted:
+		explanation = fmt.Sprintf("discounted rate for a loan-to-value of %.0f-%.0f%%",
+			set.RatioDiscountBoundaries.MinRatio*100, set.RatioDiscountBoundaries.MaxRatio*100)
+	case model.TypeUnionDiscounted:
+		explanation = "discounted rate for members of " + strings.Join(set.UnionOrganisations, " or ")
+	case model.TypeListRate, model.TypeAverageRate:
+		explanation = "list rate"
+	}
+
+	if b := set.LoanAmountBoundaries; b != nil {
+		if b.MaxAmount > 0 {
+			explanation += fmt.Sprintf(", for loans of %d-%d SEK", b.MinAmount, b.MaxAmount)
+		} else {
+			explanation += fmt.Sprintf(", for loans from %d SEK", b.MinAmount)
+		}
+	}
+	if set.MaxEnergyClass != "" {
+		explanation += fmt.Sprintf(", for properties with energy class %s or better", set.MaxEnergyClass)
+	}
+	if set.Lender != "" {
+		explanation += fmt.Sprintf(", lent by %s", set.Lender)
+	}
+	return explanation
+}
+
+func latestAverageRate(sets []model.InterestSet, bank model.Bank, term model.Term, lender model.Bank, borrower Borrower) *model.InterestSet {
+	var latest *model.InterestSet
+	for i, set := range sets {
+		if set.Type != model.TypeAverageRate || set.Bank != bank || set.Term != term || set.Lender != lender || set.AverageReferenceMonth == nil {
+			continue
+		}
+		if seg := set.AverageSegment; seg != nil && (!seg.Bounded() || !seg.Contains(borrower.LoanToValue(), borrower.LoanAmount)) {
+			continue // a risk class or band the borrower can't be placed in
+		}
+		if latest == nil || laterMonth(*set.AverageReferenceMonth, *latest.AverageReferenceMonth) {
+			latest = &sets[i]
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	avg := *latest
+	return &avg
+}
+
+func laterMonth(a, b model.AvgMonth) bool {
+	if a.Year != b.Year {
+		return a.Year > b.Year
+	}
+	return a.Month > b.Month
+}
+
+// banks returns all banks that have at least one rate, sorted by name.
+func banks(sets []model.InterestSet) []model.Bank {
+	seen := map[model.Bank]bool{}
+	result := []model.Bank{}
+	for _, set := range sets {
+		if !seen[set.Bank] {
+			seen[set.Bank] = true
+			result = append(result, set.Bank)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// offeredTerms returns all known terms that at least one bank has a rate for, shortest first.
+func offeredTerms(sets []model.InterestSet) []model.Term {
+	seen := map[model.Term]bool{}
+	result := []model.Term{}
+	for _, set := range sets {
+		if _, err := termMonths(set.Term); err != nil || seen[set.Term] {
+			continue
+		}
+		seen[set.Term] = true
+		result = append(result, set.Term)
+	}
+	slices.SortFunc(result, model.Term.Compare)
+	return result
+}
@@ -0,0 +1,193 @@
+package calc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func rankSets() []model.InterestSet {
+	return append(testSets(),
+		model.InterestSet{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.4)},
+		model.InterestSet{
+			Bank: "SEB", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.45),
+			AverageReferenceMonth: &model.AvgMonth{Month: time.December, Year: 2025},
+		},
+		model.InterestSet{
+			Bank: "SEB", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.4),
+			AverageReferenceMonth: &model.AvgMonth{Month: time.January, Year: 2026},
+		},
+		model.InterestSet{
+			Bank: "SEB", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.5),
+			AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025},
+		},
+	)
+}
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		borrower Borrower
+		terms    []model.Term
+		// want maps each ranked term to the expected banks and rates in ranking order.
+		want    map[model.Term][]Offer
+		wantErr error
+	}{
+		{
+			name:     "LTV tier beats the other bank's list rate",
+			borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000},
+			want: map[model.Term][]Offer{
+				model.Term3months: {{Bank: "SEB", NominalRate: model.RateFromPercent(3.5)}, {Bank: "Nordea", NominalRate: model.RateFromPercent(3.9)}},
+				model.Term1year:   {{Bank: "Nordea", NominalRate: model.RateFromPercent(3.4)}, {Bank: "SEB", NominalRate: model.RateFromPercent(3.6)}},
+			},
+		},
+		{
+			name:     "high LTV only gets list rates",
+			borrower: Borrower{LoanAmount: 3_200_000, PropertyValue: 4_000_000},
+			terms:    []model.Term{model.Term3months},
+			want: map[model.Term][]Offer{
+				model.Term3months: {{Bank: "Nordea", NominalRate: model.RateFromPercent(3.9)}, {Bank: "SEB", NominalRate: model.RateFromPercent(4.0)}},
+			},
+		},
+		{
+			name:     "union member with green property",
+			borrower: Borrower{LoanAmount: 3_200_000, PropertyValue: 4_000_000, Union: "Saco", EnergyClass: model.EnergyClassB},
+			terms:    []model.Term{model.Term3months},
+			want: map[model.Term][]Offer{
+				model.Term3months: {{Bank: "SEB", NominalRate: model.RateFromPercent(3.6)}, {Bank: "Nordea", NominalRate: model.RateFromPercent(3.9)}},
+			},
+		},
+		{
+			name:     "term nobody offers",
+			borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000},
+			terms:    []model.Term{model.Term10years},
+			want:     map[model.Term][]Offer{model.Term10years: {}},
+		},
+		{
+			name:     "unknown term",
+			borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000},
+			terms:    []model.Term{{}},
+			wantErr:  ErrInvalidRequest,
+		},
+		{
+			name:     "LTV above bolånetak",
+			borrower: Borrower{LoanAmount: 3_600_000, PropertyValue: 4_000_000},
+			wantErr:  ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Rank(rankSets(), tt.borrower, tt.terms, DefaultRules())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rank() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(got.Terms) != len(tt.want) {
+				t.Fatalf("Rank() returned %d terms, want %d", len(got.Terms), len(tt.want))
+			}
+			for _, ranking := range got.Terms {
+				want, ok := tt.want[ranking.Term]
+				if !ok {
+					t.Errorf("unexpected term %q in ranking", ranking.Term)
+					continue
+				}
+				if len(ranking.Offers) != len(want) {
+					t.Fatalf("term %q has %d offers, want %d", ranking.Term, len(ranking.Offers), len(want))
+				}
+				for i, offer := range ranking.Offers {
+					if offer.Rank != i+1 || offer.Bank != want[i].Bank || offer.NominalRate != want[i].NominalRate {
+						t.Errorf("term %q offer %d = #%d %s %v, want #%d %s %v",
+							ranking.Term, i, offer.Rank, offer.Bank, offer.NominalRate, i+1, want[i].Bank, want[i].NominalRate)
+					}
+					if offer.EffectiveRate <= offer.NominalRate.Percent() {
+						t.Errorf("EffectiveRate = %v, want above nominal rate %v", offer.EffectiveRate, offer.NominalRate)
+					}
+					if offer.Explanation == "" {
+						t.Errorf("term %q offer %d has no explanation", ranking.Term, i)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRank_TermOrderAndAverageRate(t *testing.T) {
+	t.Parallel()
+
+	got, err := Rank(rankSets(), Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}, nil, DefaultRules())
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+	if len(got.Terms) != 2 || got.Terms[0].Term != model.Term3months || got.Terms[1].Term != model.Term1year {
+		t.Fatalf("Rank() terms = %v, want [3m 1y]", got.Terms)
+	}
+
+	seb := got.Terms[0].Offers[0]
+	if seb.LatestAverageRate == nil || seb.LatestAverageRate.NominalRate != model.RateFromPercent(3.4) {
+		t.Errorf("LatestAverageRate = %v, want the January 2026 rate 3.4", seb.LatestAverageRate)
+	}
+	if seb.Explanation != "discounted rate for a loan-to-value of 0-60%" {
+		t.Errorf("Explanation = %q", seb.Explanation)
+	}
+	if nordea := got.Terms[0].Offers[1]; nordea.LatestAverageRate != nil {
+		t.Errorf("LatestAverageRate = %v, want nil for a bank without average rates", nordea.LatestAverageRate)
+	}
+}
+
+func TestRank_SegmentedAverageRate(t *testing.T) {
+	t.Parallel()
+
+	month := &model.AvgMonth{Month: time.November, Year: 2025}
+	sets := []model.InterestSet{
+		{Bank: "Bluestep", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.5)},
+		{
+			Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.2),
+			AverageReferenceMonth: month, AverageSegment: &model.AverageSegment{Label: "Belåningsgrad upp till 60 %", MaxRatio: 0.6},
+		},
+		{
+			Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(5.9),
+			AverageReferenceMonth: month, AverageSegment: &model.AverageSegment{Label: "Belåningsgrad 60-85 %", MinRatio: 0.6, MaxRatio: 0.85},
+		},
+		{
+			Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(4.9),
+			AverageReferenceMonth: month, AverageSegment: &model.AverageSegment{Label: "Riskklass A"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		borrower Borrower
+		want     model.Rate
+	}{
+		{name: "low LTV", borrower: Borrower{LoanAmount: 2_000_000, PropertyValue: 4_000_000}, want: model.RateFromPercent(5.2)},
+		{name: "high LTV", borrower: Borrower{LoanAmount: 3_000_000, PropertyValue: 4_000_000}, want: model.RateFromPercent(5.9)},
+	}
+	for _, tt := range tests {
+		got, err := Rank(sets, tt.borrower, nil, DefaultRules())
+		if err != nil {
+			t.Fatalf("Rank() error = %v", err)
+		}
+		avg := got.Terms[0].Offers[0].LatestAverageRate
+		if avg == nil || avg.NominalRate != tt.want {
+			t.Errorf("%s: LatestAverageRate = %v, want the series containing the loan with %v", tt.name, avg, tt.want)
+		}
+	}
+}
+
+func TestEffectiveRate(t *testing.T) {
+	t.Parallel()
+
+	if got := EffectiveRate(model.RateFromPercent(4.0)); !almostEqual(got, 4.0742) {
+		t.Errorf("EffectiveRate(4.0) = %v, want 4.0742", got)
+	}
+}
@@ -0,0 +1,83 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// Spread is how far one bank's list rate lies above a reference rate.
+type Spread struct {
+	Bank      model.Bank        `json:"bank"`
+	Lender    model.Bank        `json:"lender,omitempty"` // only if Bank distributes another lender's loan
+	ListRate  model.InterestSet `json:"listRate"`
+	SpreadBps float64           `json:"spreadBps"`
+	// Group and Alternatives are only set if the report is collapsed by bank group, see CollapseSpreads.
+	Group        model.BankGroup `json:"group,omitempty"`
+	Alternatives []Spread        `json:"alternatives,omitempty"`
+}
+
+// SpreadReport lists the spreads of all banks over one reference rate for one term, smallest spread first.
+type SpreadReport struct {
+	Term      model.Term          `json:"term"`
+	Reference model.ReferenceRate `json:"reference"`
+	Spreads   []Spread            `json:"spreads"`
+}
+
+// LatestReferenceRate returns the most recent of the rates. It returns ErrNoRate if there are none.
+func LatestReferenceRate(rates []model.ReferenceRate) (model.ReferenceRate, error) {
+	if len(rates) == 0 {
+		return model.ReferenceRate{}, fmt.Errorf("%w: no reference rate stored", ErrNoRate)
+	}
+
+	latest := rates[0]
+	for _, rate := range rates[1:] {
+		if rate.Date.After(latest.Date) {
+			latest = rate
+		}
+	}
+	return latest, nil
+}
+
+// Spreads computes the spread of every bank's plain list rate for the term over the reference rate. Discounted rates
+// are left out, they depend on the borrower and not on the market.
+func Spreads(sets []model.InterestSet, reference model.ReferenceRate, term model.Term) (SpreadReport, error) {
+	if _, err := termMonths(term); err != nil {
+		return SpreadReport{}, err
+	}
+
+	type product struct{ bank, lender model.Bank }
+	seen := map[product]bool{}
+	report := SpreadReport{Term: term, Reference: reference, Spreads: []Spread{}}
+	for _, set := range sets {
+		if set.Type != model.TypeListRate || set.Term != term || !unconditional(set) || seen[product{set.Bank, set.Lender}] {
+			continue
+		}
+		seen[product{set.Bank, set.Lender}] = true
+		report.Spreads = append(report.Spreads, Spread{
+			Bank:      set.Bank,
+			Lender:    set.Lender,
+			ListRate:  set,
+			SpreadBps: (set.NominalRate - reference.Rate).BasisPoints(),
+		})
+	}
+
+	sort.SliceStable(report.Spreads, func(i, j int) bool {
+		a, b := report.Spreads[i], report.Spreads[j]
+		if a.SpreadBps != b.SpreadBps {
+			return a.SpreadBps < b.SpreadBps
+		}
+		if a.Bank != b.Bank {
+			return a.Bank < b.Bank
+		}
+		return a.Lender < b.Lender
+	})
+	return report, nil
+}
+
+// unconditional reports whether the set applies to every borrower.
+func unconditional(set model.InterestSet) bool {
+	return set.RatioDiscountBoundaries == nil && set.LoanAmountBoundaries == nil && set.MaxEnergyClass == "" &&
+		!set.UnionDiscount && len(set.UnionOrganisations) == 0
+}
@@ -0,0 +1,90 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestSpreads(t *testing.T) {
+	t.Parallel()
+
+	policyRate := model.ReferenceRate{
+		Series: model.ReferencePolicyRate, Date: time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC), Rate: model.RateFromPercent(1.75),
+	}
+	sets := append(testSets(),
+		model.InterestSet{Bank: "Avanza", Lender: "Stabelo", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.55)},
+		model.InterestSet{Bank: "Avanza", Lender: "Landshypotek", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.9)},
+	)
+
+	tests := []struct {
+		name    string
+		term    model.Term
+		want    []string // bank/lender=spreadBps in order
+		wantErr error
+	}{
+		{
+			name: "plain list rates only, smallest spread first",
+			term: model.Term3months,
+			want: []string{"Avanza/Stabelo=180", "Avanza/Landshypotek=215", "Nordea/=215", "SEB/=225"},
+		},
+		{name: "other term", term: model.Term1year, want: []string{"SEB/=185"}},
+		{name: "term nobody offers", term: model.Term10years, want: nil},
+		{name: "unknown term", term: model.Term{}, wantErr: ErrInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := Spreads(sets, policyRate, tt.term)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Spreads() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, spread := range report.Spreads {
+				got = append(got, fmt.Sprintf("%s/%s=%.0f", spread.Bank, spread.Lender, spread.SpreadBps))
+				if spread.ListRate.Bank != spread.Bank || spread.ListRate.Type != model.TypeListRate {
+					t.Errorf("ListRate of %s = %+v, want its list rate", spread.Bank, spread.ListRate)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Spreads() = %v, want %v", got, tt.want)
+			}
+			if report.Term != tt.term || report.Reference != policyRate {
+				t.Errorf("report = term %v, reference %+v; want %v, %+v", report.Term, report.Reference, tt.term, policyRate)
+			}
+		})
+	}
+}
+
+func TestLatestReferenceRate(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2025, time.October, d, 0, 0, 0, 0, time.UTC) }
+	rates := []model.ReferenceRate{
+		{Series: model.ReferencePolicyRate, Date: day(2), Rate: model.RateFromPercent(1.75)},
+		{Series: model.ReferencePolicyRate, Date: day(3), Rate: model.RateFromPercent(1.75)},
+		{Series: model.ReferencePolicyRate, Date: day(1), Rate: model.RateFromPercent(2)},
+	}
+
+	got, err := LatestReferenceRate(rates)
+	if err != nil {
+		t.Fatalf("LatestReferenceRate() error = %v", err)
+	}
+	if !got.Date.Equal(day(3)) {
+		t.Errorf("LatestReferenceRate() = %s, want 2025-10-03", got.Date.Format(time.DateOnly))
+	}
+
+	if _, err := LatestReferenceRate(nil); !errors.Is(err, ErrNoRate) {
+		t.Errorf("LatestReferenceRate(nil) error = %v, want ErrNoRate", err)
+	}
+}
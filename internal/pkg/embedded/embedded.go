@@ -0,0 +1,48 @@
+// Package embedded decodes the data that JavaScript-rendered bank sites embed in their pages, so crawlers can read
+// rates without a headless browser. Every decoder returns plain Go values as produced by encoding/json: map[string]any,
+// []any, string, float64, bool and nil. Use Query and its typed helpers to navigate them.
+//
+// Supported formats:
+//   - Nuxt payloads (_payload.json or window.__NUXT__), serialized with devalue: DecodeNuxtPayload.
+//   - Remix single-fetch data, serialized with turbo-stream: DecodeTurboStream and DecodeRemixContext.
+//   - Next.js pages router data (__NEXT_DATA__): ExtractNextData.
+//   - Next.js app router flight data (self.__next_f): ExtractNextFlight.
+//   - Episerver (Optimizely) Content Delivery API responses: DecodeEpiserver.
+package embedded
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrMalformed means the input is not valid data in the expected format.
+	ErrMalformed = errors.New("malformed embedded data")
+	// ErrNotFound means a page holds no embedded data of the expected kind, or a query path matched nothing.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidPath means a query path could not be parsed.
+	ErrInvalidPath = errors.New("invalid query path")
+	// ErrWrongType means a query matched a value of another type than requested.
+	ErrWrongType = errors.New("wrong type")
+)
+
+// unmarshal decodes JSON into plain Go values.
+func unmarshal(data string) (any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &value); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return value, nil
+}
+
+// unquoteJSString decodes a double-quoted JavaScript string literal as written by JSON.stringify, which is how
+// frameworks inline their data chunks into script tags.
+func unquoteJSString(literal string) (string, error) {
+	var str string
+	if err := json.Unmarshal([]byte(literal), &str); err != nil {
+		return "", fmt.Errorf("%w: string literal: %w", ErrMalformed, err)
+	}
+	return str, nil
+}
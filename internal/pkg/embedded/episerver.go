@@ -0,0 +1,36 @@
+package embedded
+
+// DecodeEpiserver decodes a response of the Episerver (Optimizely) Content Delivery API. Depending on the site's
+// configuration, properties are either flattened to their value or wrapped as {"value": ..., "propertyDataType": ...};
+// wrapped properties are unwrapped so that both shapes query the same way. Content areas fetched with expand=* carry
+// their blocks in "expandedValue", which is preferred over the bare content references in "value".
+func DecodeEpiserver(data string) (any, error) {
+	value, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return unwrapEpiserver(value), nil
+}
+
+func unwrapEpiserver(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if _, ok := v["propertyDataType"]; ok {
+			if expanded, ok := v["expandedValue"]; ok && expanded != nil {
+				return unwrapEpiserver(expanded)
+			}
+			return unwrapEpiserver(v["value"])
+		}
+		for key, field := range v {
+			v[key] = unwrapEpiserver(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = unwrapEpiserver(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,56 @@
+package embedded
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEpiserver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "flattened properties",
+			input: `[{"name":"Räntor","body":"<table></table>"}]`,
+			want:  []any{map[string]any{"name": "Räntor", "body": "<table></table>"}},
+		},
+		{
+			name:  "wrapped properties",
+			input: `{"body":{"value":"<table></table>","propertyDataType":"PropertyXhtmlString"}}`,
+			want:  map[string]any{"body": "<table></table>"},
+		},
+		{
+			name: "expanded content area",
+			input: `{"mainContentArea":{"value":[{"contentLink":{"id":1}}],"expandedValue":[` +
+				`{"body":{"value":"<p>x</p>","propertyDataType":"PropertyXhtmlString"}}],` +
+				`"propertyDataType":"PropertyContentArea"}}`,
+			want: map[string]any{"mainContentArea": []any{map[string]any{"body": "<p>x</p>"}}},
+		},
+		{
+			name:  "unexpanded content area",
+			input: `{"area":{"value":[{"contentLink":{"id":1}}],"expandedValue":null,"propertyDataType":"PropertyContentArea"}}`,
+			want:  map[string]any{"area": []any{map[string]any{"contentLink": map[string]any{"id": 1.0}}}},
+		},
+		{name: "invalid json", input: `{`, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeEpiserver(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeEpiserver() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeEpiserver() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package embedded
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	nextDataRegex   = regexp.MustCompile(`(?s)<script[^>]*\bid="__NEXT_DATA__"[^>]*>(.*?)</script>`)
+	nextFlightRegex = regexp.MustCompile(`self\.__next_f\.push\(\[1,\s*("(?:[^"\\]|\\.)*")\]\)`)
+	flightRowRegex  = regexp.MustCompile(`^([0-9a-f]+):`)
+)
+
+// ExtractNextData decodes the __NEXT_DATA__ script tag of a Next.js pages router site. Page data is found under
+// "props.pageProps".
+func ExtractNextData(rawHTML string) (any, error) {
+	matches := nextDataRegex.FindStringSubmatch(rawHTML)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: no __NEXT_DATA__ in page", ErrNotFound)
+	}
+	return unmarshal(matches[1])
+}
+
+// ExtractNextFlight decodes the React Server Components flight data a Next.js app router site inlines through
+// self.__next_f.push([1, "..."]) calls. The result maps each row ID to its decoded JSON value; text rows ("T") are
+// returned as strings. Module imports, hints and other tagged rows carry no page data and are skipped.
+func ExtractNextFlight(rawHTML string) (map[string]any, error) {
+	matches := nextFlightRegex.FindAllStringSubmatch(rawHTML, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: no Next.js flight data in page", ErrNotFound)
+	}
+
+	var payload strings.Builder
+	for _, m := range matches {
+		chunk, err := unquoteJSString(m[1])
+		if err != nil {
+			return nil, err
+		}
+		payload.WriteString(chunk)
+	}
+
+	return parseFlightRows(payload.String())
+}
+
+// parseFlightRows splits a flight payload into rows of the form "<hex id>:<value>\n". Text rows are written as
+// "<hex id>:T<hex byte length>,<text>" without a trailing newline, so their text may contain newlines.
+func parseFlightRows(payload string) (map[string]any, error) {
+	rows := make(map[string]any)
+	for payload != "" {
+		m := flightRowRegex.FindStringSubmatch(payload)
+		if m == nil {
+			// Skip to the next line, e.g. after a row this parser does not understand.
+			_, rest, found := strings.Cut(payload, "\n")
+			if !found {
+				break
+			}
+			payload = rest
+			continue
+		}
+		id := m[1]
+		payload = payload[len(m[0]):]
+
+		if text, ok := strings.CutPrefix(payload, "T"); ok {
+			lengthHex, rest, found := strings.Cut(text, ",")
+			length, err := strconv.ParseInt(lengthHex, 16, 64)
+			if !found || err != nil || length < 0 || int(length) > len(rest) {
+				return nil, fmt.Errorf("%w: flight text row %s", ErrMalformed, id)
+			}
+			rows[id] = rest[:length]
+			payload = rest[length:]
+			continue
+		}
+
+		line, rest, _ := strings.Cut(payload, "\n")
+		payload = rest
+		var value any
+		if err := json.Unmarshal([]byte(line), &value); err == nil {
+			rows[id] = value
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no data rows in flight payload", ErrNotFound)
+	}
+	return rows, nil
+}
@@ -0,0 +1,108 @@
+package embedded
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExtractNextData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "script tag",
+			input: `<html><script id="__NEXT_DATA__" type="application/json">` +
+				`{"props":{"pageProps":{"rate":3.5}}}</script></html>`,
+			want: map[string]any{"props": map[string]any{"pageProps": map[string]any{"rate": 3.5}}},
+		},
+		{
+			name:  "attributes in any order",
+			input: `<script type="application/json" id="__NEXT_DATA__" nonce="x">[1]</script>`,
+			want:  []any{1.0},
+		},
+		{name: "no script tag", input: `<html></html>`, wantErr: ErrNotFound},
+		{name: "invalid json", input: `<script id="__NEXT_DATA__">{</script>`, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ExtractNextData(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtractNextData() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractNextData() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractNextFlight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]any
+		wantErr error
+	}{
+		{
+			name:  "json rows",
+			input: `<script>self.__next_f.push([1,"0:{\"rate\":3.5}\n1a:[\"$\",\"div\"]\n"])</script>`,
+			want: map[string]any{
+				"0":  map[string]any{"rate": 3.5},
+				"1a": []any{"$", "div"},
+			},
+		},
+		{
+			name: "rows split across chunks",
+			input: `<script>self.__next_f.push([0])</script>` +
+				`<script>self.__next_f.push([1,"2:{\"ra"])</script>` +
+				`<script>self.__next_f.push([1,"te\":3.5}\n"])</script>`,
+			want: map[string]any{"2": map[string]any{"rate": 3.5}},
+		},
+		{
+			name:  "text row with newline",
+			input: `<script>self.__next_f.push([1,"3:T5,a\nbcd4:\"x\"\n"])</script>`,
+			want:  map[string]any{"3": "a\nbcd", "4": "x"},
+		},
+		{
+			name:  "module rows are skipped",
+			input: `<script>self.__next_f.push([1,"5:I[\"chunk.js\"]\n6:HL[\"/font.woff2\"]\n7:1\n"])</script>`,
+			want:  map[string]any{"7": 1.0},
+		},
+		{name: "no flight data", input: `<html></html>`, wantErr: ErrNotFound},
+		{
+			name:    "text row longer than payload",
+			input:   `<script>self.__next_f.push([1,"3:Tff,abc"])</script>`,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "text row with negative length",
+			input:   `<script>self.__next_f.push([1,"0:T-1,x"])</script>`,
+			wantErr: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ExtractNextFlight(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtractNextFlight() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractNextFlight() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
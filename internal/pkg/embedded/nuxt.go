@@ -0,0 +1,180 @@
+package embedded
+
+import (
+	"fmt"
+	"math"
+)
+
+// devalue encodes these values as negative references instead of array entries.
+const (
+	devalueUndefined        = -1
+	devalueHole             = -2
+	devalueNaN              = -3
+	devaluePositiveInfinity = -4
+	devalueNegativeInfinity = -5
+	devalueNegativeZero     = -6
+)
+
+// DecodeNuxtPayload decodes a Nuxt payload, e.g. the body of /borantor/_payload.json. Nuxt serializes its payload with
+// devalue: a flat JSON array where entry 0 is the root and objects and arrays hold the indices of their values instead
+// of the values themselves. Nuxt's own wrappers like ["Reactive", 4] or ["ShallowRef", 7] are unwrapped to the value
+// they wrap, dates are returned as their ISO string, sets as arrays and maps as objects.
+func DecodeNuxtPayload(data string) (any, error) {
+	root, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := root.(type) {
+	case []any:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("%w: empty devalue array", ErrMalformed)
+		}
+		d := &devalueDecoder{values: v, hydrated: make(map[int]any, len(v)), unwrapping: make(map[int]bool)}
+		return d.hydrate(0)
+	case float64:
+		// A payload that is a single special value, e.g. undefined, is encoded as its negative reference alone.
+		return devalueConstant(int(v))
+	default:
+		return nil, fmt.Errorf("%w: devalue payload is a %T, want an array", ErrMalformed, root)
+	}
+}
+
+type devalueDecoder struct {
+	values   []any
+	hydrated map[int]any
+	// unwrapping holds the wrappers being resolved. A wrapper has no value of its own to cache before resolving what
+	// it wraps, so one that wraps itself is detected here instead.
+	unwrapping map[int]bool
+}
+
+func (d *devalueDecoder) hydrate(ref int) (any, error) {
+	if ref < 0 {
+		return devalueConstant(ref)
+	}
+	if ref >= len(d.values) {
+		return nil, fmt.Errorf("%w: devalue reference %d out of range", ErrMalformed, ref)
+	}
+	if value, ok := d.hydrated[ref]; ok {
+		return value, nil
+	}
+
+	switch v := d.values[ref].(type) {
+	case map[string]any:
+		obj := make(map[string]any, len(v))
+		d.hydrated[ref] = obj // before the fields, so that cycles resolve to this object
+		for key, valueRef := range v {
+			value, err := d.hydrateRef(valueRef)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = value
+		}
+		return obj, nil
+	case []any:
+		if len(v) > 0 {
+			if typ, ok := v[0].(string); ok {
+				return d.hydrateSpecial(ref, typ, v[1:])
+			}
+		}
+		arr := make([]any, len(v))
+		d.hydrated[ref] = arr
+		for i, valueRef := range v {
+			value, err := d.hydrateRef(valueRef)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = value
+		}
+		return arr, nil
+	default:
+		d.hydrated[ref] = v
+		return v, nil
+	}
+}
+
+// hydrateSpecial decodes a typed array like ["Date", "2025-11-10T00:00:00.000Z"] or ["Reactive", 4].
+func (d *devalueDecoder) hydrateSpecial(ref int, typ string, args []any) (any, error) {
+	switch typ {
+	case "Date", "BigInt", "RegExp", "Object":
+		// The first argument is the literal value: an ISO date, the digits of a BigInt, a regexp source or a boxed
+		// primitive.
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%w: devalue %s without value", ErrMalformed, typ)
+		}
+		d.hydrated[ref] = args[0]
+		return args[0], nil
+	case "Set":
+		arr := make([]any, len(args))
+		d.hydrated[ref] = arr
+		for i, valueRef := range args {
+			value, err := d.hydrateRef(valueRef)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = value
+		}
+		return arr, nil
+	case "Map", "null":
+		// Map holds alternating key and value references, a null-prototype object alternating literal keys and value
+		// references.
+		obj := make(map[string]any, len(args)/2)
+		d.hydrated[ref] = obj
+		for i := 0; i+1 < len(args); i += 2 {
+			key := args[i]
+			if typ == "Map" {
+				var err error
+				if key, err = d.hydrateRef(key); err != nil {
+					return nil, err
+				}
+			}
+			value, err := d.hydrateRef(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj[fmt.Sprint(key)] = value
+		}
+		return obj, nil
+	default:
+		// Nuxt's reducers (Reactive, ShallowReactive, Ref, ShallowRef, NuxtError, Island, ...) wrap a single reference.
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: unsupported devalue type %q", ErrMalformed, typ)
+		}
+		if d.unwrapping[ref] {
+			return nil, fmt.Errorf("%w: devalue %s %d wraps itself", ErrMalformed, typ, ref)
+		}
+		d.unwrapping[ref] = true
+		value, err := d.hydrateRef(args[0])
+		delete(d.unwrapping, ref)
+		if err != nil {
+			return nil, err
+		}
+		d.hydrated[ref] = value
+		return value, nil
+	}
+}
+
+func (d *devalueDecoder) hydrateRef(valueRef any) (any, error) {
+	f, ok := valueRef.(float64)
+	if !ok || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%w: devalue reference %v is not an integer", ErrMalformed, valueRef)
+	}
+	return d.hydrate(int(f))
+}
+
+func devalueConstant(ref int) (any, error) {
+	switch ref {
+	case devalueUndefined, devalueHole:
+		return nil, nil //nolint:nilnil // undefined decodes to nil, like a missing JSON value
+	case devalueNaN:
+		return math.NaN(), nil
+	case devaluePositiveInfinity:
+		return math.Inf(1), nil
+	case devalueNegativeInfinity:
+		return math.Inf(-1), nil
+	case devalueNegativeZero:
+		return math.Copysign(0, -1), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown devalue constant %d", ErrMalformed, ref)
+	}
+}
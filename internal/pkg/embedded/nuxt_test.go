@@ -0,0 +1,116 @@
+package embedded
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeNuxtPayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "object with primitive values",
+			input: `[{"rate":1,"term":2},2.78,"threeMonth"]`,
+			want:  map[string]any{"rate": 2.78, "term": "threeMonth"},
+		},
+		{
+			name:  "nested array",
+			input: `[{"rates":1},[2,3],2.78,3.1]`,
+			want:  map[string]any{"rates": []any{2.78, 3.1}},
+		},
+		{
+			name:  "shared values",
+			input: `[[1,1],"-"]`,
+			want:  []any{"-", "-"},
+		},
+		{
+			name:  "nuxt reactive wrappers are unwrapped",
+			input: `[["ShallowReactive",1],{"data":2},["Reactive",3],{"rate":4},2.78]`,
+			want:  map[string]any{"data": map[string]any{"rate": 2.78}},
+		},
+		{
+			name:  "date as iso string",
+			input: `[{"validFrom":1},["Date","2025-11-10T00:00:00.000Z"]]`,
+			want:  map[string]any{"validFrom": "2025-11-10T00:00:00.000Z"},
+		},
+		{
+			name:  "set as array",
+			input: `[["Set",1,2],"a","b"]`,
+			want:  []any{"a", "b"},
+		},
+		{
+			name:  "map as object",
+			input: `[["Map",1,2],"3M",2.78]`,
+			want:  map[string]any{"3M": 2.78},
+		},
+		{
+			name:  "null prototype object",
+			input: `[["null","rate",1],2.78]`,
+			want:  map[string]any{"rate": 2.78},
+		},
+		{
+			name:  "undefined and holes",
+			input: `[{"a":-1,"b":2},0,[-2]]`,
+			want:  map[string]any{"a": nil, "b": []any{nil}},
+		},
+		{
+			name:  "negative zero",
+			input: `[{"a":-6}]`,
+			want:  map[string]any{"a": math.Copysign(0, -1)},
+		},
+		{
+			name:  "single constant",
+			input: `-1`,
+			want:  nil,
+		},
+		{name: "invalid json", input: `[{"a":1}`, wantErr: ErrMalformed},
+		{name: "empty array", input: `[]`, wantErr: ErrMalformed},
+		{name: "not an array", input: `{"a":1}`, wantErr: ErrMalformed},
+		{name: "reference out of range", input: `[{"a":5}]`, wantErr: ErrMalformed},
+		{name: "non-integer reference", input: `[{"a":1.5},2]`, wantErr: ErrMalformed},
+		{name: "unknown constant", input: `[{"a":-9}]`, wantErr: ErrMalformed},
+		{name: "unsupported type", input: `[["Custom",1,2],1,2]`, wantErr: ErrMalformed},
+		{name: "self-referencing wrapper", input: `[["Reactive",0]]`, wantErr: ErrMalformed},
+		{name: "wrapper cycle", input: `[{"a":1},["Ref",2],["ShallowRef",1]]`, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeNuxtPayload(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeNuxtPayload() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeNuxtPayload() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeNuxtPayload_Cycle(t *testing.T) {
+	t.Parallel()
+
+	got, err := DecodeNuxtPayload(`[{"self":0,"name":1},"root"]`)
+	if err != nil {
+		t.Fatalf("DecodeNuxtPayload() error = %v", err)
+	}
+
+	obj, ok := got.(map[string]any)
+	if !ok {
+		t.Fatalf("DecodeNuxtPayload() = %T, want an object", got)
+	}
+	self, ok := obj["self"].(map[string]any)
+	if !ok || self["name"] != "root" {
+		t.Errorf("self reference = %#v, want the root object", obj["self"])
+	}
+}
@@ -0,0 +1,266 @@
+package embedded
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type stepKind int
+
+const (
+	stepKey stepKind = iota
+	stepIndex
+	stepWildcard
+	stepDescend
+)
+
+type step struct {
+	kind  stepKind
+	key   string
+	index int
+}
+
+// Query returns all values matching path in a decoded value. The path syntax is a small subset of JSONPath:
+//
+//	props.pageProps.page       object keys, separated by dots
+//	rows[0].cells[-1]          array indices, negative ones count from the end
+//	loaderData["routes/_index"] quoted keys, for keys containing dots or brackets
+//	rates[*].term, rates.*     every element of an array or value of an object
+//	..rate_fixation            the values of a key at any depth
+//
+// An empty path matches the value itself. A path that matches nothing returns no values and no error; only a
+// malformed path is an error.
+func Query(value any, path string) ([]any, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := []any{value}
+	for _, s := range steps {
+		var next []any
+		for _, v := range current {
+			next = append(next, s.apply(v)...)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// Get returns the first value matching path, or ErrNotFound.
+func Get(value any, path string) (any, error) {
+	matches, err := Query(value, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	return matches[0], nil
+}
+
+// String returns the first value matching path, which must be a string.
+func String(value any, path string) (string, error) {
+	return getAs[string](value, path)
+}
+
+// Number returns the first value matching path, which must be a number.
+func Number(value any, path string) (float64, error) {
+	return getAs[float64](value, path)
+}
+
+// Array returns the first value matching path, which must be an array.
+func Array(value any, path string) ([]any, error) {
+	return getAs[[]any](value, path)
+}
+
+// Object returns the first value matching path, which must be an object.
+func Object(value any, path string) (map[string]any, error) {
+	return getAs[map[string]any](value, path)
+}
+
+// FindObjects returns every object at any depth of value that has the given key, e.g. all rate entries with a
+// "monthPeriod" in a payload whose layout is not worth spelling out as a path. Objects are returned in a stable order
+// and only once, even if the decoded data references them from several places.
+func FindObjects(value any, key string) []map[string]any {
+	var found []map[string]any
+	walk(value, func(v any) {
+		if obj, ok := v.(map[string]any); ok {
+			if _, ok := obj[key]; ok {
+				found = append(found, obj)
+			}
+		}
+	})
+	return found
+}
+
+func getAs[T any](value any, path string) (T, error) {
+	var zero T
+	match, err := Get(value, path)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := match.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is a %T, want %T", ErrWrongType, path, match, zero)
+	}
+	return typed, nil
+}
+
+func (s step) apply(value any) []any {
+	switch s.kind {
+	case stepKey:
+		if obj, ok := value.(map[string]any); ok {
+			if v, ok := obj[s.key]; ok {
+				return []any{v}
+			}
+		}
+	case stepIndex:
+		if arr, ok := value.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []any{arr[i]}
+			}
+		}
+	case stepWildcard:
+		return children(value)
+	case stepDescend:
+		var matches []any
+		walk(value, func(v any) {
+			if obj, ok := v.(map[string]any); ok {
+				if child, ok := obj[s.key]; ok {
+					matches = append(matches, child)
+				}
+			}
+		})
+		return matches
+	}
+	return nil
+}
+
+// children returns the elements of an array or the values of an object, the latter sorted by key.
+func children(value any) []any {
+	switch v := value.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		values := make([]any, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, v[key])
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// walk calls visit for value and everything below it, depth first. Decoded devalue and turbo-stream data may share
+// or even cycle through objects, so every object and array is visited only once.
+func walk(value any, visit func(any)) {
+	seen := make(map[uintptr]bool)
+	var rec func(v any)
+	rec = func(v any) {
+		switch v.(type) {
+		case map[string]any, []any:
+			ptr := reflect.ValueOf(v).Pointer()
+			if ptr != 0 && seen[ptr] {
+				return
+			}
+			seen[ptr] = true
+		}
+		visit(v)
+		for _, child := range children(v) {
+			rec(child)
+		}
+	}
+	rec(value)
+}
+
+func parsePath(path string) ([]step, error) {
+	var steps []step
+	for pos := 0; pos < len(path); {
+		var s step
+		var err error
+		switch {
+		case strings.HasPrefix(path[pos:], ".."):
+			var name string
+			name, pos = readName(path, pos+2)
+			s, err = step{kind: stepDescend, key: name}, requireName(name, path)
+		case path[pos] == '.':
+			var name string
+			name, pos = readName(path, pos+1)
+			s, err = nameStep(name), requireName(name, path)
+		case path[pos] == '[':
+			s, pos, err = parseBracket(path, pos)
+		case pos == 0:
+			var name string
+			name, pos = readName(path, pos)
+			s = nameStep(name)
+		default:
+			err = fmt.Errorf("%w: unexpected %q at %d in %q", ErrInvalidPath, path[pos], pos, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+func parseBracket(path string, pos int) (step, int, error) {
+	end := strings.IndexByte(path[pos:], ']')
+	if end < 0 {
+		return step{}, 0, fmt.Errorf("%w: unclosed bracket in %q", ErrInvalidPath, path)
+	}
+	inner := path[pos+1 : pos+end]
+	next := pos + end + 1
+
+	if inner == "*" {
+		return step{kind: stepWildcard}, next, nil
+	}
+	if strings.HasPrefix(inner, `"`) {
+		key, err := strconv.Unquote(inner)
+		if err != nil {
+			return step{}, 0, fmt.Errorf("%w: bad quoted key %s in %q", ErrInvalidPath, inner, path)
+		}
+		return step{kind: stepKey, key: key}, next, nil
+	}
+	i, err := strconv.Atoi(inner)
+	if err != nil {
+		return step{}, 0, fmt.Errorf("%w: bad index %q in %q", ErrInvalidPath, inner, path)
+	}
+	return step{kind: stepIndex, index: i}, next, nil
+}
+
+func readName(path string, pos int) (string, int) {
+	end := pos
+	for end < len(path) && path[end] != '.' && path[end] != '[' {
+		end++
+	}
+	return path[pos:end], end
+}
+
+func nameStep(name string) step {
+	if name == "*" {
+		return step{kind: stepWildcard}
+	}
+	return step{kind: stepKey, key: name}
+}
+
+func requireName(name, path string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty key in %q", ErrInvalidPath, path)
+	}
+	return nil
+}
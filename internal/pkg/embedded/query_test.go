@@ -0,0 +1,116 @@
+package embedded
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testDocument() any {
+	return map[string]any{
+		"props": map[string]any{
+			"pageProps": map[string]any{
+				"rates": []any{
+					map[string]any{"term": "3M", "rate": 3.33},
+					map[string]any{"term": "1Y", "rate": 3.5},
+				},
+			},
+		},
+		"loaderData": map[string]any{
+			"routes/_index": map[string]any{"title": "Räntor"},
+		},
+	}
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []any
+		wantErr error
+	}{
+		{name: "empty path", path: "", want: []any{testDocument()}},
+		{name: "dotted keys", path: "props.pageProps.rates[0].term", want: []any{"3M"}},
+		{name: "leading bracket", path: `["loaderData"]["routes/_index"].title`, want: []any{"Räntor"}},
+		{name: "negative index", path: "props.pageProps.rates[-1].term", want: []any{"1Y"}},
+		{name: "array wildcard", path: "props.pageProps.rates[*].rate", want: []any{3.33, 3.5}},
+		{name: "dot wildcard", path: "props.pageProps.rates.*.term", want: []any{"3M", "1Y"}},
+		{name: "object wildcard sorted by key", path: "props.pageProps.rates[0].*", want: []any{3.33, "3M"}},
+		{name: "recursive descent", path: "..term", want: []any{"3M", "1Y"}},
+		{name: "recursive descent below key", path: "loaderData..title", want: []any{"Räntor"}},
+		{name: "missing key", path: "props.missing", want: nil},
+		{name: "index out of range", path: "props.pageProps.rates[5]", want: nil},
+		{name: "index on object", path: "props[0]", want: nil},
+		{name: "unclosed bracket", path: "props[0", wantErr: ErrInvalidPath},
+		{name: "bad index", path: "props[x]", wantErr: ErrInvalidPath},
+		{name: "bad quoted key", path: `props["x]`, wantErr: ErrInvalidPath},
+		{name: "empty key", path: "props..", wantErr: ErrInvalidPath},
+		{name: "trailing dot", path: "props.", wantErr: ErrInvalidPath},
+		{name: "key after bracket without dot", path: "props[0]x", wantErr: ErrInvalidPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Query(testDocument(), tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Query(%q) error = %v, want %v", tt.path, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	t.Parallel()
+
+	doc := testDocument()
+
+	if got, err := String(doc, "props.pageProps.rates[0].term"); err != nil || got != "3M" {
+		t.Errorf("String() = %q, %v, want 3M", got, err)
+	}
+	if got, err := Number(doc, "props.pageProps.rates[1].rate"); err != nil || got != 3.5 {
+		t.Errorf("Number() = %v, %v, want 3.5", got, err)
+	}
+	if got, err := Array(doc, "props.pageProps.rates"); err != nil || len(got) != 2 {
+		t.Errorf("Array() = %v, %v, want 2 elements", got, err)
+	}
+	if got, err := Object(doc, "loaderData"); err != nil || len(got) != 1 {
+		t.Errorf("Object() = %v, %v, want 1 key", got, err)
+	}
+	if _, err := String(doc, "props.pageProps.rates[0].rate"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("String() on a number error = %v, want %v", err, ErrWrongType)
+	}
+	if _, err := Number(doc, "props.missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Number() on a missing key error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := Get(doc, "props["); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("Get() on a malformed path error = %v, want %v", err, ErrInvalidPath)
+	}
+}
+
+func TestFindObjects(t *testing.T) {
+	t.Parallel()
+
+	shared := map[string]any{"monthPeriod": "2025-11", "threeMonth": "2.69"}
+	doc := map[string]any{
+		"a": []any{shared, map[string]any{"monthPeriod": "2025-10"}},
+		"b": shared,
+		"c": map[string]any{"other": 1.0},
+	}
+	doc["self"] = doc
+
+	got := FindObjects(doc, "monthPeriod")
+	want := []map[string]any{shared, {"monthPeriod": "2025-10"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindObjects() = %#v, want %#v", got, want)
+	}
+	if got := FindObjects(doc, "missing"); got != nil {
+		t.Errorf("FindObjects() for a missing key = %#v, want nil", got)
+	}
+}
@@ -0,0 +1,254 @@
+package embedded
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// turbo-stream encodes these values as negative references instead of array entries.
+const (
+	turboHole             = -1
+	turboNaN              = -2
+	turboNegativeInfinity = -3
+	turboNegativeZero     = -4
+	turboNull             = -5
+	turboPositiveInfinity = -6
+	turboUndefined        = -7
+)
+
+var remixEnqueueRegex = regexp.MustCompile(`streamController\.enqueue\(("(?:[^"\\]|\\.)*")\)`)
+
+// DecodeRemixContext decodes the loader data a Remix (or React Router 7) page inlines into its HTML through
+// window.__remixContext.streamController.enqueue(...) calls. The result is the root object with its "loaderData".
+func DecodeRemixContext(rawHTML string) (any, error) {
+	stream, err := ExtractRemixStream(rawHTML)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTurboStream(stream)
+}
+
+// ExtractRemixStream concatenates the turbo-stream chunks a Remix page inlines into its HTML.
+func ExtractRemixStream(rawHTML string) (string, error) {
+	matches := remixEnqueueRegex.FindAllStringSubmatch(rawHTML, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("%w: no Remix stream chunks in page", ErrNotFound)
+	}
+
+	var stream strings.Builder
+	for _, m := range matches {
+		chunk, err := unquoteJSString(m[1])
+		if err != nil {
+			return "", err
+		}
+		stream.WriteString(chunk)
+	}
+	return stream.String(), nil
+}
+
+// DecodeTurboStream decodes a turbo-stream as used by Remix single fetch. The first line is a flat JSON array where
+// entry 0 is the root; objects are written as {"_<key index>": <value index>} and arrays hold value indices. Dates are
+// returned as RFC 3339 strings, sets as arrays and maps as objects. Promises resolved in later lines of the stream
+// are not followed and decode to nil.
+func DecodeTurboStream(stream string) (any, error) {
+	line, _, _ := strings.Cut(stream, "\n")
+	root, err := unmarshal(line)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := root.(type) {
+	case []any:
+		if len(v) == 0 {
+			return nil, fmt.Errorf("%w: empty turbo-stream array", ErrMalformed)
+		}
+		d := &turboDecoder{values: v, hydrated: make(map[int]any, len(v)), unwrapping: make(map[int]bool)}
+		return d.hydrate(0)
+	case float64:
+		return turboConstant(int(v))
+	default:
+		return nil, fmt.Errorf("%w: turbo-stream is a %T, want an array", ErrMalformed, root)
+	}
+}
+
+type turboDecoder struct {
+	values   []any
+	hydrated map[int]any
+	// unwrapping holds the "Z" references being resolved, which have no value of their own to cache beforehand.
+	unwrapping map[int]bool
+}
+
+func (d *turboDecoder) hydrate(ref int) (any, error) {
+	if ref < 0 {
+		return turboConstant(ref)
+	}
+	if ref >= len(d.values) {
+		return nil, fmt.Errorf("%w: turbo-stream reference %d out of range", ErrMalformed, ref)
+	}
+	if value, ok := d.hydrated[ref]; ok {
+		return value, nil
+	}
+
+	switch v := d.values[ref].(type) {
+	case map[string]any:
+		obj := make(map[string]any, len(v))
+		d.hydrated[ref] = obj // before the fields, so that cycles resolve to this object
+		if err := d.fillObject(obj, v); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case []any:
+		if len(v) > 0 {
+			if typ, ok := v[0].(string); ok {
+				return d.hydrateSpecial(ref, typ, v[1:])
+			}
+		}
+		arr := make([]any, len(v))
+		d.hydrated[ref] = arr
+		for i, valueRef := range v {
+			value, err := d.hydrateRef(valueRef)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = value
+		}
+		return arr, nil
+	default:
+		d.hydrated[ref] = v
+		return v, nil
+	}
+}
+
+// fillObject resolves the "_<key index>" keys and value indices of an encoded object into obj.
+func (d *turboDecoder) fillObject(obj, encoded map[string]any) error {
+	for encodedKey, valueRef := range encoded {
+		key := encodedKey
+		if keyRef, ok := strings.CutPrefix(encodedKey, "_"); ok {
+			i, err := strconv.Atoi(keyRef)
+			if err != nil {
+				return fmt.Errorf("%w: turbo-stream key %q", ErrMalformed, encodedKey)
+			}
+			resolved, err := d.hydrate(i)
+			if err != nil {
+				return err
+			}
+			key = fmt.Sprint(resolved)
+		}
+
+		value, err := d.hydrateRef(valueRef)
+		if err != nil {
+			return err
+		}
+		obj[key] = value
+	}
+	return nil
+}
+
+// hydrateSpecial decodes a typed array like ["D", 1735689600000] or ["S", 4, 5].
+func (d *turboDecoder) hydrateSpecial(ref int, typ string, args []any) (any, error) {
+	switch typ {
+	case "D":
+		millis, ok := firstArg(args).(float64)
+		if !ok {
+			return nil, fmt.Errorf("%w: turbo-stream date without timestamp", ErrMalformed)
+		}
+		date := time.UnixMilli(int64(millis)).UTC().Format(time.RFC3339Nano)
+		d.hydrated[ref] = date
+		return date, nil
+	case "B", "R", "U", "Y":
+		// BigInt digits, regexp source, URL and symbol name are stored as literal strings.
+		d.hydrated[ref] = firstArg(args)
+		return firstArg(args), nil
+	case "S":
+		arr := make([]any, len(args))
+		d.hydrated[ref] = arr
+		for i, valueRef := range args {
+			value, err := d.hydrateRef(valueRef)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = value
+		}
+		return arr, nil
+	case "M":
+		obj := make(map[string]any, len(args)/2)
+		d.hydrated[ref] = obj
+		for i := 0; i+1 < len(args); i += 2 {
+			key, err := d.hydrateRef(args[i])
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.hydrateRef(args[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj[fmt.Sprint(key)] = value
+		}
+		return obj, nil
+	case "N":
+		obj := make(map[string]any)
+		d.hydrated[ref] = obj
+		if encoded, ok := firstArg(args).(map[string]any); ok {
+			if err := d.fillObject(obj, encoded); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	case "E":
+		errObj := map[string]any{"message": firstArg(args)}
+		d.hydrated[ref] = errObj
+		return errObj, nil
+	case "Z":
+		if d.unwrapping[ref] {
+			return nil, fmt.Errorf("%w: turbo-stream reference %d refers to itself", ErrMalformed, ref)
+		}
+		d.unwrapping[ref] = true
+		value, err := d.hydrateRef(firstArg(args))
+		delete(d.unwrapping, ref)
+		if err != nil {
+			return nil, err
+		}
+		d.hydrated[ref] = value
+		return value, nil
+	default:
+		// Promises ("P") resolve in later chunks, plugin types are application specific.
+		d.hydrated[ref] = nil
+		return nil, nil //nolint:nilnil // unresolvable values decode to nil, like undefined
+	}
+}
+
+func (d *turboDecoder) hydrateRef(valueRef any) (any, error) {
+	f, ok := valueRef.(float64)
+	if !ok || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%w: turbo-stream reference %v is not an integer", ErrMalformed, valueRef)
+	}
+	return d.hydrate(int(f))
+}
+
+func firstArg(args []any) any {
+	if len(args) == 0 {
+		return nil
+	}
+	return args[0]
+}
+
+func turboConstant(ref int) (any, error) {
+	switch ref {
+	case turboHole, turboNull, turboUndefined:
+		return nil, nil //nolint:nilnil // null and undefined decode to nil, like a JSON null
+	case turboNaN:
+		return math.NaN(), nil
+	case turboNegativeInfinity:
+		return math.Inf(-1), nil
+	case turboPositiveInfinity:
+		return math.Inf(1), nil
+	case turboNegativeZero:
+		return math.Copysign(0, -1), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown turbo-stream constant %d", ErrMalformed, ref)
+	}
+}
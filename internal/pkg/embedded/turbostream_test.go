@@ -0,0 +1,125 @@
+package embedded
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTurboStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "object with indexed keys",
+			input: `[{"_1":2},"bps",333]`,
+			want:  map[string]any{"bps": 333.0},
+		},
+		{
+			name:  "nested objects and arrays",
+			input: `[{"_1":2},"rates",[3],{"_4":5},"rate_fixation","3M"]`,
+			want:  map[string]any{"rates": []any{map[string]any{"rate_fixation": "3M"}}},
+		},
+		{
+			name:  "date as rfc 3339 string",
+			input: `[{"_1":2},"updated",["D",1735689600000]]`,
+			want:  map[string]any{"updated": "2025-01-01T00:00:00Z"},
+		},
+		{
+			name:  "set as array",
+			input: `[["S",1,2],"a","b"]`,
+			want:  []any{"a", "b"},
+		},
+		{
+			name:  "map as object",
+			input: `[["M",1,2],"3M",333]`,
+			want:  map[string]any{"3M": 333.0},
+		},
+		{
+			name:  "null prototype object",
+			input: `[["N",{"_1":2}],"bps",333]`,
+			want:  map[string]any{"bps": 333.0},
+		},
+		{
+			name:  "error",
+			input: `[["E","boom"]]`,
+			want:  map[string]any{"message": "boom"},
+		},
+		{
+			name:  "null, undefined and holes",
+			input: `[{"_1":-5,"_2":-7},"a","b"]`,
+			want:  map[string]any{"a": nil, "b": nil},
+		},
+		{
+			name:  "unresolved promise",
+			input: `[{"_1":2},"later",["P",3]]` + "\n" + `P3:[1]`,
+			want:  map[string]any{"later": nil},
+		},
+		{name: "invalid json", input: `[{"_1":2}`, wantErr: ErrMalformed},
+		{name: "empty array", input: `[]`, wantErr: ErrMalformed},
+		{name: "not an array", input: `"x"`, wantErr: ErrMalformed},
+		{name: "reference out of range", input: `[{"_1":9},"a"]`, wantErr: ErrMalformed},
+		{name: "bad key", input: `[{"_x":1},"a"]`, wantErr: ErrMalformed},
+		{name: "date without timestamp", input: `[["D"]]`, wantErr: ErrMalformed},
+		{name: "self-referencing Z", input: `[["Z",0]]`, wantErr: ErrMalformed},
+		{name: "Z cycle", input: `[{"_1":2},"a",["Z",3],["Z",2]]`, wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeTurboStream(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeTurboStream() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeTurboStream() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRemixContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "single chunk",
+			input: `<script>window.__remixContext.streamController.enqueue(` +
+				`"[{\"_1\":2},\"loaderData\",{\"_3\":4},\"root\",null]\n");</script>`,
+			want: map[string]any{"loaderData": map[string]any{"root": nil}},
+		},
+		{
+			name: "chunks split mid value",
+			input: `<script>window.__remixContext.streamController.enqueue("[{\"_1\":2},\"ra");</script>` +
+				`<script>window.__remixContext.streamController.enqueue("te\",333]\n");</script>`,
+			want: map[string]any{"rate": 333.0},
+		},
+		{name: "no stream", input: `<html></html>`, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeRemixContext(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeRemixContext() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeRemixContext() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package model
+
+const (
+	BankCategoryStandard  BankCategory = "standard"  // banks and mortgage institutions lending to prime borrowers
+	BankCategorySpecialty BankCategory = "specialty" // non-prime lenders with a much wider rate range
+	BankCategoryBenchmark BankCategory = "benchmark" // market statistics, no lender
+
+	// BenchmarkBank is the market-wide average rate of new mortgages published by Statistics Sweden (SCB). It is
+	// stored like a bank's average rates, so that every bank's average rates can be compared with it.
+	BenchmarkBank Bank = "SCB"
+)
+
+type BankCategory string
+
+// BankGroup is a banking group whose brands lend from the same balance sheet, like NOBA Bank Group for Nordax Bank and
+// Svensk Hypotekspension. Their offers are alternatives to each other rather than competing offers.
+type BankGroup string
+
+// BankProfile describes static, crawler-independent facts about a bank.
+type BankProfile struct {
+	Bank     Bank
+	Category BankCategory
+	Terms    []Term    // all terms the bank is known to publish, across all rate types
+	Group    BankGroup // empty if the bank forms a group of its own
+}
+
+// HasTerm reports whether the term is one the bank is known to publish.
+func (p BankProfile) HasTerm(term Term) bool {
+	for _, t := range p.Terms {
+		if t == term {
+			return true
+		}
+	}
+	return false
+}
+
+// BankGroups maps every bank of the profiles that belongs to a group to that group.
+func BankGroups(profiles []BankProfile) map[Bank]BankGroup {
+	groups := make(map[Bank]BankGroup)
+	for _, p := range profiles {
+		if p.Group != "" {
+			groups[p.Bank] = p.Group
+		}
+	}
+	return groups
+}
+
+// GroupOf returns the group of a product: the group of the lender for a distributed loan, otherwise of the bank. A bank
+// without a group forms one of its own, named after the bank.
+func GroupOf(groups map[Bank]BankGroup, bank, lender Bank) BankGroup {
+	if lender != "" {
+		bank = lender
+	}
+	if group, ok := groups[bank]; ok {
+		return group
+	}
+	return BankGroup(bank)
+}
+
+// BankProfiles returns the catalogue of all crawled banks.
+func BankProfiles() []BankProfile {
+	allTerms := []Term{
+		Term3months, Term6months, Term1year, Term2years, Term3years, Term4years,
+		Term5years, Term6years, Term7years, Term8years, Term9years, Term10years,
+	}
+	sparbankTerms := []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}
+	// Banks lending from another group's balance sheet. Distributors like Avanza are grouped by the Lender of each set.
+	const (
+		borgoGroup BankGroup = "Borgo"
+		enityGroup BankGroup = "Enity Bank Group"
+		nobaGroup  BankGroup = "NOBA Bank Group"
+	)
+	borgoTerms := []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}
+
+	return []BankProfile{
+		{Bank: "Avanza", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term10years}},
+		{Bank: "Bluestep", Category: BankCategorySpecialty, Terms: []Term{Term3months, Term1year, Term3years, Term5years}, Group: enityGroup},
+		{Bank: "Bergslagens Sparbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Borgo", Category: BankCategoryStandard, Terms: borgoTerms, Group: borgoGroup},
+		{Bank: "Danske Bank", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term6years, Term10years}},
+		{Bank: "Ekobanken", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term3years}},
+		{Bank: "Falkenbergs Sparbank", Category: BankCategoryStandard, Terms: sparbankTerms},
+		{Bank: "Handelsbanken", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Hypoteket", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term5years}},
+		{Bank: "ICA Banken", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}},
+		{Bank: "Ikano Bank", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}, Group: borgoGroup},
+		{Bank: "JAK Medlemsbank", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year}},
+		{Bank: "Landshypotek", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years}},
+		{Bank: "Länsförsäkringar", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}},
+		{Bank: "Marginalen Bank", Category: BankCategorySpecialty, Terms: []Term{Term3months, Term6months, Term1year, Term2years, Term3years}},
+		{Bank: "Nordax Bank", Category: BankCategorySpecialty, Terms: []Term{Term3months, Term3years, Term5years}, Group: nobaGroup},
+		{Bank: "Nordea", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Nordnet", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term5years, Term10years}},
+		{Bank: "Sala Sparbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "SBAB", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}},
+		{Bank: BenchmarkBank, Category: BankCategoryBenchmark, Terms: []Term{Term3months, Term1year, Term3years, Term5years, Term10years}},
+		{Bank: "SEB", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Skandia", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Alingsås", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Eken", Category: BankCategoryStandard, Terms: sparbankTerms},
+		{Bank: "Sparbanken Lidköping", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Nord", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Rekarne", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Sjuhärad", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Skaraborg", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Skåne", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Sparbanken Syd", Category: BankCategoryStandard, Terms: sparbankTerms},
+		{Bank: "Stabelo", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term5years, Term10years}},
+		{Bank: "Svea Bank", Category: BankCategorySpecialty, Terms: []Term{TermVariable}},
+		{Bank: "Svensk Hypotekspension", Category: BankCategorySpecialty, Terms: []Term{Term3months, Term1year, Term3years, Term5years}, Group: nobaGroup},
+		{Bank: "Swedbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Söderberg & Partners", Category: BankCategoryStandard, Terms: borgoTerms, Group: borgoGroup},
+		{Bank: "Sörmlands Sparbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Varbergs Sparbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Vimmerby Sparbank", Category: BankCategoryStandard, Terms: allTerms},
+		{Bank: "Ålandsbanken", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term4years, Term5years, Term7years, Term10years}},
+		{Bank: "Ölands Bank", Category: BankCategoryStandard, Terms: []Term{Term3months, Term1year, Term2years, Term3years, Term5years}},
+	}
+}
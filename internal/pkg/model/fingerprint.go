@@ -0,0 +1,43 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// Fingerprint describes the structure of a source a crawler parsed, e.g. the header texts of its tables or the key
+// paths of its JSON, but none of the rates in it. A changed fingerprint means the bank changed the layout of the source.
+type Fingerprint struct {
+	Bank   Bank   `json:"bank"`
+	Source string `json:"source"` // URL of the parsed page, API or document
+	// Features are the structural features of the source, sorted and without duplicates.
+	Features   []string  `json:"features"`
+	CapturedAt time.Time `json:"capturedAt"`
+}
+
+// FingerprintDiff lists how the features of a source changed between two fingerprints.
+type FingerprintDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// Empty reports whether the structure did not change.
+func (d FingerprintDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Diff returns the features f has and previous lacks as Added, and the other way round as Removed.
+func (f Fingerprint) Diff(previous Fingerprint) FingerprintDiff {
+	var diff FingerprintDiff
+	for _, feature := range f.Features {
+		if !slices.Contains(previous.Features, feature) {
+			diff.Added = append(diff.Added, feature)
+		}
+	}
+	for _, feature := range previous.Features {
+		if !slices.Contains(f.Features, feature) {
+			diff.Removed = append(diff.Removed, feature)
+		}
+	}
+	return diff
+}
@@ -1,23 +1,12 @@
 package model
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
 const (
-	Term3months Term = "3m"
-	Term6months Term = "6m"
-	Term1year   Term = "1y"
-	Term2years  Term = "2y"
-	Term3years  Term = "3y"
-	Term4years  Term = "4y"
-	Term5years  Term = "5y"
-	Term6years  Term = "6y"
-	Term7years  Term = "7y"
-	Term8years  Term = "8y"
-	Term9years  Term = "9y"
-	Term10years Term = "10y"
-
 	TypeListRate        Type = "listRate"
 	TypeAverageRate     Type = "averageRate"
 	TypeRatioDiscounted Type = "ratioDiscountedRate"
@@ -25,30 +14,122 @@ const (
 )
 
 type (
-	Term string
 	Type string
 	Bank string
 )
 
+// EnergyClass is the energy performance class (energiklass) of a property, from A (best) to G (worst).
+type EnergyClass string
+
+const (
+	EnergyClassA EnergyClass = "A"
+	EnergyClassB EnergyClass = "B"
+	EnergyClassC EnergyClass = "C"
+	EnergyClassD EnergyClass = "D"
+	EnergyClassE EnergyClass = "E"
+	EnergyClassF EnergyClass = "F"
+	EnergyClassG EnergyClass = "G"
+)
+
+// Valid reports whether c is one of the classes A to G.
+func (c EnergyClass) Valid() bool {
+	return len(c) == 1 && c >= EnergyClassA && c <= EnergyClassG
+}
+
+// Qualifies reports whether a property of class c meets the requirement of class maxClass or better.
+func (c EnergyClass) Qualifies(maxClass EnergyClass) bool {
+	return c.Valid() && maxClass.Valid() && c <= maxClass
+}
+
 type RatioDiscountBoundary struct {
 	MinRatio float32 `json:"minRatio"`
 	MaxRatio float32 `json:"maxRatio"`
 }
 
+// LoanAmountBoundary limits a discounted rate to loans within [MinAmount, MaxAmount] SEK. MaxAmount 0 means unbounded.
+type LoanAmountBoundary struct {
+	MinAmount uint `json:"minAmount"`
+	MaxAmount uint `json:"maxAmount"`
+}
+
+// AverageSegment tells apart the average rate series of a bank that publishes several per term and month, e.g. one per
+// risk class, loan-to-value band or loan size. Label names the series as published, the boundaries are only set if the
+// series is limited to loans within them. MaxRatio and MaxAmount 0 mean unbounded.
+type AverageSegment struct {
+	Label     string  `json:"label"`
+	MinRatio  float32 `json:"minRatio,omitempty"`
+	MaxRatio  float32 `json:"maxRatio,omitempty"`
+	MinAmount uint    `json:"minAmount,omitempty"`
+	MaxAmount uint    `json:"maxAmount,omitempty"`
+}
+
+// Contains reports whether a loan with the loan-to-value ratio and amount falls within the boundaries of the segment.
+func (s AverageSegment) Contains(ratio, amount float64) bool {
+	if ratio < float64(s.MinRatio) || (s.MaxRatio > 0 && ratio > float64(s.MaxRatio)) {
+		return false
+	}
+	return amount >= float64(s.MinAmount) && (s.MaxAmount == 0 || amount <= float64(s.MaxAmount))
+}
+
+// Bounded reports whether the segment limits the loan-to-value ratio or loan amount, rather than only naming a series.
+func (s AverageSegment) Bounded() bool {
+	return s.MinRatio > 0 || s.MaxRatio > 0 || s.MinAmount > 0 || s.MaxAmount > 0
+}
+
+// InterestSet is a single published rate. A set of type unionDiscounted only applies to members of one of its
+// UnionOrganisations, like "Saco" or "TCO".
 type InterestSet struct {
 	Bank          Bank       `json:"bank"`
 	Type          Type       `json:"type"`
 	Term          Term       `json:"term"`
-	NominalRate   float32    `json:"nominalRate"`
+	NominalRate   Rate       `json:"nominalRate"`
 	ChangedOn     *time.Time `json:"changedOn"`
 	LastCrawledAt time.Time  `json:"lastCrawledAt"`
 
-	RatioDiscountBoundaries *RatioDiscountBoundary `json:"ratioDiscountBoundaries"` // only for type ratioDiscounted
-	UnionDiscount           bool                   `json:"unionDiscount"`           // only for type unionDiscounted
-	AverageReferenceMonth   *AvgMonth              `json:"averageReferenceMonth"`   // only for type averageRate
+	Lender                  Bank                   `json:"lender,omitempty"`               // only if Bank distributes another lender's loan
+	RatioDiscountBoundaries *RatioDiscountBoundary `json:"ratioDiscountBoundaries"`        // only for type ratioDiscounted
+	LoanAmountBoundaries    *LoanAmountBoundary    `json:"loanAmountBoundaries,omitempty"` // only for discounted types
+	MaxEnergyClass          EnergyClass            `json:"maxEnergyClass,omitempty"`       // only for green loan discounts
+	UnionDiscount           bool                   `json:"unionDiscount"`                  // only for type unionDiscounted
+	UnionOrganisations      []string               `json:"unionOrganisations,omitempty"`   // only for type unionDiscounted
+	AverageReferenceMonth   *AvgMonth              `json:"averageReferenceMonth"`          // only for type averageRate
+	AverageSegment          *AverageSegment        `json:"averageSegment,omitempty"`       // only for type averageRate, if the bank publishes several series
+
+	Source *SourceRef `json:"source,omitempty"` // document the rate was parsed from, nil if unknown
 }
 
 type AvgMonth struct {
 	Month time.Month `json:"month"`
 	Year  uint       `json:"year"`
 }
+
+// Key identifies the product and, for average rates, the month and series a rate belongs to. Two sets with the same
+// key are versions of the same rate.
+func (set InterestSet) Key() string {
+	id := fmt.Sprintf("%s|%s|%s", set.Bank, set.Type, set.Term)
+	if set.Lender != "" {
+		id += "|" + string(set.Lender)
+	}
+	if set.AverageReferenceMonth != nil {
+		id += fmt.Sprintf("|%d-%02d", set.AverageReferenceMonth.Year, set.AverageReferenceMonth.Month)
+	}
+	if seg := set.AverageSegment; seg != nil {
+		id += "|segment " + seg.Label
+		if seg.Bounded() {
+			id += fmt.Sprintf(" %.2f-%.2f %d-%d SEK", seg.MinRatio, seg.MaxRatio, seg.MinAmount, seg.MaxAmount)
+		}
+	}
+	if set.RatioDiscountBoundaries != nil {
+		id += fmt.Sprintf("|%.2f-%.2f", set.RatioDiscountBoundaries.MinRatio, set.RatioDiscountBoundaries.MaxRatio)
+	}
+	if set.LoanAmountBoundaries != nil {
+		id += fmt.Sprintf("|%d-%d SEK", set.LoanAmountBoundaries.MinAmount, set.LoanAmountBoundaries.MaxAmount)
+	}
+	if set.MaxEnergyClass != "" {
+		id += "|energy " + string(set.MaxEnergyClass)
+	}
+	if len(set.UnionOrganisations) > 0 {
+		id += "|" + strings.Join(set.UnionOrganisations, ",")
+	}
+	return id
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RateScale is the number of Rate units in one percent. A Rate unit is a hundredth of a basis point, which is finer
+// than any bank publishes, so every published rate is represented exactly.
+const RateScale = 10000
+
+const (
+	rateDecimals           = 4
+	rateUnitsPerBasisPoint = RateScale / 100
+)
+
+var ErrInvalidRate = errors.New("invalid rate")
+
+// Rate is an interest rate in hundredths of a basis point, e.g. Rate(33300) for 3.33%. Unlike a float it compares
+// and subtracts exactly, so 3.33% parsed from a bank page always equals 3.33% read back from the store. It is
+// serialized to JSON as a percentage number, e.g. 3.33.
+type Rate int64
+
+// RateFromPercent converts a percentage like 3.33 to a Rate, rounded to the nearest hundredth of a basis point.
+func RateFromPercent(percent float64) Rate {
+	return Rate(math.Round(percent * RateScale))
+}
+
+// RateFromBasisPoints converts basis points like 333 to a Rate, rounded to the nearest hundredth of a basis point.
+func RateFromBasisPoints(bps float64) Rate {
+	return Rate(math.Round(bps * rateUnitsPerBasisPoint))
+}
+
+// ParseRate parses a plain decimal percentage like "3.33", "-0.5" or the "3.3300" a NUMERIC column returns. Digits
+// beyond the fourth decimal are rounded half away from zero.
+func ParseRate(str string) (Rate, error) {
+	digits, negative := strings.CutPrefix(str, "-")
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, str)
+	}
+
+	roundUp := len(fracPart) > rateDecimals && fracPart[rateDecimals] >= '5'
+	fracPart = (fracPart + strings.Repeat("0", rateDecimals))[:rateDecimals]
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %w", ErrInvalidRate, str, err)
+	}
+	if roundUp {
+		units++
+	}
+	if negative {
+		units = -units
+	}
+
+	return Rate(units), nil
+}
+
+// Percent returns the rate as a percentage, e.g. 3.33. Use it for arithmetic that leaves the exact domain, like
+// effective rate calculations.
+func (r Rate) Percent() float64 {
+	return float64(r) / RateScale
+}
+
+// BasisPoints returns the rate in basis points, e.g. 333 for 3.33%.
+func (r Rate) BasisPoints() float64 {
+	return float64(r) / rateUnitsPerBasisPoint
+}
+
+// Abs returns the absolute value of r, e.g. for the size of a rate change.
+func (r Rate) Abs() Rate {
+	if r < 0 {
+		return -r
+	}
+	return r
+}
+
+// String formats the rate as an exact decimal percentage without trailing zeros, e.g. "3.33" or "4".
+func (r Rate) String() string {
+	sign := ""
+	units := int64(r)
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	whole, frac := units/RateScale, units%RateScale
+	if frac == 0 {
+		return sign + strconv.FormatInt(whole, 10)
+	}
+
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*d", rateDecimals, frac), "0")
+	return sign + strconv.FormatInt(whole, 10) + "." + fracStr
+}
+
+// MarshalJSON writes the rate as a percentage number, e.g. 3.33.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON reads a percentage number. Plain decimals are parsed exactly, numbers in exponent notation through
+// float64.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		return nil
+	}
+
+	if rate, err := ParseRate(str); err == nil {
+		*r = rate
+		return nil
+	}
+
+	percent, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRate, str)
+	}
+	*r = RateFromPercent(percent)
+
+	return nil
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
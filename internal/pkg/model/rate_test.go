@@ -0,0 +1,126 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    Rate
+		wantErr bool
+	}{
+		{input: "3.33", want: 33300},
+		{input: "3.3300", want: 33300},
+		{input: "4", want: 40000},
+		{input: ".5", want: 5000},
+		{input: "0.0001", want: 1},
+		{input: "3.33335", want: 33334},
+		{input: "3.33334", want: 33333},
+		{input: "-0.5", want: -5000},
+		{input: "", wantErr: true},
+		{input: ".", wantErr: true},
+		{input: "3,33", wantErr: true},
+		{input: "1e2", wantErr: true},
+		{input: "NaN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRate(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRate) {
+					t.Errorf("ParseRate(%q) error = %v, want ErrInvalidRate", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRate(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRate(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRate_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rate Rate
+		want string
+	}{
+		{rate: 33300, want: "3.33"},
+		{rate: 40000, want: "4"},
+		{rate: 1, want: "0.0001"},
+		{rate: 20500, want: "2.05"},
+		{rate: -5000, want: "-0.5"},
+		{rate: 0, want: "0"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rate.String(); got != tt.want {
+			t.Errorf("Rate(%d).String() = %q, want %q", int64(tt.rate), got, tt.want)
+		}
+	}
+}
+
+func TestRate_Conversions(t *testing.T) {
+	t.Parallel()
+
+	// 3.33 has no exact float representation, but converts to the same Rate as the parsed text.
+	if got := RateFromPercent(3.33); got != 33300 {
+		t.Errorf("RateFromPercent(3.33) = %d, want 33300", got)
+	}
+	if got := RateFromBasisPoints(333); got != 33300 {
+		t.Errorf("RateFromBasisPoints(333) = %d, want 33300", got)
+	}
+	if got := Rate(33350).BasisPoints(); got != 333.5 {
+		t.Errorf("BasisPoints() = %v, want 333.5", got)
+	}
+	if got := Rate(33300).Percent(); got != 3.33 {
+		t.Errorf("Percent() = %v, want 3.33", got)
+	}
+	if got := Rate(-75).Abs(); got != 75 {
+		t.Errorf("Abs() = %d, want 75", got)
+	}
+}
+
+func TestRate_JSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(InterestSet{NominalRate: 33300})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var set InterestSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if set.NominalRate != 33300 {
+		t.Errorf("round trip NominalRate = %d, want 33300 from %s", set.NominalRate, data)
+	}
+
+	for input, want := range map[string]Rate{`3.33`: 33300, `3.7400000095367432`: 37400, `3.33e0`: 33300, `4`: 40000} {
+		var rate Rate
+		if err := json.Unmarshal([]byte(input), &rate); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", input, err)
+		}
+		if rate != want {
+			t.Errorf("json.Unmarshal(%s) = %d, want %d", input, rate, want)
+		}
+	}
+
+	var rate Rate
+	if err := json.Unmarshal([]byte(`"3.33"`), &rate); err == nil {
+		t.Error(`json.Unmarshal("3.33") succeeded, want error for a string`)
+	}
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	ReferencePolicyRate ReferenceSeries = "policyRate" // Riksbankens styrränta
+	ReferenceSTIBOR3M   ReferenceSeries = "stibor3m"   // 3 months STIBOR, the interbank rate most 3 months mortgages follow
+	ReferenceSWESTR     ReferenceSeries = "swestr"     // overnight transaction rate published by the Riksbank
+)
+
+// ReferenceSeries is a market reference rate the banks price their mortgages against.
+type ReferenceSeries string
+
+// Valid reports whether the series is one of the known reference series.
+func (s ReferenceSeries) Valid() bool {
+	switch s {
+	case ReferencePolicyRate, ReferenceSTIBOR3M, ReferenceSWESTR:
+		return true
+	}
+	return false
+}
+
+// ReferenceRate is the value of a reference series on one day.
+type ReferenceRate struct {
+	Series        ReferenceSeries `json:"series"`
+	Date          time.Time       `json:"date"` // day the rate applies to, midnight UTC
+	Rate          Rate            `json:"rate"`
+	LastCrawledAt time.Time       `json:"lastCrawledAt"`
+
+	Source *SourceRef `json:"source,omitempty"` // document the rate was parsed from, nil if unknown
+}
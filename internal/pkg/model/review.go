@@ -0,0 +1,14 @@
+package model
+
+import (
+	"time"
+)
+
+// PendingReview is a crawled InterestSet that looked suspicious and is held back until it is confirmed.
+type PendingReview struct {
+	ID           string      `json:"id"`
+	Set          InterestSet `json:"set"`
+	PreviousRate *Rate       `json:"previousRate"` // stored rate the new one was compared against, if any
+	Reasons      []string    `json:"reasons"`
+	DetectedAt   time.Time   `json:"detectedAt"`
+}
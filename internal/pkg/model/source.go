@@ -0,0 +1,37 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SourceKind is the format of a fetched document.
+type SourceKind string
+
+const (
+	SourceKindJSON SourceKind = "json"
+	SourceKindHTML SourceKind = "html"
+	SourceKindPDF  SourceKind = "pdf"
+	SourceKindXLSX SourceKind = "xlsx"
+)
+
+// SourceRef points to the fetched document a rate was parsed from. The raw source archive stores every document under
+// its Hash, so the reference leads to the exact bytes the crawler saw. Method names the crawler function that extracted
+// the rate, which tells apart the tables or embedded data of a document holding several.
+type SourceRef struct {
+	URL    string     `json:"url"`
+	Kind   SourceKind `json:"kind"`
+	Method string     `json:"method"`
+	Hash   string     `json:"hash"` // hex SHA-256 of the document
+}
+
+// NewSourceRef returns the reference to the document fetched from url.
+func NewSourceRef(url string, content []byte) SourceRef {
+	return SourceRef{URL: url, Hash: HashContent(content)}
+}
+
+// HashContent returns the hex SHA-256 of a document, the key it is archived under.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
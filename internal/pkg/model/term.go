@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidTerm = errors.New("invalid term")
+
+// Term is the binding period (bindningstid) of a rate: the number of Months the rate is fixed for, or Variable for a
+// rörlig ränta that the bank may change at any time and that has no Months. The zero Term is unknown.
+//
+// It is written as "3m", "18m", "1y", "15y" or "variable" in JSON, the store and rate keys. Whole years are always
+// written in years, so 12 months and 1 year are the same term.
+type Term struct {
+	Months   int
+	Variable bool
+}
+
+// Terms used across the crawlers. Any other number of months is a valid term too, see FixedTerm.
+//
+//nolint:gochecknoglobals // struct values can't be constants, treat them as such
+var (
+	TermVariable = Term{Months: 0, Variable: true}
+	Term1month   = FixedTerm(1)
+	Term3months  = FixedTerm(3)
+	Term6months  = FixedTerm(6)
+	Term1year    = FixedTerm(12)
+	Term2years   = FixedTerm(24)
+	Term3years   = FixedTerm(36)
+	Term4years   = FixedTerm(48)
+	Term5years   = FixedTerm(60)
+	Term6years   = FixedTerm(72)
+	Term7years   = FixedTerm(84)
+	Term8years   = FixedTerm(96)
+	Term9years   = FixedTerm(108)
+	Term10years  = FixedTerm(120)
+	Term15years  = FixedTerm(180)
+)
+
+// FixedTerm returns the term of a rate fixed for the given number of months.
+func FixedTerm(months int) Term {
+	return Term{Months: months, Variable: false}
+}
+
+// ParseTerm parses a term as written by Term.String, e.g. "3m", "18m", "5y" or "variable".
+func ParseTerm(str string) (Term, error) {
+	if str == "variable" {
+		return TermVariable, nil
+	}
+
+	unitsPerMonth := 0
+	number, ok := strings.CutSuffix(str, "m")
+	if ok {
+		unitsPerMonth = 1
+	} else if number, ok = strings.CutSuffix(str, "y"); ok {
+		unitsPerMonth = 12
+	}
+	count, err := strconv.Atoi(number)
+	if unitsPerMonth == 0 || err != nil || count <= 0 || number[0] == '+' {
+		return Term{}, fmt.Errorf("%w: %q", ErrInvalidTerm, str)
+	}
+	return FixedTerm(count * unitsPerMonth), nil
+}
+
+// IsZero reports whether the term is unknown.
+func (t Term) IsZero() bool {
+	return t == Term{}
+}
+
+// String returns the term as "3m", "18m", "5y" or "variable", and "" for the zero Term.
+func (t Term) String() string {
+	switch {
+	case t.Variable:
+		return "variable"
+	case t.Months <= 0:
+		return ""
+	case t.Months%12 == 0:
+		return strconv.Itoa(t.Months/12) + "y"
+	default:
+		return strconv.Itoa(t.Months) + "m"
+	}
+}
+
+// Compare orders terms by their binding period, the variable rate first. It returns -1, 0 or 1 like cmp.Compare.
+func (t Term) Compare(other Term) int {
+	switch {
+	case t == other:
+		return 0
+	case t.Variable:
+		return -1
+	case other.Variable:
+		return 1
+	case t.Months < other.Months:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// MarshalText writes the term as String does, which keeps it a plain string in JSON.
+func (t Term) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText reads a term written by MarshalText. An empty string is the zero Term.
+func (t *Term) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = Term{}
+		return nil
+	}
+
+	term, err := ParseTerm(string(text))
+	if err != nil {
+		return err
+	}
+	*t = term
+	return nil
+}
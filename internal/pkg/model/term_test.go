@@ -0,0 +1,122 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseTerm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    Term
+		wantErr bool
+	}{
+		{input: "1m", want: Term1month},
+		{input: "3m", want: Term3months},
+		{input: "18m", want: FixedTerm(18)},
+		{input: "12m", want: Term1year},
+		{input: "1y", want: Term1year},
+		{input: "15y", want: Term15years},
+		{input: "variable", want: TermVariable},
+		{input: "", wantErr: true},
+		{input: "0m", wantErr: true},
+		{input: "-3m", wantErr: true},
+		{input: "+3m", wantErr: true},
+		{input: "3 m", wantErr: true},
+		{input: "3w", wantErr: true},
+		{input: "y", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTerm(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTerm) {
+					t.Errorf("ParseTerm(%q) error = %v, want ErrInvalidTerm", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTerm(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTerm(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerm_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		term Term
+		want string
+	}{
+		{term: Term1month, want: "1m"},
+		{term: Term3months, want: "3m"},
+		{term: FixedTerm(18), want: "18m"},
+		{term: Term1year, want: "1y"},
+		{term: Term15years, want: "15y"},
+		{term: TermVariable, want: "variable"},
+		{term: Term{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.term.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestTerm_Compare(t *testing.T) {
+	t.Parallel()
+
+	terms := []Term{Term10years, Term3months, TermVariable, FixedTerm(18), Term1year, Term1month}
+	slices.SortFunc(terms, Term.Compare)
+
+	want := []Term{TermVariable, Term1month, Term3months, Term1year, FixedTerm(18), Term10years}
+	if !slices.Equal(terms, want) {
+		t.Errorf("sorted terms = %v, want %v", terms, want)
+	}
+}
+
+func TestTerm_JSON(t *testing.T) {
+	t.Parallel()
+
+	// Terms were plain strings before they got a structure, stored and served JSON must keep its shape.
+	data, err := json.Marshal(InterestSet{Term: Term5years})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if raw["term"] != "5y" {
+		t.Errorf("term = %v, want \"5y\"", raw["term"])
+	}
+
+	terms := map[Term]string{}
+	if err := json.Unmarshal([]byte(`{"3m": "a", "variable": "b", "18m": "c"}`), &terms); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := map[Term]string{Term3months: "a", TermVariable: "b", FixedTerm(18): "c"}
+	if len(terms) != len(want) || terms[Term3months] != "a" || terms[TermVariable] != "b" || terms[FixedTerm(18)] != "c" {
+		t.Errorf("terms = %v, want %v", terms, want)
+	}
+
+	var term Term
+	if err := json.Unmarshal([]byte(`"forever"`), &term); !errors.Is(err, ErrInvalidTerm) {
+		t.Errorf("Unmarshal(forever) error = %v, want ErrInvalidTerm", err)
+	}
+	if err := json.Unmarshal([]byte(`""`), &term); err != nil || !term.IsZero() {
+		t.Errorf("Unmarshal(\"\") = %+v, %v; want zero term", term, err)
+	}
+}
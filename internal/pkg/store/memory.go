@@ -1,6 +1,10 @@
 package store
 
 import (
+	"fmt"
+	"slices"
+	"sync"
+
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
 	"go.uber.org/zap"
@@ -9,25 +13,40 @@ import (
 // Compile-time interface compliance check.
 var _ Store = &MemoryStore{}
 
-// MemoryStore implements Store using in-memory storage.
+// MemoryStore implements Store using in-memory storage. It is safe for concurrent use.
 type MemoryStore struct {
-	logger *zap.Logger
-	data   []model.InterestSet
+	mu           sync.RWMutex
+	logger       *zap.Logger
+	data         []model.InterestSet
+	reviews      []model.PendingReview
+	fingerprints []model.Fingerprint
+	references   []model.ReferenceRate
 }
 
 func NewMemoryStore(_ *pgxpool.Pool, logger *zap.Logger) *MemoryStore {
 	return &MemoryStore{
-		logger: logger,
-		data:   []model.InterestSet{},
+		logger:       logger,
+		data:         []model.InterestSet{},
+		reviews:      []model.PendingReview{},
+		fingerprints: []model.Fingerprint{},
+		references:   []model.ReferenceRate{},
 	}
 }
 
 func alreadyExists(a model.InterestSet, b model.InterestSet) bool {
-	if a.Bank != b.Bank || a.Type != b.Type || a.Term != b.Term {
+	if a.Bank != b.Bank || a.Lender != b.Lender || a.Type != b.Type || a.Term != b.Term || a.MaxEnergyClass != b.MaxEnergyClass {
+		return false
+	}
+
+	if !equalPtr(a.RatioDiscountBoundaries, b.RatioDiscountBoundaries) || !equalPtr(a.LoanAmountBoundaries, b.LoanAmountBoundaries) ||
+		!slices.Equal(a.UnionOrganisations, b.UnionOrganisations) {
 		return false
 	}
 
 	if a.Type == model.TypeAverageRate {
+		if !equalPtr(a.AverageSegment, b.AverageSegment) {
+			return false
+		}
 		if a.AverageReferenceMonth == nil || b.AverageReferenceMonth == nil {
 			return false
 		}
@@ -40,7 +59,22 @@ func alreadyExists(a model.InterestSet, b model.InterestSet) bool {
 	return true
 }
 
+// equalPtr reports whether both pointers are nil or point to equal values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func (s *MemoryStore) UpsertInterestSet(set model.InterestSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.upsertInterestSet(set)
+}
+
+func (s *MemoryStore) upsertInterestSet(set model.InterestSet) error {
 	s.logger.Debug("upserting InterestSet", zap.Any("interestSet", set))
 
 	// Check if an entry with the same Bank, Type, and Term already exists
@@ -49,9 +83,9 @@ func (s *MemoryStore) UpsertInterestSet(set model.InterestSet) error {
 			s.logger.Debug("updating existing InterestSet",
 				zap.String("bank", string(set.Bank)),
 				zap.String("type", string(set.Type)),
-				zap.String("term", string(set.Term)),
-				zap.Float32("oldRate", existing.NominalRate),
-				zap.Float32("newRate", set.NominalRate))
+				zap.Stringer("term", set.Term),
+				zap.Stringer("oldRate", existing.NominalRate),
+				zap.Stringer("newRate", set.NominalRate))
 
 			// Update the existing entry
 			s.data[i] = set
@@ -63,7 +97,7 @@ func (s *MemoryStore) UpsertInterestSet(set model.InterestSet) error {
 	s.logger.Debug("adding new InterestSet",
 		zap.String("bank", string(set.Bank)),
 		zap.String("type", string(set.Type)),
-		zap.String("term", string(set.Term)))
+		zap.Stringer("term", set.Term))
 	s.data = append(s.data, set)
 
 	// todo: warn-log and store somewhere else when overwriting in PG Database, because bank tries to alter history?
@@ -71,5 +105,103 @@ func (s *MemoryStore) UpsertInterestSet(set model.InterestSet) error {
 }
 
 func (s *MemoryStore) GetInterestSets() ([]model.InterestSet, error) {
-	return s.data, nil
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]model.InterestSet{}, s.data...), nil
+}
+
+func (s *MemoryStore) AddPendingReview(review model.PendingReview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.reviews {
+		if existing.ID == review.ID {
+			s.reviews[i] = review
+			return nil
+		}
+	}
+
+	s.reviews = append(s.reviews, review)
+	return nil
+}
+
+func (s *MemoryStore) GetPendingReviews() ([]model.PendingReview, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]model.PendingReview{}, s.reviews...), nil
+}
+
+func (s *MemoryStore) ResolvePendingReview(id string, approve bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, review := range s.reviews {
+		if review.ID != id {
+			continue
+		}
+
+		s.reviews = append(s.reviews[:i], s.reviews[i+1:]...)
+		if !approve {
+			s.logger.Info("discarded pending review", zap.String("id", id))
+			return nil
+		}
+
+		s.logger.Info("approved pending review", zap.String("id", id))
+		return s.upsertInterestSet(review.Set)
+	}
+
+	return fmt.Errorf("pending review %q: %w", id, ErrNotFound)
+}
+
+func (s *MemoryStore) SaveFingerprint(fingerprint model.Fingerprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.fingerprints {
+		if existing.Bank == fingerprint.Bank && existing.Source == fingerprint.Source {
+			s.fingerprints[i] = fingerprint
+			return nil
+		}
+	}
+
+	s.fingerprints = append(s.fingerprints, fingerprint)
+	return nil
+}
+
+func (s *MemoryStore) GetFingerprints() ([]model.Fingerprint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]model.Fingerprint{}, s.fingerprints...), nil
+}
+
+func (s *MemoryStore) UpsertReferenceRate(rate model.ReferenceRate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.references {
+		if existing.Series == rate.Series && existing.Date.Equal(rate.Date) {
+			s.references[i] = rate
+			return nil
+		}
+	}
+
+	s.references = append(s.references, rate)
+	return nil
+}
+
+func (s *MemoryStore) GetReferenceRates(series model.ReferenceSeries) ([]model.ReferenceRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rates := []model.ReferenceRate{}
+	for _, rate := range s.references {
+		if rate.Series == series {
+			rates = append(rates, rate)
+		}
+	}
+	slices.SortFunc(rates, func(a, b model.ReferenceRate) int { return a.Date.Compare(b.Date) })
+	return rates, nil
 }
@@ -2,6 +2,7 @@
 package store
 
 import (
+	"errors"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -12,7 +13,7 @@ import (
 )
 
 // generateInterestSet generates random interest sets for testing.
-func generateInterestSet(interestType model.Type, term model.Term, rate float32) model.InterestSet {
+func generateInterestSet(interestType model.Type, term model.Term, rate float64) model.InterestSet {
 	baseTime := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
 
 	// Random bank names
@@ -22,7 +23,7 @@ func generateInterestSet(interestType model.Type, term model.Term, rate float32)
 		Bank:          banks[rand.Intn(len(banks))],
 		Type:          interestType,
 		Term:          term,
-		NominalRate:   rate,
+		NominalRate:   model.RateFromPercent(rate),
 		ChangedOn:     nil,
 		LastCrawledAt: baseTime.Add(time.Duration(rand.Intn(24)) * time.Hour),
 	}
@@ -41,6 +42,7 @@ func generateInterestSet(interestType model.Type, term model.Term, rate float32)
 		}
 	case model.TypeUnionDiscounted:
 		set.UnionDiscount = true
+		set.UnionOrganisations = []string{"Saco"}
 	}
 
 	return set
@@ -202,7 +204,7 @@ func TestMemoryStore_UpsertInterestSet_NonMatchingCases(t *testing.T) {
 		Bank:        "Nordea",
 		Type:        model.TypeListRate,
 		Term:        model.Term1year,
-		NominalRate: 3.5,
+		NominalRate: model.RateFromPercent(3.5),
 	}
 
 	tests := []struct {
@@ -218,7 +220,7 @@ func TestMemoryStore_UpsertInterestSet_NonMatchingCases(t *testing.T) {
 				Bank:        "SEB", // Different bank
 				Type:        model.TypeListRate,
 				Term:        model.Term1year,
-				NominalRate: 3.6,
+				NominalRate: model.RateFromPercent(3.6),
 			},
 			wantCount: 2,
 		},
@@ -229,7 +231,7 @@ func TestMemoryStore_UpsertInterestSet_NonMatchingCases(t *testing.T) {
 				Bank:        "Nordea",
 				Type:        model.TypeRatioDiscounted, // Different type
 				Term:        model.Term1year,
-				NominalRate: 3.2,
+				NominalRate: model.RateFromPercent(3.2),
 			},
 			wantCount: 2,
 		},
@@ -240,7 +242,67 @@ func TestMemoryStore_UpsertInterestSet_NonMatchingCases(t *testing.T) {
 				Bank:        "Nordea",
 				Type:        model.TypeListRate,
 				Term:        model.Term5years, // Different term
-				NominalRate: 4.0,
+				NominalRate: model.RateFromPercent(4.0),
+			},
+			wantCount: 2,
+		},
+		{
+			name: "different ratio discount tier adds new entry",
+			existing: model.InterestSet{
+				Bank: "Nordea", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(3.2),
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+			},
+			newEntry: model.InterestSet{
+				Bank: "Nordea", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(3.4),
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0.6, MaxRatio: 0.75},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "same ratio discount tier updates existing entry",
+			existing: model.InterestSet{
+				Bank: "Nordea", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(3.2),
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+			},
+			newEntry: model.InterestSet{
+				Bank: "Nordea", Type: model.TypeRatioDiscounted, Term: model.Term1year, NominalRate: model.RateFromPercent(3.1),
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+			},
+			wantCount: 1,
+		},
+		{
+			name: "different average rate series adds new entry",
+			existing: model.InterestSet{
+				Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(6.1),
+				AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025}, AverageSegment: &model.AverageSegment{Label: "Bolån"},
+			},
+			newEntry: model.InterestSet{
+				Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(7.3),
+				AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025}, AverageSegment: &model.AverageSegment{Label: "Seniorlån"},
+			},
+			wantCount: 2,
+		},
+		{
+			name: "same average rate series updates existing entry",
+			existing: model.InterestSet{
+				Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(6.1),
+				AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025}, AverageSegment: &model.AverageSegment{Label: "Bolån"},
+			},
+			newEntry: model.InterestSet{
+				Bank: "Bluestep", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(6.2),
+				AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025}, AverageSegment: &model.AverageSegment{Label: "Bolån"},
+			},
+			wantCount: 1,
+		},
+		{
+			name:     "different lender adds new entry",
+			existing: baseEntry,
+			newEntry: model.InterestSet{
+				Bank:        "Nordea",
+				Lender:      "Stabelo", // Distributed product of another lender
+				Type:        model.TypeListRate,
+				Term:        model.Term1year,
+				NominalRate: model.RateFromPercent(3.4),
 			},
 			wantCount: 2,
 		},
@@ -251,7 +313,7 @@ func TestMemoryStore_UpsertInterestSet_NonMatchingCases(t *testing.T) {
 				Bank:        "Nordea",
 				Type:        model.TypeListRate,
 				Term:        model.Term1year,
-				NominalRate: 4.5, // Only rate different
+				NominalRate: model.RateFromPercent(4.5), // Only rate different
 			},
 			wantCount: 1,
 		},
@@ -287,7 +349,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.5,
+			NominalRate:           model.RateFromPercent(3.5),
 			AverageReferenceMonth: nil, // nil reference month
 		}
 
@@ -295,7 +357,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.8,
+			NominalRate:           model.RateFromPercent(3.8),
 			AverageReferenceMonth: &model.AvgMonth{Month: time.January, Year: 2024},
 		}
 
@@ -321,7 +383,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.5,
+			NominalRate:           model.RateFromPercent(3.5),
 			AverageReferenceMonth: &model.AvgMonth{Month: time.January, Year: 2024},
 		}
 
@@ -329,7 +391,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.8,
+			NominalRate:           model.RateFromPercent(3.8),
 			AverageReferenceMonth: &model.AvgMonth{Month: time.January, Year: 2025}, // Different year
 		}
 
@@ -354,7 +416,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.5,
+			NominalRate:           model.RateFromPercent(3.5),
 			AverageReferenceMonth: nil,
 		}
 
@@ -362,7 +424,7 @@ func TestMemoryStore_UpsertInterestSet_AverageRateEdgeCases(t *testing.T) {
 			Bank:                  "Nordea",
 			Type:                  model.TypeAverageRate,
 			Term:                  model.Term1year,
-			NominalRate:           3.8,
+			NominalRate:           model.RateFromPercent(3.8),
 			AverageReferenceMonth: nil, // Also nil
 		}
 
@@ -392,7 +454,7 @@ func TestMemoryStore_UpsertInterestSet_MultipleUpdates(t *testing.T) {
 		Bank:        "Nordea",
 		Type:        model.TypeListRate,
 		Term:        model.Term1year,
-		NominalRate: 3.0,
+		NominalRate: model.RateFromPercent(3.0),
 	}
 	if err := s.UpsertInterestSet(entry1); err != nil {
 		t.Fatalf("First UpsertInterestSet() error = %v", err)
@@ -403,7 +465,7 @@ func TestMemoryStore_UpsertInterestSet_MultipleUpdates(t *testing.T) {
 		Bank:        "Nordea",
 		Type:        model.TypeListRate,
 		Term:        model.Term1year,
-		NominalRate: 3.5,
+		NominalRate: model.RateFromPercent(3.5),
 	}
 	if err := s.UpsertInterestSet(entry2); err != nil {
 		t.Fatalf("Second UpsertInterestSet() error = %v", err)
@@ -414,7 +476,7 @@ func TestMemoryStore_UpsertInterestSet_MultipleUpdates(t *testing.T) {
 		Bank:        "Nordea",
 		Type:        model.TypeListRate,
 		Term:        model.Term1year,
-		NominalRate: 4.0,
+		NominalRate: model.RateFromPercent(4.0),
 	}
 	if err := s.UpsertInterestSet(entry3); err != nil {
 		t.Fatalf("Third UpsertInterestSet() error = %v", err)
@@ -426,8 +488,8 @@ func TestMemoryStore_UpsertInterestSet_MultipleUpdates(t *testing.T) {
 	}
 
 	// Should have the last rate
-	if s.data[0].NominalRate != 4.0 {
-		t.Errorf("Expected rate 4.0 after updates, got %f", s.data[0].NominalRate)
+	if s.data[0].NominalRate != model.RateFromPercent(4.0) {
+		t.Errorf("Expected rate 4.0 after updates, got %v", s.data[0].NominalRate)
 	}
 }
 
@@ -436,9 +498,9 @@ func TestMemoryStore_UpsertInterestSet_PreservesOtherEntries(t *testing.T) {
 
 	// Create store with multiple entries
 	entries := []model.InterestSet{
-		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: 3.0},
-		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term1year, NominalRate: 3.1},
-		{Bank: "Swedbank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: 3.2},
+		{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.0)},
+		{Bank: "SEB", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.1)},
+		{Bank: "Swedbank", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.2)},
 	}
 
 	s := &MemoryStore{
@@ -451,7 +513,7 @@ func TestMemoryStore_UpsertInterestSet_PreservesOtherEntries(t *testing.T) {
 		Bank:        "SEB",
 		Type:        model.TypeListRate,
 		Term:        model.Term1year,
-		NominalRate: 3.5, // Updated rate
+		NominalRate: model.RateFromPercent(3.5), // Updated rate
 	}
 
 	if err := s.UpsertInterestSet(updatedEntry); err != nil {
@@ -464,13 +526,137 @@ func TestMemoryStore_UpsertInterestSet_PreservesOtherEntries(t *testing.T) {
 	}
 
 	// Verify each entry
-	if s.data[0].Bank != "Nordea" || s.data[0].NominalRate != 3.0 {
+	if s.data[0].Bank != "Nordea" || s.data[0].NominalRate != model.RateFromPercent(3.0) {
 		t.Errorf("First entry modified unexpectedly: %+v", s.data[0])
 	}
-	if s.data[1].Bank != "SEB" || s.data[1].NominalRate != 3.5 {
+	if s.data[1].Bank != "SEB" || s.data[1].NominalRate != model.RateFromPercent(3.5) {
 		t.Errorf("Second entry not updated correctly: %+v", s.data[1])
 	}
-	if s.data[2].Bank != "Swedbank" || s.data[2].NominalRate != 3.2 {
+	if s.data[2].Bank != "Swedbank" || s.data[2].NominalRate != model.RateFromPercent(3.2) {
 		t.Errorf("Third entry modified unexpectedly: %+v", s.data[2])
 	}
 }
+
+func TestMemoryStore_PendingReviews(t *testing.T) {
+	t.Parallel()
+
+	set := model.InterestSet{Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(4.5)}
+	review := model.PendingReview{ID: "Nordea|listRate|1y", Set: set, Reasons: []string{"jump"}}
+
+	t.Run("adding the same ID replaces the review", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewMemoryStore(nil, zap.NewNop())
+		if err := s.AddPendingReview(review); err != nil {
+			t.Fatalf("AddPendingReview() error = %v", err)
+		}
+		updated := review
+		updated.Reasons = []string{"bigger jump"}
+		if err := s.AddPendingReview(updated); err != nil {
+			t.Fatalf("AddPendingReview() error = %v", err)
+		}
+
+		got, _ := s.GetPendingReviews()
+		if !reflect.DeepEqual(got, []model.PendingReview{updated}) {
+			t.Errorf("GetPendingReviews() = %v, want %v", got, []model.PendingReview{updated})
+		}
+	})
+
+	t.Run("approving upserts the interest set", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewMemoryStore(nil, zap.NewNop())
+		_ = s.AddPendingReview(review)
+		if err := s.ResolvePendingReview(review.ID, true); err != nil {
+			t.Fatalf("ResolvePendingReview() error = %v", err)
+		}
+
+		if len(s.reviews) != 0 {
+			t.Errorf("got %d pending reviews after approval, want 0", len(s.reviews))
+		}
+		if !reflect.DeepEqual(s.data, []model.InterestSet{set}) {
+			t.Errorf("data = %v, want %v", s.data, []model.InterestSet{set})
+		}
+	})
+
+	t.Run("discarding drops the interest set", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewMemoryStore(nil, zap.NewNop())
+		_ = s.AddPendingReview(review)
+		if err := s.ResolvePendingReview(review.ID, false); err != nil {
+			t.Fatalf("ResolvePendingReview() error = %v", err)
+		}
+
+		if len(s.reviews) != 0 || len(s.data) != 0 {
+			t.Errorf("got %d reviews and %d sets after discarding, want none", len(s.reviews), len(s.data))
+		}
+	})
+
+	t.Run("unknown ID returns ErrNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewMemoryStore(nil, zap.NewNop())
+		if err := s.ResolvePendingReview("missing", true); !errors.Is(err, ErrNotFound) {
+			t.Errorf("ResolvePendingReview() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestMemoryStore_Fingerprints(t *testing.T) {
+	t.Parallel()
+
+	s := NewMemoryStore(nil, zap.NewNop())
+	list := model.Fingerprint{Bank: "Nordea", Source: "https://example.com/list", Features: []string{"list: column 0 \"Bindningstid\""}}
+	avg := model.Fingerprint{Bank: "Nordea", Source: "https://example.com/avg", Features: []string{"avg: column 0 \"Månad\""}}
+	for _, fingerprint := range []model.Fingerprint{list, avg} {
+		if err := s.SaveFingerprint(fingerprint); err != nil {
+			t.Fatalf("SaveFingerprint() error = %v", err)
+		}
+	}
+
+	// Saving the same bank and source replaces the fingerprint.
+	updated := list
+	updated.Features = []string{"list: column 0 \"Löptid\""}
+	if err := s.SaveFingerprint(updated); err != nil {
+		t.Fatalf("SaveFingerprint() error = %v", err)
+	}
+
+	got, err := s.GetFingerprints()
+	if err != nil {
+		t.Fatalf("GetFingerprints() error = %v", err)
+	}
+	if want := []model.Fingerprint{updated, avg}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFingerprints() = %v, want %v", got, want)
+	}
+}
+
+func TestMemoryStore_ReferenceRates(t *testing.T) {
+	t.Parallel()
+
+	s := NewMemoryStore(nil, zap.NewNop())
+	day := func(d int) time.Time { return time.Date(2025, time.October, d, 0, 0, 0, 0, time.UTC) }
+	second := model.ReferenceRate{Series: model.ReferencePolicyRate, Date: day(2), Rate: model.RateFromPercent(1.75)}
+	first := model.ReferenceRate{Series: model.ReferencePolicyRate, Date: day(1), Rate: model.RateFromPercent(2)}
+	stibor := model.ReferenceRate{Series: model.ReferenceSTIBOR3M, Date: day(1), Rate: model.RateFromPercent(1.9)}
+	for _, rate := range []model.ReferenceRate{second, first, stibor} {
+		if err := s.UpsertReferenceRate(rate); err != nil {
+			t.Fatalf("UpsertReferenceRate() error = %v", err)
+		}
+	}
+
+	// Saving the same series and day replaces the rate.
+	revised := first
+	revised.Rate = model.RateFromPercent(1.75)
+	if err := s.UpsertReferenceRate(revised); err != nil {
+		t.Fatalf("UpsertReferenceRate() error = %v", err)
+	}
+
+	got, err := s.GetReferenceRates(model.ReferencePolicyRate)
+	if err != nil {
+		t.Fatalf("GetReferenceRates() error = %v", err)
+	}
+	if want := []model.ReferenceRate{revised, second}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetReferenceRates() = %v, want %v", got, want)
+	}
+}
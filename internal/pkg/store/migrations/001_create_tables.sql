@@ -0,0 +1,31 @@
+CREATE TABLE interest_sets
+(
+    key                 TEXT PRIMARY KEY,
+    bank                TEXT        NOT NULL,
+    lender              TEXT        NOT NULL DEFAULT '',
+    type                TEXT        NOT NULL,
+    term                TEXT        NOT NULL,
+    nominal_rate        REAL        NOT NULL,
+    changed_on          TIMESTAMPTZ,
+    last_crawled_at     TIMESTAMPTZ NOT NULL,
+    ratio_min           REAL,
+    ratio_max           REAL,
+    loan_amount_min     BIGINT,
+    loan_amount_max     BIGINT,
+    max_energy_class    TEXT        NOT NULL DEFAULT '',
+    union_discount      BOOLEAN     NOT NULL DEFAULT FALSE,
+    union_organisations TEXT[]      NOT NULL DEFAULT '{}',
+    avg_year            INTEGER,
+    avg_month           INTEGER
+);
+
+CREATE INDEX interest_sets_bank_type_term_idx ON interest_sets (bank, type, term);
+
+CREATE TABLE pending_reviews
+(
+    id            TEXT PRIMARY KEY,
+    interest_set  JSONB       NOT NULL,
+    previous_rate REAL,
+    reasons       TEXT[]      NOT NULL DEFAULT '{}',
+    detected_at   TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,8 @@
+-- Rates were stored as REAL, which cannot represent most published rates exactly (3.74 became 3.7400000095...).
+-- NUMERIC(9,4) holds every rate to a hundredth of a basis point, matching model.Rate. REAL converts to NUMERIC with
+-- at most six significant digits, so the rounding restores the rates the banks published.
+ALTER TABLE interest_sets
+    ALTER COLUMN nominal_rate TYPE NUMERIC(9, 4) USING round(nominal_rate::numeric, 4);
+
+ALTER TABLE pending_reviews
+    ALTER COLUMN previous_rate TYPE NUMERIC(9, 4) USING round(previous_rate::numeric, 4);
@@ -0,0 +1,9 @@
+-- The structure of every crawled source as of the last crawl, to report layout changes. See model.Fingerprint.
+CREATE TABLE source_fingerprints
+(
+    bank        TEXT        NOT NULL,
+    source      TEXT        NOT NULL,
+    features    TEXT[]      NOT NULL DEFAULT '{}',
+    captured_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (bank, source)
+);
@@ -0,0 +1,4 @@
+-- The document each rate was last parsed from, see model.SourceRef. Empty for rates stored before sources were tracked.
+ALTER TABLE interest_sets
+    ADD COLUMN source_url  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN source_hash TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- The format of the source document and the crawler function that extracted each rate, see model.SourceRef.
+ALTER TABLE interest_sets
+    ADD COLUMN source_kind   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN source_method TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,14 @@
+-- Daily values of the market reference rates the banks price against, like the Riksbank policy rate. See
+-- model.ReferenceRate.
+CREATE TABLE reference_rates
+(
+    series          TEXT          NOT NULL,
+    date            DATE          NOT NULL,
+    rate            NUMERIC(9, 4) NOT NULL,
+    last_crawled_at TIMESTAMPTZ   NOT NULL,
+    source_url      TEXT          NOT NULL DEFAULT '',
+    source_kind     TEXT          NOT NULL DEFAULT '',
+    source_method   TEXT          NOT NULL DEFAULT '',
+    source_hash     TEXT          NOT NULL DEFAULT '',
+    PRIMARY KEY (series, date)
+);
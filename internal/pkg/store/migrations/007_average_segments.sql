@@ -0,0 +1,8 @@
+-- The series of an average rate for banks that publish several per term and month, see model.AverageSegment. An empty
+-- label means the bank publishes a single series.
+ALTER TABLE interest_sets
+    ADD COLUMN avg_segment            TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN avg_segment_ratio_min  REAL   NOT NULL DEFAULT 0,
+    ADD COLUMN avg_segment_ratio_max  REAL   NOT NULL DEFAULT 0,
+    ADD COLUMN avg_segment_amount_min BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN avg_segment_amount_max BIGINT NOT NULL DEFAULT 0;
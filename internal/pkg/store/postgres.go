@@ -0,0 +1,556 @@
+package store
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+	"go.uber.org/zap"
+)
+
+// Compile-time interface compliance check.
+var _ Store = &PostgresStore{}
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+const queryTimeout = 30 * time.Second
+
+const upsertInterestSetSQL = `
+INSERT INTO interest_sets (key, bank, lender, type, term, nominal_rate, changed_on, last_crawled_at, ratio_min, ratio_max,
+                           loan_amount_min, loan_amount_max, max_energy_class, union_discount, union_organisations,
+                           avg_year, avg_month, source_url, source_kind, source_method, source_hash, avg_segment,
+                           avg_segment_ratio_min, avg_segment_ratio_max, avg_segment_amount_min, avg_segment_amount_max)
+VALUES ($1, $2, $3, $4, $5, $6::numeric, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23,
+        $24, $25, $26)
+ON CONFLICT (key) DO UPDATE SET nominal_rate    = excluded.nominal_rate,
+                                changed_on      = excluded.changed_on,
+                                last_crawled_at = excluded.last_crawled_at,
+                                source_url      = excluded.source_url,
+                                source_kind     = excluded.source_kind,
+                                source_method   = excluded.source_method,
+                                source_hash     = excluded.source_hash`
+
+const selectInterestSetsSQL = `
+SELECT bank, lender, type, term, nominal_rate::text, changed_on, last_crawled_at, ratio_min, ratio_max,
+       loan_amount_min, loan_amount_max, max_energy_class, union_discount, union_organisations, avg_year, avg_month,
+       source_url, source_kind, source_method, source_hash, avg_segment, avg_segment_ratio_min, avg_segment_ratio_max,
+       avg_segment_amount_min, avg_segment_amount_max
+FROM interest_sets
+ORDER BY bank, type, term, avg_year, avg_month, avg_segment`
+
+// PostgresStore implements Store using PostgreSQL, so that the rates survive restarts.
+type PostgresStore struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewPostgresStore(pool *pgxpool.Pool, logger *zap.Logger) *PostgresStore {
+	return &PostgresStore{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// Migrate applies all embedded migrations that have not been applied yet, in the order of their file names.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".sql")
+		if err := s.migrate(ctx, file, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) migrate(ctx context.Context, file, version string) error {
+	sql, err := migrations.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %s: %w", version, err)
+	}
+
+	return s.inTx(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT DO NOTHING`, version)
+		if err != nil {
+			return fmt.Errorf("failed to record migration %s: %w", version, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil // already applied
+		}
+
+		if _, err := tx.Exec(ctx, string(sql)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+		s.logger.Info("applied migration", zap.String("version", version))
+		return nil
+	})
+}
+
+func (s *PostgresStore) UpsertInterestSet(set model.InterestSet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	return upsertInterestSet(ctx, s.pool, set)
+}
+
+func (s *PostgresStore) GetInterestSets() ([]model.InterestSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, selectInterestSetsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interest sets: %w", err)
+	}
+	defer rows.Close()
+
+	sets := []model.InterestSet{}
+	for rows.Next() {
+		var r interestSetRow
+		err := rows.Scan(&r.Bank, &r.Lender, &r.Type, &r.Term, &r.NominalRate, &r.ChangedOn, &r.LastCrawledAt,
+			&r.RatioMin, &r.RatioMax, &r.LoanAmountMin, &r.LoanAmountMax, &r.MaxEnergyClass, &r.UnionDiscount,
+			&r.UnionOrganisations, &r.AvgYear, &r.AvgMonth, &r.SourceURL, &r.SourceKind,
+			&r.SourceMethod, &r.SourceHash, &r.AvgSegment, &r.AvgSegmentRatioMin, &r.AvgSegmentRatioMax,
+			&r.AvgSegmentAmountMin, &r.AvgSegmentAmountMax)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interest set: %w", err)
+		}
+		set, err := r.toInterestSet()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read interest sets: %w", err)
+	}
+
+	return sets, nil
+}
+
+func (s *PostgresStore) AddPendingReview(review model.PendingReview) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	set, err := json.Marshal(review.Set)
+	if err != nil {
+		return fmt.Errorf("failed to marshal interest set of review %s: %w", review.ID, err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO pending_reviews (id, interest_set, previous_rate, reasons, detected_at)
+		VALUES ($1, $2, $3::numeric, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET interest_set  = excluded.interest_set,
+		                               previous_rate = excluded.previous_rate,
+		                               reasons       = excluded.reasons,
+		                               detected_at   = excluded.detected_at`,
+		review.ID, set, numericRate(review.PreviousRate), review.Reasons, review.DetectedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add pending review %s: %w", review.ID, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) GetPendingReviews() ([]model.PendingReview, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, interest_set, previous_rate::text, reasons, detected_at FROM pending_reviews ORDER BY detected_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []model.PendingReview{}
+	for rows.Next() {
+		var review model.PendingReview
+		var set []byte
+		var previousRate *string
+		if err := rows.Scan(&review.ID, &set, &previousRate, &review.Reasons, &review.DetectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending review: %w", err)
+		}
+		if previousRate != nil {
+			rate, err := model.ParseRate(*previousRate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse previous rate of review %s: %w", review.ID, err)
+			}
+			review.PreviousRate = &rate
+		}
+		if err := json.Unmarshal(set, &review.Set); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal interest set of review %s: %w", review.ID, err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pending reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (s *PostgresStore) ResolvePendingReview(id string, approve bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	return s.inTx(ctx, func(tx pgx.Tx) error {
+		var raw []byte
+		err := tx.QueryRow(ctx, `DELETE FROM pending_reviews WHERE id = $1 RETURNING interest_set`, id).Scan(&raw)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("pending review %q: %w", id, ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete pending review %s: %w", id, err)
+		}
+		if !approve {
+			return nil
+		}
+
+		var set model.InterestSet
+		if err := json.Unmarshal(raw, &set); err != nil {
+			return fmt.Errorf("failed to unmarshal interest set of review %s: %w", id, err)
+		}
+		return upsertInterestSet(ctx, tx, set)
+	})
+}
+
+func (s *PostgresStore) SaveFingerprint(fingerprint model.Fingerprint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	features := fingerprint.Features
+	if features == nil {
+		features = []string{}
+	}
+
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO source_fingerprints (bank, source, features, captured_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bank, source) DO UPDATE SET features    = excluded.features,
+		                                         captured_at = excluded.captured_at`,
+		string(fingerprint.Bank), fingerprint.Source, features, fingerprint.CapturedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save fingerprint of %s: %w", fingerprint.Source, err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) GetFingerprints() ([]model.Fingerprint, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `SELECT bank, source, features, captured_at FROM source_fingerprints ORDER BY bank, source`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fingerprints: %w", err)
+	}
+	defer rows.Close()
+
+	fingerprints := []model.Fingerprint{}
+	for rows.Next() {
+		var fingerprint model.Fingerprint
+		var bank string
+		if err := rows.Scan(&bank, &fingerprint.Source, &fingerprint.Features, &fingerprint.CapturedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan fingerprint: %w", err)
+		}
+		fingerprint.Bank = model.Bank(bank)
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fingerprints: %w", err)
+	}
+
+	return fingerprints, nil
+}
+
+func (s *PostgresStore) UpsertReferenceRate(rate model.ReferenceRate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	r := newReferenceRateRow(rate)
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO reference_rates (series, date, rate, last_crawled_at, source_url, source_kind, source_method, source_hash)
+		VALUES ($1, $2, $3::numeric, $4, $5, $6, $7, $8)
+		ON CONFLICT (series, date) DO UPDATE SET rate            = excluded.rate,
+		                                         last_crawled_at = excluded.last_crawled_at,
+		                                         source_url      = excluded.source_url,
+		                                         source_kind     = excluded.source_kind,
+		                                         source_method   = excluded.source_method,
+		                                         source_hash     = excluded.source_hash`,
+		r.Series, r.Date, r.Rate, r.LastCrawledAt, r.SourceURL, r.SourceKind, r.SourceMethod, r.SourceHash)
+	if err != nil {
+		return fmt.Errorf("failed to upsert reference rate %s %s: %w", r.Series, r.Date.Format(time.DateOnly), err)
+	}
+	return nil
+}
+
+func (s *PostgresStore) GetReferenceRates(series model.ReferenceSeries) ([]model.ReferenceRate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT series, date, rate::text, last_crawled_at, source_url, source_kind, source_method, source_hash
+		FROM reference_rates
+		WHERE series = $1
+		ORDER BY date`, string(series))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reference rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []model.ReferenceRate{}
+	for rows.Next() {
+		var r referenceRateRow
+		if err := rows.Scan(&r.Series, &r.Date, &r.Rate, &r.LastCrawledAt, &r.SourceURL, &r.SourceKind, &r.SourceMethod,
+			&r.SourceHash); err != nil {
+			return nil, fmt.Errorf("failed to scan reference rate: %w", err)
+		}
+		rate, err := r.toReferenceRate()
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read reference rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (s *PostgresStore) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// execer is implemented by both the pool and transactions.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func upsertInterestSet(ctx context.Context, db execer, set model.InterestSet) error {
+	r := newInterestSetRow(set)
+	_, err := db.Exec(ctx, upsertInterestSetSQL, set.Key(), r.Bank, r.Lender, r.Type, r.Term, r.NominalRate, r.ChangedOn,
+		r.LastCrawledAt, r.RatioMin, r.RatioMax, r.LoanAmountMin, r.LoanAmountMax, r.MaxEnergyClass, r.UnionDiscount,
+		r.UnionOrganisations, r.AvgYear, r.AvgMonth, r.SourceURL, r.SourceKind, r.SourceMethod, r.SourceHash, r.AvgSegment,
+		r.AvgSegmentRatioMin, r.AvgSegmentRatioMax, r.AvgSegmentAmountMin, r.AvgSegmentAmountMax)
+	if err != nil {
+		return fmt.Errorf("failed to upsert interest set %s: %w", set.Key(), err)
+	}
+	return nil
+}
+
+// interestSetRow is the flat database representation of a model.InterestSet. Optional fields are nil pointers.
+type interestSetRow struct {
+	Bank               string
+	Lender             string
+	Type               string
+	Term               string
+	NominalRate        string // NUMERIC as text, so the rate never passes through a float
+	ChangedOn          *time.Time
+	LastCrawledAt      time.Time
+	RatioMin           *float32
+	RatioMax           *float32
+	LoanAmountMin      *int64
+	LoanAmountMax      *int64
+	MaxEnergyClass     string
+	UnionDiscount      bool
+	UnionOrganisations []string
+	AvgYear            *int32
+	AvgMonth           *int32
+	SourceURL          string
+	SourceKind         string
+	SourceMethod       string
+	SourceHash         string
+	// AvgSegment is the label of the model.AverageSegment, empty if there is none.
+	AvgSegment          string
+	AvgSegmentRatioMin  float32
+	AvgSegmentRatioMax  float32
+	AvgSegmentAmountMin int64
+	AvgSegmentAmountMax int64
+}
+
+func newInterestSetRow(set model.InterestSet) interestSetRow {
+	r := interestSetRow{
+		Bank:               string(set.Bank),
+		Lender:             string(set.Lender),
+		Type:               string(set.Type),
+		Term:               set.Term.String(),
+		NominalRate:        set.NominalRate.String(),
+		ChangedOn:          set.ChangedOn,
+		LastCrawledAt:      set.LastCrawledAt,
+		MaxEnergyClass:     string(set.MaxEnergyClass),
+		UnionDiscount:      set.UnionDiscount,
+		UnionOrganisations: set.UnionOrganisations,
+	}
+	if r.UnionOrganisations == nil {
+		r.UnionOrganisations = []string{}
+	}
+	if b := set.RatioDiscountBoundaries; b != nil {
+		r.RatioMin, r.RatioMax = &b.MinRatio, &b.MaxRatio
+	}
+	if b := set.LoanAmountBoundaries; b != nil {
+		minAmount, maxAmount := int64(b.MinAmount), int64(b.MaxAmount) //nolint:gosec // loan amounts fit into int64
+		r.LoanAmountMin, r.LoanAmountMax = &minAmount, &maxAmount
+	}
+	if m := set.AverageReferenceMonth; m != nil {
+		year, month := int32(m.Year), int32(m.Month) //nolint:gosec // years and months fit into int32
+		r.AvgYear, r.AvgMonth = &year, &month
+	}
+	if seg := set.AverageSegment; seg != nil {
+		minAmount, maxAmount := int64(seg.MinAmount), int64(seg.MaxAmount) //nolint:gosec // loan amounts fit into int64
+		r.AvgSegment, r.AvgSegmentRatioMin, r.AvgSegmentRatioMax = seg.Label, seg.MinRatio, seg.MaxRatio
+		r.AvgSegmentAmountMin, r.AvgSegmentAmountMax = minAmount, maxAmount
+	}
+	if src := set.Source; src != nil {
+		r.SourceURL, r.SourceKind, r.SourceMethod, r.SourceHash = src.URL, string(src.Kind), src.Method, src.Hash
+	}
+	return r
+}
+
+func (r interestSetRow) toInterestSet() (model.InterestSet, error) {
+	rate, err := model.ParseRate(r.NominalRate)
+	if err != nil {
+		return model.InterestSet{}, fmt.Errorf("failed to parse nominal rate of %s %s %s: %w", r.Bank, r.Type, r.Term, err)
+	}
+
+	term, err := model.ParseTerm(r.Term)
+	if err != nil {
+		return model.InterestSet{}, fmt.Errorf("failed to parse term of %s %s: %w", r.Bank, r.Type, err)
+	}
+
+	set := model.InterestSet{
+		Bank:           model.Bank(r.Bank),
+		Lender:         model.Bank(r.Lender),
+		Type:           model.Type(r.Type),
+		Term:           term,
+		NominalRate:    rate,
+		ChangedOn:      r.ChangedOn,
+		LastCrawledAt:  r.LastCrawledAt,
+		MaxEnergyClass: model.EnergyClass(r.MaxEnergyClass),
+		UnionDiscount:  r.UnionDiscount,
+	}
+	if len(r.UnionOrganisations) > 0 {
+		set.UnionOrganisations = r.UnionOrganisations
+	}
+	if r.RatioMin != nil && r.RatioMax != nil {
+		set.RatioDiscountBoundaries = &model.RatioDiscountBoundary{MinRatio: *r.RatioMin, MaxRatio: *r.RatioMax}
+	}
+	if r.LoanAmountMin != nil && r.LoanAmountMax != nil {
+		set.LoanAmountBoundaries = &model.LoanAmountBoundary{
+			MinAmount: uint(*r.LoanAmountMin), //nolint:gosec // stored from uint
+			MaxAmount: uint(*r.LoanAmountMax), //nolint:gosec // stored from uint
+		}
+	}
+	if r.AvgYear != nil && r.AvgMonth != nil {
+		set.AverageReferenceMonth = &model.AvgMonth{
+			Month: time.Month(*r.AvgMonth),
+			Year:  uint(*r.AvgYear), //nolint:gosec // years are always positive
+		}
+	}
+	if r.AvgSegment != "" {
+		set.AverageSegment = &model.AverageSegment{
+			Label:     r.AvgSegment,
+			MinRatio:  r.AvgSegmentRatioMin,
+			MaxRatio:  r.AvgSegmentRatioMax,
+			MinAmount: uint(r.AvgSegmentAmountMin), //nolint:gosec // stored from uint
+			MaxAmount: uint(r.AvgSegmentAmountMax), //nolint:gosec // stored from uint
+		}
+	}
+	if r.SourceURL != "" || r.SourceHash != "" {
+		set.Source = &model.SourceRef{
+			URL:    r.SourceURL,
+			Kind:   model.SourceKind(r.SourceKind),
+			Method: r.SourceMethod,
+			Hash:   r.SourceHash,
+		}
+	}
+	return set, nil
+}
+
+// numericRate converts an optional rate to the text of a NUMERIC parameter, keeping nil as NULL.
+func numericRate(rate *model.Rate) *string {
+	if rate == nil {
+		return nil
+	}
+	str := rate.String()
+	return &str
+}
+
+// referenceRateRow is the flat database representation of a model.ReferenceRate.
+type referenceRateRow struct {
+	Series        string
+	Date          time.Time
+	Rate          string // NUMERIC as text, so the rate never passes through a float
+	LastCrawledAt time.Time
+	SourceURL     string
+	SourceKind    string
+	SourceMethod  string
+	SourceHash    string
+}
+
+func newReferenceRateRow(rate model.ReferenceRate) referenceRateRow {
+	r := referenceRateRow{
+		Series:        string(rate.Series),
+		Date:          rate.Date,
+		Rate:          rate.Rate.String(),
+		LastCrawledAt: rate.LastCrawledAt,
+	}
+	if src := rate.Source; src != nil {
+		r.SourceURL, r.SourceKind, r.SourceMethod, r.SourceHash = src.URL, string(src.Kind), src.Method, src.Hash
+	}
+	return r
+}
+
+func (r referenceRateRow) toReferenceRate() (model.ReferenceRate, error) {
+	value, err := model.ParseRate(r.Rate)
+	if err != nil {
+		return model.ReferenceRate{}, fmt.Errorf("failed to parse reference rate %s %s: %w", r.Series, r.Date.Format(time.DateOnly), err)
+	}
+
+	rate := model.ReferenceRate{
+		Series:        model.ReferenceSeries(r.Series),
+		Date:          r.Date.UTC(),
+		Rate:          value,
+		LastCrawledAt: r.LastCrawledAt,
+	}
+	if r.SourceURL != "" || r.SourceHash != "" {
+		rate.Source = &model.SourceRef{
+			URL:    r.SourceURL,
+			Kind:   model.SourceKind(r.SourceKind),
+			Method: r.SourceMethod,
+			Hash:   r.SourceHash,
+		}
+	}
+	return rate, nil
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestInterestSetRow_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	changedOn := time.Date(2025, 9, 26, 0, 0, 0, 0, time.UTC)
+	crawledAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		set  model.InterestSet
+	}{
+		{
+			name: "list rate",
+			set: model.InterestSet{
+				Bank: "SEB", Type: model.TypeListRate, Term: model.Term3months, NominalRate: model.RateFromPercent(3.74),
+				ChangedOn: &changedOn, LastCrawledAt: crawledAt,
+			},
+		},
+		{
+			name: "distributed average rate",
+			set: model.InterestSet{
+				Bank: "Avanza", Lender: "Stabelo", Type: model.TypeAverageRate, Term: model.Term1year, NominalRate: model.RateFromPercent(2.8),
+				LastCrawledAt: crawledAt, AverageReferenceMonth: &model.AvgMonth{Month: time.March, Year: 1990},
+			},
+		},
+		{
+			name: "average rate of one of several series",
+			set: model.InterestSet{
+				Bank: "Marginalen Bank", Type: model.TypeAverageRate, Term: model.Term3months, NominalRate: model.RateFromPercent(6.2),
+				LastCrawledAt: crawledAt, AverageReferenceMonth: &model.AvgMonth{Month: time.November, Year: 2025},
+				AverageSegment: &model.AverageSegment{Label: "Belåningsgrad 60-85 %", MinRatio: 0.6, MaxRatio: 0.85, MinAmount: 500_000},
+			},
+		},
+		{
+			name: "ratio and amount discounted green rate",
+			set: model.InterestSet{
+				Bank: "Landshypotek", Type: model.TypeRatioDiscounted, Term: model.Term5years, NominalRate: model.RateFromPercent(3.1),
+				LastCrawledAt:           crawledAt,
+				RatioDiscountBoundaries: &model.RatioDiscountBoundary{MinRatio: 0, MaxRatio: 0.6},
+				LoanAmountBoundaries:    &model.LoanAmountBoundary{MinAmount: 5_000_000},
+				MaxEnergyClass:          model.EnergyClassB,
+			},
+		},
+		{
+			name: "union discounted rate",
+			set: model.InterestSet{
+				Bank: "Danske Bank", Type: model.TypeUnionDiscounted, Term: model.Term3months, NominalRate: model.RateFromPercent(2.5),
+				LastCrawledAt: crawledAt, UnionDiscount: true, UnionOrganisations: []string{"Saco", "TCO"},
+			},
+		},
+		{
+			name: "rate with source",
+			set: model.InterestSet{
+				Bank: "Nordea", Type: model.TypeListRate, Term: model.Term1year, NominalRate: model.RateFromPercent(3.2),
+				LastCrawledAt: crawledAt,
+				Source: &model.SourceRef{
+					URL:    "https://www.nordea.se/privat/produkter/bolan/listrantor.html",
+					Kind:   model.SourceKindHTML,
+					Method: "extractListRates",
+					Hash:   "9f86d081",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newInterestSetRow(tt.set).toInterestSet()
+			if err != nil {
+				t.Fatalf("toInterestSet() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.set) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.set)
+			}
+		})
+	}
+}
+
+func TestInterestSetRow_ParsesNumericText(t *testing.T) {
+	t.Parallel()
+
+	// PostgreSQL returns NUMERIC(9,4) as text padded to four decimals.
+	row := interestSetRow{Bank: "SEB", Type: string(model.TypeListRate), Term: model.Term3months.String(), NominalRate: "3.7400"}
+	set, err := row.toInterestSet()
+	if err != nil {
+		t.Fatalf("toInterestSet() error = %v", err)
+	}
+	if set.NominalRate != model.RateFromPercent(3.74) {
+		t.Errorf("NominalRate = %v, want 3.74", set.NominalRate)
+	}
+
+	row.NominalRate = "NaN"
+	if _, err := row.toInterestSet(); err == nil {
+		t.Error("toInterestSet() with invalid rate succeeded, want error")
+	}
+}
+
+func TestReferenceRateRow_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rate := model.ReferenceRate{
+		Series:        model.ReferenceSTIBOR3M,
+		Date:          time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		Rate:          model.RateFromPercent(1.912),
+		LastCrawledAt: time.Date(2025, 10, 2, 6, 0, 0, 0, time.UTC),
+		Source: &model.SourceRef{
+			URL:    "https://api.riksbank.se/swea/v1/Observations/SEDP3MSTIBORDELAYC/2025-09-01",
+			Kind:   model.SourceKindJSON,
+			Method: "parseSWEAObservations",
+			Hash:   "9f86d081",
+		},
+	}
+
+	got, err := newReferenceRateRow(rate).toReferenceRate()
+	if err != nil {
+		t.Fatalf("toReferenceRate() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, rate) {
+		t.Errorf("round trip = %+v, want %+v", got, rate)
+	}
+
+	row := newReferenceRateRow(rate)
+	row.Rate = "NaN"
+	if _, err := row.toReferenceRate(); err == nil {
+		t.Error("toReferenceRate() with invalid rate succeeded, want error")
+	}
+}
+
+func TestMigrations_Embedded(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range []string{
+		"migrations/001_create_tables.sql",
+		"migrations/002_exact_rates.sql",
+		"migrations/003_source_fingerprints.sql",
+		"migrations/004_source_refs.sql",
+		"migrations/005_source_provenance.sql",
+		"migrations/006_reference_rates.sql",
+		"migrations/007_average_segments.sql",
+	} {
+		sql, err := migrations.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read embedded migration %s: %v", file, err)
+		}
+		if len(sql) == 0 {
+			t.Errorf("embedded migration %s is empty", file)
+		}
+	}
+}
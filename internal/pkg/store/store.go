@@ -3,10 +3,32 @@
 //go:generate go run -mod=mod github.com/matryer/moq -out storemock/store_mock.go -pkg storemock . Store
 package store
 
-import "github.com/yama6a/bolan-compare/internal/pkg/model"
+import (
+	"errors"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+var ErrNotFound = errors.New("not found")
 
 // Store defines the interface for persisting interest rate data.
 type Store interface {
 	UpsertInterestSet(set model.InterestSet) error
 	GetInterestSets() ([]model.InterestSet, error)
+
+	// AddPendingReview holds back a suspicious InterestSet. A review with the same ID is replaced.
+	AddPendingReview(review model.PendingReview) error
+	GetPendingReviews() ([]model.PendingReview, error)
+	// ResolvePendingReview removes the review and, if approved, upserts its InterestSet.
+	// Returns ErrNotFound if no review with the given ID exists.
+	ResolvePendingReview(id string, approve bool) error
+
+	// SaveFingerprint stores the structure of a source, replacing the fingerprint with the same Bank and Source.
+	SaveFingerprint(fingerprint model.Fingerprint) error
+	GetFingerprints() ([]model.Fingerprint, error)
+
+	// UpsertReferenceRate stores the value of a reference series on one day, replacing the one of the same day.
+	UpsertReferenceRate(rate model.ReferenceRate) error
+	// GetReferenceRates returns all stored values of the series, oldest first.
+	GetReferenceRates(series model.ReferenceSeries) ([]model.ReferenceRate, error)
 }
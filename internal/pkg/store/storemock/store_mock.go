@@ -19,12 +19,33 @@ var _ store.Store = &StoreMock{}
 //
 //		// make and configure a mocked store.Store
 //		mockedStore := &StoreMock{
+//			AddPendingReviewFunc: func(review model.PendingReview) error {
+//				panic("mock out the AddPendingReview method")
+//			},
+//			GetFingerprintsFunc: func() ([]model.Fingerprint, error) {
+//				panic("mock out the GetFingerprints method")
+//			},
 //			GetInterestSetsFunc: func() ([]model.InterestSet, error) {
 //				panic("mock out the GetInterestSets method")
 //			},
+//			GetPendingReviewsFunc: func() ([]model.PendingReview, error) {
+//				panic("mock out the GetPendingReviews method")
+//			},
+//			GetReferenceRatesFunc: func(series model.ReferenceSeries) ([]model.ReferenceRate, error) {
+//				panic("mock out the GetReferenceRates method")
+//			},
+//			ResolvePendingReviewFunc: func(id string, approve bool) error {
+//				panic("mock out the ResolvePendingReview method")
+//			},
+//			SaveFingerprintFunc: func(fingerprint model.Fingerprint) error {
+//				panic("mock out the SaveFingerprint method")
+//			},
 //			UpsertInterestSetFunc: func(set model.InterestSet) error {
 //				panic("mock out the UpsertInterestSet method")
 //			},
+//			UpsertReferenceRateFunc: func(rate model.ReferenceRate) error {
+//				panic("mock out the UpsertReferenceRate method")
+//			},
 //		}
 //
 //		// use mockedStore in code that requires store.Store
@@ -32,25 +53,145 @@ var _ store.Store = &StoreMock{}
 //
 //	}
 type StoreMock struct {
+	// AddPendingReviewFunc mocks the AddPendingReview method.
+	AddPendingReviewFunc func(review model.PendingReview) error
+
+	// GetFingerprintsFunc mocks the GetFingerprints method.
+	GetFingerprintsFunc func() ([]model.Fingerprint, error)
+
 	// GetInterestSetsFunc mocks the GetInterestSets method.
 	GetInterestSetsFunc func() ([]model.InterestSet, error)
 
+	// GetPendingReviewsFunc mocks the GetPendingReviews method.
+	GetPendingReviewsFunc func() ([]model.PendingReview, error)
+
+	// GetReferenceRatesFunc mocks the GetReferenceRates method.
+	GetReferenceRatesFunc func(series model.ReferenceSeries) ([]model.ReferenceRate, error)
+
+	// ResolvePendingReviewFunc mocks the ResolvePendingReview method.
+	ResolvePendingReviewFunc func(id string, approve bool) error
+
+	// SaveFingerprintFunc mocks the SaveFingerprint method.
+	SaveFingerprintFunc func(fingerprint model.Fingerprint) error
+
 	// UpsertInterestSetFunc mocks the UpsertInterestSet method.
 	UpsertInterestSetFunc func(set model.InterestSet) error
 
+	// UpsertReferenceRateFunc mocks the UpsertReferenceRate method.
+	UpsertReferenceRateFunc func(rate model.ReferenceRate) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddPendingReview holds details about calls to the AddPendingReview method.
+		AddPendingReview []struct {
+			// Review is the review argument value.
+			Review model.PendingReview
+		}
+		// GetFingerprints holds details about calls to the GetFingerprints method.
+		GetFingerprints []struct {
+		}
 		// GetInterestSets holds details about calls to the GetInterestSets method.
 		GetInterestSets []struct {
 		}
+		// GetPendingReviews holds details about calls to the GetPendingReviews method.
+		GetPendingReviews []struct {
+		}
+		// GetReferenceRates holds details about calls to the GetReferenceRates method.
+		GetReferenceRates []struct {
+			// Series is the series argument value.
+			Series model.ReferenceSeries
+		}
+		// ResolvePendingReview holds details about calls to the ResolvePendingReview method.
+		ResolvePendingReview []struct {
+			// ID is the id argument value.
+			ID string
+			// Approve is the approve argument value.
+			Approve bool
+		}
+		// SaveFingerprint holds details about calls to the SaveFingerprint method.
+		SaveFingerprint []struct {
+			// Fingerprint is the fingerprint argument value.
+			Fingerprint model.Fingerprint
+		}
 		// UpsertInterestSet holds details about calls to the UpsertInterestSet method.
 		UpsertInterestSet []struct {
 			// Set is the set argument value.
 			Set model.InterestSet
 		}
+		// UpsertReferenceRate holds details about calls to the UpsertReferenceRate method.
+		UpsertReferenceRate []struct {
+			// Rate is the rate argument value.
+			Rate model.ReferenceRate
+		}
 	}
-	lockGetInterestSets   sync.RWMutex
-	lockUpsertInterestSet sync.RWMutex
+	lockAddPendingReview     sync.RWMutex
+	lockGetFingerprints      sync.RWMutex
+	lockGetInterestSets      sync.RWMutex
+	lockGetPendingReviews    sync.RWMutex
+	lockGetReferenceRates    sync.RWMutex
+	lockResolvePendingReview sync.RWMutex
+	lockSaveFingerprint      sync.RWMutex
+	lockUpsertInterestSet    sync.RWMutex
+	lockUpsertReferenceRate  sync.RWMutex
+}
+
+// AddPendingReview calls AddPendingReviewFunc.
+func (mock *StoreMock) AddPendingReview(review model.PendingReview) error {
+	if mock.AddPendingReviewFunc == nil {
+		panic("StoreMock.AddPendingReviewFunc: method is nil but Store.AddPendingReview was just called")
+	}
+	callInfo := struct {
+		Review model.PendingReview
+	}{
+		Review: review,
+	}
+	mock.lockAddPendingReview.Lock()
+	mock.calls.AddPendingReview = append(mock.calls.AddPendingReview, callInfo)
+	mock.lockAddPendingReview.Unlock()
+	return mock.AddPendingReviewFunc(review)
+}
+
+// AddPendingReviewCalls gets all the calls that were made to AddPendingReview.
+// Check the length with:
+//
+//	len(mockedStore.AddPendingReviewCalls())
+func (mock *StoreMock) AddPendingReviewCalls() []struct {
+	Review model.PendingReview
+} {
+	var calls []struct {
+		Review model.PendingReview
+	}
+	mock.lockAddPendingReview.RLock()
+	calls = mock.calls.AddPendingReview
+	mock.lockAddPendingReview.RUnlock()
+	return calls
+}
+
+// GetFingerprints calls GetFingerprintsFunc.
+func (mock *StoreMock) GetFingerprints() ([]model.Fingerprint, error) {
+	if mock.GetFingerprintsFunc == nil {
+		panic("StoreMock.GetFingerprintsFunc: method is nil but Store.GetFingerprints was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetFingerprints.Lock()
+	mock.calls.GetFingerprints = append(mock.calls.GetFingerprints, callInfo)
+	mock.lockGetFingerprints.Unlock()
+	return mock.GetFingerprintsFunc()
+}
+
+// GetFingerprintsCalls gets all the calls that were made to GetFingerprints.
+// Check the length with:
+//
+//	len(mockedStore.GetFingerprintsCalls())
+func (mock *StoreMock) GetFingerprintsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetFingerprints.RLock()
+	calls = mock.calls.GetFingerprints
+	mock.lockGetFingerprints.RUnlock()
+	return calls
 }
 
 // GetInterestSets calls GetInterestSetsFunc.
@@ -80,6 +221,133 @@ func (mock *StoreMock) GetInterestSetsCalls() []struct {
 	return calls
 }
 
+// GetPendingReviews calls GetPendingReviewsFunc.
+func (mock *StoreMock) GetPendingReviews() ([]model.PendingReview, error) {
+	if mock.GetPendingReviewsFunc == nil {
+		panic("StoreMock.GetPendingReviewsFunc: method is nil but Store.GetPendingReviews was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetPendingReviews.Lock()
+	mock.calls.GetPendingReviews = append(mock.calls.GetPendingReviews, callInfo)
+	mock.lockGetPendingReviews.Unlock()
+	return mock.GetPendingReviewsFunc()
+}
+
+// GetPendingReviewsCalls gets all the calls that were made to GetPendingReviews.
+// Check the length with:
+//
+//	len(mockedStore.GetPendingReviewsCalls())
+func (mock *StoreMock) GetPendingReviewsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetPendingReviews.RLock()
+	calls = mock.calls.GetPendingReviews
+	mock.lockGetPendingReviews.RUnlock()
+	return calls
+}
+
+// GetReferenceRates calls GetReferenceRatesFunc.
+func (mock *StoreMock) GetReferenceRates(series model.ReferenceSeries) ([]model.ReferenceRate, error) {
+	if mock.GetReferenceRatesFunc == nil {
+		panic("StoreMock.GetReferenceRatesFunc: method is nil but Store.GetReferenceRates was just called")
+	}
+	callInfo := struct {
+		Series model.ReferenceSeries
+	}{
+		Series: series,
+	}
+	mock.lockGetReferenceRates.Lock()
+	mock.calls.GetReferenceRates = append(mock.calls.GetReferenceRates, callInfo)
+	mock.lockGetReferenceRates.Unlock()
+	return mock.GetReferenceRatesFunc(series)
+}
+
+// GetReferenceRatesCalls gets all the calls that were made to GetReferenceRates.
+// Check the length with:
+//
+//	len(mockedStore.GetReferenceRatesCalls())
+func (mock *StoreMock) GetReferenceRatesCalls() []struct {
+	Series model.ReferenceSeries
+} {
+	var calls []struct {
+		Series model.ReferenceSeries
+	}
+	mock.lockGetReferenceRates.RLock()
+	calls = mock.calls.GetReferenceRates
+	mock.lockGetReferenceRates.RUnlock()
+	return calls
+}
+
+// ResolvePendingReview calls ResolvePendingReviewFunc.
+func (mock *StoreMock) ResolvePendingReview(id string, approve bool) error {
+	if mock.ResolvePendingReviewFunc == nil {
+		panic("StoreMock.ResolvePendingReviewFunc: method is nil but Store.ResolvePendingReview was just called")
+	}
+	callInfo := struct {
+		ID      string
+		Approve bool
+	}{
+		ID:      id,
+		Approve: approve,
+	}
+	mock.lockResolvePendingReview.Lock()
+	mock.calls.ResolvePendingReview = append(mock.calls.ResolvePendingReview, callInfo)
+	mock.lockResolvePendingReview.Unlock()
+	return mock.ResolvePendingReviewFunc(id, approve)
+}
+
+// ResolvePendingReviewCalls gets all the calls that were made to ResolvePendingReview.
+// Check the length with:
+//
+//	len(mockedStore.ResolvePendingReviewCalls())
+func (mock *StoreMock) ResolvePendingReviewCalls() []struct {
+	ID      string
+	Approve bool
+} {
+	var calls []struct {
+		ID      string
+		Approve bool
+	}
+	mock.lockResolvePendingReview.RLock()
+	calls = mock.calls.ResolvePendingReview
+	mock.lockResolvePendingReview.RUnlock()
+	return calls
+}
+
+// SaveFingerprint calls SaveFingerprintFunc.
+func (mock *StoreMock) SaveFingerprint(fingerprint model.Fingerprint) error {
+	if mock.SaveFingerprintFunc == nil {
+		panic("StoreMock.SaveFingerprintFunc: method is nil but Store.SaveFingerprint was just called")
+	}
+	callInfo := struct {
+		Fingerprint model.Fingerprint
+	}{
+		Fingerprint: fingerprint,
+	}
+	mock.lockSaveFingerprint.Lock()
+	mock.calls.SaveFingerprint = append(mock.calls.SaveFingerprint, callInfo)
+	mock.lockSaveFingerprint.Unlock()
+	return mock.SaveFingerprintFunc(fingerprint)
+}
+
+// SaveFingerprintCalls gets all the calls that were made to SaveFingerprint.
+// Check the length with:
+//
+//	len(mockedStore.SaveFingerprintCalls())
+func (mock *StoreMock) SaveFingerprintCalls() []struct {
+	Fingerprint model.Fingerprint
+} {
+	var calls []struct {
+		Fingerprint model.Fingerprint
+	}
+	mock.lockSaveFingerprint.RLock()
+	calls = mock.calls.SaveFingerprint
+	mock.lockSaveFingerprint.RUnlock()
+	return calls
+}
+
 // UpsertInterestSet calls UpsertInterestSetFunc.
 func (mock *StoreMock) UpsertInterestSet(set model.InterestSet) error {
 	if mock.UpsertInterestSetFunc == nil {
@@ -111,3 +379,35 @@ func (mock *StoreMock) UpsertInterestSetCalls() []struct {
 	mock.lockUpsertInterestSet.RUnlock()
 	return calls
 }
+
+// UpsertReferenceRate calls UpsertReferenceRateFunc.
+func (mock *StoreMock) UpsertReferenceRate(rate model.ReferenceRate) error {
+	if mock.UpsertReferenceRateFunc == nil {
+		panic("StoreMock.UpsertReferenceRateFunc: method is nil but Store.UpsertReferenceRate was just called")
+	}
+	callInfo := struct {
+		Rate model.ReferenceRate
+	}{
+		Rate: rate,
+	}
+	mock.lockUpsertReferenceRate.Lock()
+	mock.calls.UpsertReferenceRate = append(mock.calls.UpsertReferenceRate, callInfo)
+	mock.lockUpsertReferenceRate.Unlock()
+	return mock.UpsertReferenceRateFunc(rate)
+}
+
+// UpsertReferenceRateCalls gets all the calls that were made to UpsertReferenceRate.
+// Check the length with:
+//
+//	len(mockedStore.UpsertReferenceRateCalls())
+func (mock *StoreMock) UpsertReferenceRateCalls() []struct {
+	Rate model.ReferenceRate
+} {
+	var calls []struct {
+		Rate model.ReferenceRate
+	}
+	mock.lockUpsertReferenceRate.RLock()
+	calls = mock.calls.UpsertReferenceRate
+	mock.lockUpsertReferenceRate.RUnlock()
+	return calls
+}
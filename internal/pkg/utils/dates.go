@@ -0,0 +1,191 @@
+//nolint:revive,nolintlint // I like this package name, leave me alone
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// full names come before abbreviations, so the leftmost-first alternation never stops at a prefix.
+const monthNamePattern = `(januari|februari|mars|april|maj|juni|juli|augusti|september|oktober|november|december|` +
+	`jan|feb|mar|apr|may|jun|jul|aug|sept|sep|okt|oct|nov|dec)\.?`
+
+var (
+	ErrUnknownMonth = errors.New("unknown month name")
+	ErrInvalidDate  = errors.New("invalid date")
+
+	dayMonthYearRegex   = regexp.MustCompile(`^(\d{1,2})\.? ` + monthNamePattern + ` (\d{4})$`)    // 25 september 2025
+	isoDateRegex        = regexp.MustCompile(`^(\d{4})[-.](\d{2})[-.](\d{2})$`)                    // 2025-09-25, 2025.09.25
+	compactDateRegex    = regexp.MustCompile(`^(\d{4})(\d{2})(\d{2})$`)                            // 20250925
+	monthNameYearRegex  = regexp.MustCompile(`^` + monthNamePattern + `(?: ?- ?| )(\d{4}|\d{2})$`) // oktober 2025, okt-25
+	numericMonthRegex   = regexp.MustCompile(`^(\d{4})(?:[ \-/](\d{1,2})|(\d{2}))$`)               // 2025 10, 2025-10, 202510
+	findDayMonthYearRgx = regexp.MustCompile(`\b(\d{1,2})\.? ` + monthNamePattern + ` (\d{4})\b`)  // ... 25 september 2025 ...
+	findISODateRegex    = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)                        // ... 2025-09-25 ...
+	findMonthYearRegex  = regexp.MustCompile(`\b` + monthNamePattern + ` (\d{4})\b`)               // ... oktober 2025 ...
+)
+
+// ParseSwedishMonth converts a Swedish month name to a time.Month. It accepts full names ("oktober") and the
+// abbreviations the banks use ("okt", "okt.", "sept."), in any case. English abbreviations that differ from the
+// Swedish ones ("may", "oct") are accepted as well, since some banks mix them into their tables.
+func ParseSwedishMonth(name string) (time.Month, error) {
+	name = strings.TrimSuffix(strings.ToLower(NormalizeSpaces(name)), ".")
+
+	switch name {
+	case "januari", "jan":
+		return time.January, nil
+	case "februari", "feb":
+		return time.February, nil
+	case "mars", "mar":
+		return time.March, nil
+	case "april", "apr":
+		return time.April, nil
+	case "maj", "may":
+		return time.May, nil
+	case "juni", "jun":
+		return time.June, nil
+	case "juli", "jul":
+		return time.July, nil
+	case "augusti", "aug":
+		return time.August, nil
+	case "september", "sept", "sep":
+		return time.September, nil
+	case "oktober", "okt", "oct":
+		return time.October, nil
+	case "november", "nov":
+		return time.November, nil
+	case "december", "dec":
+		return time.December, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownMonth, name)
+	}
+}
+
+// ParseSwedishDate parses a date in one of the forms the banks publish: "25 september 2025", "25 sep. 2025",
+// "2025-09-25", "2025.09.25" or "20250925". The whole string must be the date. The result is midnight UTC.
+func ParseSwedishDate(str string) (time.Time, error) {
+	str = strings.ToLower(NormalizeSpaces(str))
+
+	if m := dayMonthYearRegex.FindStringSubmatch(str); m != nil {
+		return dateFromNamedMonth(m[1], m[2], m[3])
+	}
+	if m := isoDateRegex.FindStringSubmatch(str); m != nil {
+		return dateFromNumbers(m[1], m[2], m[3])
+	}
+	if m := compactDateRegex.FindStringSubmatch(str); m != nil {
+		return dateFromNumbers(m[1], m[2], m[3])
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDate, str)
+}
+
+// FindSwedishDate returns the first date in text written as "25 september 2025" or "2025-09-25", e.g. in
+// "Senast ändrad 25 september 2025".
+func FindSwedishDate(text string) (time.Time, error) {
+	text = strings.ToLower(NormalizeSpaces(text))
+
+	if m := findDayMonthYearRgx.FindStringSubmatch(text); m != nil {
+		return dateFromNamedMonth(m[1], m[2], m[3])
+	}
+	if m := findISODateRegex.FindStringSubmatch(text); m != nil {
+		return dateFromNumbers(m[1], m[2], m[3])
+	}
+
+	return time.Time{}, fmt.Errorf("%w: no date found in %q", ErrInvalidDate, text)
+}
+
+// ParseMonthYear parses the reference month of an average rate in one of the forms the banks publish:
+// "oktober 2025", "okt. 2025", "oktober-25", "2025 10", "2025-10", "2025/10" or "202510". A full date such as
+// "2025-10-31" is accepted too and yields its month. The whole string must be the month.
+func ParseMonthYear(str string) (model.AvgMonth, error) {
+	str = strings.ToLower(NormalizeSpaces(str))
+
+	if m := monthNameYearRegex.FindStringSubmatch(str); m != nil {
+		month, err := ParseSwedishMonth(m[1])
+		if err != nil {
+			return model.AvgMonth{}, err
+		}
+		year, err := strconv.Atoi(m[2])
+		if err != nil {
+			return model.AvgMonth{}, fmt.Errorf("%w: %q", ErrInvalidDate, str)
+		}
+		if len(m[2]) == 2 {
+			year = ExpandTwoDigitYear(year)
+		}
+		return model.AvgMonth{Year: uint(year), Month: month}, nil //nolint:gosec // year has at most four digits
+	}
+
+	if m := numericMonthRegex.FindStringSubmatch(str); m != nil {
+		monthStr := m[2]
+		if monthStr == "" {
+			monthStr = m[3]
+		}
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(monthStr)
+		if month < 1 || month > 12 {
+			return model.AvgMonth{}, fmt.Errorf("%w: month %d in %q", ErrInvalidDate, month, str)
+		}
+		return model.AvgMonth{Year: uint(year), Month: time.Month(month)}, nil //nolint:gosec // year has four digits
+	}
+
+	if date, err := ParseSwedishDate(str); err == nil {
+		return model.AvgMonth{Year: uint(date.Year()), Month: date.Month()}, nil //nolint:gosec // year is positive
+	}
+
+	return model.AvgMonth{}, fmt.Errorf("%w: %q", ErrInvalidDate, str)
+}
+
+// FindMonthYear returns the first month written as "oktober 2025" or "okt. 2025" in text, e.g. in
+// "Genomsnittlig ränta oktober 2025".
+func FindMonthYear(text string) (model.AvgMonth, error) {
+	text = strings.ToLower(NormalizeSpaces(text))
+
+	m := findMonthYearRegex.FindStringSubmatch(text)
+	if m == nil {
+		return model.AvgMonth{}, fmt.Errorf("%w: no month found in %q", ErrInvalidDate, text)
+	}
+
+	month, err := ParseSwedishMonth(m[1])
+	if err != nil {
+		return model.AvgMonth{}, err
+	}
+	year, _ := strconv.Atoi(m[2])
+
+	return model.AvgMonth{Year: uint(year), Month: month}, nil //nolint:gosec // year has four digits
+}
+
+// ExpandTwoDigitYear assumes all two-digit years lower than 40 are from the 21st century, otherwise the 20th century.
+// This works until the year 2039 and assumes no bank presents data from before 1940 with a two-digit year.
+func ExpandTwoDigitYear(year int) int {
+	if year < 40 {
+		return year + 2000
+	}
+	return year + 1900
+}
+
+func dateFromNamedMonth(dayStr, monthStr, yearStr string) (time.Time, error) {
+	month, err := ParseSwedishMonth(monthStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dateFromNumbers(yearStr, strconv.Itoa(int(month)), dayStr)
+}
+
+func dateFromNumbers(yearStr, monthStr, dayStr string) (time.Time, error) {
+	year, _ := strconv.Atoi(yearStr)
+	month, _ := strconv.Atoi(monthStr)
+	day, _ := strconv.Atoi(dayStr)
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date normalizes out-of-range values (e.g. 31 February becomes 3 March), so a mismatch means invalid input.
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, fmt.Errorf("%w: %s-%s-%s", ErrInvalidDate, yearStr, monthStr, dayStr)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,294 @@
+//nolint:revive,nolintlint // package name matches the package being tested
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestParseSwedishMonth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Month
+		wantErr bool
+	}{
+		{input: "januari", want: time.January},
+		{input: "jan", want: time.January},
+		{input: "februari", want: time.February},
+		{input: "feb.", want: time.February},
+		{input: "mars", want: time.March},
+		{input: "mar", want: time.March},
+		{input: "april", want: time.April},
+		{input: "apr", want: time.April},
+		{input: "maj", want: time.May},
+		{input: "may", want: time.May},
+		{input: "juni", want: time.June},
+		{input: "jun", want: time.June},
+		{input: "juli", want: time.July},
+		{input: "jul.", want: time.July},
+		{input: "augusti", want: time.August},
+		{input: "aug", want: time.August},
+		{input: "september", want: time.September},
+		{input: "sept.", want: time.September},
+		{input: "sep", want: time.September},
+		{input: "oktober", want: time.October},
+		{input: "okt.", want: time.October},
+		{input: "oct", want: time.October},
+		{input: "november", want: time.November},
+		{input: "nov", want: time.November},
+		{input: "december", want: time.December},
+		{input: "dec", want: time.December},
+		{input: "Oktober", want: time.October},
+		{input: "DECEMBER", want: time.December},
+		{input: " maj ", want: time.May},
+		{input: "octobre", wantErr: true},
+		{input: "invalid", wantErr: true},
+		{input: "ok", wantErr: true},
+		{input: "10", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSwedishMonth(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownMonth) {
+					t.Errorf("ParseSwedishMonth(%q) error = %v, want ErrUnknownMonth", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSwedishMonth(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSwedishMonth(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSwedishDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "day month name year", input: "25 september 2025", want: date(2025, time.September, 25)},
+		{name: "single digit day", input: "1 oktober 2025", want: date(2025, time.October, 1)},
+		{name: "capitalised month", input: "3 Mars 2024", want: date(2024, time.March, 3)},
+		{name: "abbreviated month with period", input: "25 sep. 2025", want: date(2025, time.September, 25)},
+		{name: "abbreviated month without period", input: "25 okt 2025", want: date(2025, time.October, 25)},
+		{name: "day with period", input: "25. september 2025", want: date(2025, time.September, 25)},
+		{name: "non-breaking spaces", input: "25\u00a0september\u00a02025", want: date(2025, time.September, 25)},
+		{name: "ISO date", input: "2025-09-25", want: date(2025, time.September, 25)},
+		{name: "dotted date", input: "2025.10.03", want: date(2025, time.October, 3)},
+		{name: "compact date", input: "20251003", want: date(2025, time.October, 3)},
+		{name: "surrounding spaces", input: "  2025-09-25 ", want: date(2025, time.September, 25)},
+		{name: "leap day", input: "29 februari 2024", want: date(2024, time.February, 29)},
+		{name: "invalid leap day", input: "29 februari 2025", wantErr: true},
+		{name: "day out of range", input: "2025-09-31", wantErr: true},
+		{name: "month out of range", input: "2025-13-01", wantErr: true},
+		{name: "unknown month name", input: "25 septembre 2025", wantErr: true},
+		{name: "text around date", input: "senast ändrad 25 september 2025", wantErr: true},
+		{name: "month only", input: "september 2025", wantErr: true},
+		{name: "two digit year", input: "25-09-25", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSwedishDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSwedishDate(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSwedishDate(%q) unexpected error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSwedishDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSwedishDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "named date in sentence", input: "Senast ändrad 25 september 2025", want: date(2025, time.September, 25)},
+		{name: "abbreviated month in sentence", input: "Gäller från 1 okt. 2025.", want: date(2025, time.October, 1)},
+		{name: "ISO date in markup", input: `<span class="date">2025-10-03</span>`, want: date(2025, time.October, 3)},
+		{name: "named date wins over ISO date", input: "2025-01-01 ändrad 2 maj 2025", want: date(2025, time.May, 2)},
+		{name: "date only", input: "2025-09-25", want: date(2025, time.September, 25)},
+		{name: "invalid date", input: "ändrad 31 juni 2025", wantErr: true},
+		{name: "no date", input: "Senast ändrad igår", wantErr: true},
+		{name: "month without day", input: "september 2025", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindSwedishDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindSwedishDate(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindSwedishDate(%q) unexpected error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("FindSwedishDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMonthYear(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    model.AvgMonth
+		wantErr bool
+	}{
+		{name: "month name and year", input: "oktober 2025", want: avgMonth(2025, time.October)},
+		{name: "capitalised month name", input: "Oktober 2025", want: avgMonth(2025, time.October)},
+		{name: "abbreviated month with period", input: "okt. 2025", want: avgMonth(2025, time.October)},
+		{name: "abbreviated month without period", input: "nov 2025", want: avgMonth(2025, time.November)},
+		{name: "english abbreviation", input: "Feb 1955", want: avgMonth(1955, time.February)},
+		{name: "capitalised abbreviation", input: "Nov 2025", want: avgMonth(2025, time.November)},
+		{name: "capitalised full month name", input: "November 2024", want: avgMonth(2024, time.November)},
+		{name: "month name and two digit year", input: "oktober-25", want: avgMonth(2025, time.October)},
+		{name: "abbreviated month and two digit year", input: "okt-25", want: avgMonth(2025, time.October)},
+		{name: "two digit year from the 1990s", input: "mars-95", want: avgMonth(1995, time.March)},
+		{name: "spaced dash", input: "mars - 2024", want: avgMonth(2024, time.March)},
+		{name: "year and month separated by space", input: "2025 10", want: avgMonth(2025, time.October)},
+		{name: "year and single digit month", input: "2025 1", want: avgMonth(2025, time.January)},
+		{name: "year and month separated by dash", input: "2025-10", want: avgMonth(2025, time.October)},
+		{name: "year and month separated by slash", input: "2025/10", want: avgMonth(2025, time.October)},
+		{name: "compact year and month", input: "202510", want: avgMonth(2025, time.October)},
+		{name: "full ISO date", input: "2025-10-31", want: avgMonth(2025, time.October)},
+		{name: "full named date", input: "31 oktober 2025", want: avgMonth(2025, time.October)},
+		{name: "non-breaking space", input: "oktober\u00a02025", want: avgMonth(2025, time.October)},
+		{name: "month zero", input: "2025 00", wantErr: true},
+		{name: "month 13", input: "202513", wantErr: true},
+		{name: "unknown month name", input: "octobre 2025", wantErr: true},
+		{name: "three digit year", input: "oktober 202", wantErr: true},
+		{name: "ambiguous compact month", input: "20251", wantErr: true},
+		{name: "text around month", input: "Genomsnittlig ränta oktober 2025", wantErr: true},
+		{name: "year only", input: "2025", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseMonthYear(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseMonthYear(%q) = %+v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMonthYear(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMonthYear(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMonthYear(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    model.AvgMonth
+		wantErr bool
+	}{
+		{name: "month in heading", input: "Genomsnittlig ränta oktober 2025", want: avgMonth(2025, time.October)},
+		{name: "abbreviated month in text", input: "Snitträntor för nov. 2025 (preliminär)", want: avgMonth(2025, time.November)},
+		{name: "first month wins", input: "september 2025 och oktober 2025", want: avgMonth(2025, time.September)},
+		{name: "month only", input: "Augusti 2021", want: avgMonth(2021, time.August)},
+		{name: "month without year", input: "Genomsnittlig ränta oktober", wantErr: true},
+		{name: "month name inside word", input: "Snittränta marsch 2025", wantErr: true},
+		{name: "numeric month", input: "Period 2025-10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindMonthYear(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindMonthYear(%q) = %+v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindMonthYear(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("FindMonthYear(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTwoDigitYear(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input int
+		want  int
+	}{
+		{input: 0, want: 2000},
+		{input: 25, want: 2025},
+		{input: 39, want: 2039},
+		{input: 40, want: 1940},
+		{input: 95, want: 1995},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandTwoDigitYear(tt.input); got != tt.want {
+			t.Errorf("ExpandTwoDigitYear(%d) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func avgMonth(year uint, month time.Month) model.AvgMonth {
+	return model.AvgMonth{Year: year, Month: month}
+}
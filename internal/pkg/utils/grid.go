@@ -0,0 +1,178 @@
+//nolint:revive,nolintlint // I like this package name, leave me alone
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// gridCell is a cell of a laid out table. Header is set for <th> cells.
+type gridCell struct {
+	text   string
+	header bool
+}
+
+// spanningCell is a cell of an earlier row that still spans the given number of rows.
+type spanningCell struct {
+	gridCell
+	rows int
+}
+
+// layoutTable lays out the rows of a table as grids of cells the way browsers render them: cells spanning several rows
+// or columns are repeated in each of them, the <thead> rows go into head and all other rows into body, with the
+// <tfoot> rows last wherever they are in the markup. Rows of nested tables are left out.
+func layoutTable(table *html.Node) ([][]gridCell, [][]gridCell) {
+	var head, body, foot [][]gridCell
+	var loose []*html.Node
+
+	// Bare rows between sections form an implicit tbody, as browsers insert one. Spans never cross a section.
+	flushLoose := func() {
+		body = append(body, sectionGrid(loose)...)
+		loose = nil
+	}
+
+	for child := table.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		switch child.Data {
+		case TagTr:
+			loose = append(loose, child)
+		case "thead":
+			flushLoose()
+			head = append(head, sectionGrid(sectionRows(child))...)
+		case "tbody":
+			flushLoose()
+			body = append(body, sectionGrid(sectionRows(child))...)
+		case "tfoot":
+			flushLoose()
+			foot = append(foot, sectionGrid(sectionRows(child))...)
+		}
+	}
+	flushLoose()
+
+	return head, append(body, foot...)
+}
+
+func sectionRows(section *html.Node) []*html.Node {
+	var rows []*html.Node
+	for tr := section.FirstChild; tr != nil; tr = tr.NextSibling {
+		if tr.Type == html.ElementNode && tr.Data == TagTr {
+			rows = append(rows, tr)
+		}
+	}
+	return rows
+}
+
+// sectionGrid lays out the rows of one table section.
+func sectionGrid(rows []*html.Node) [][]gridCell {
+	var grid [][]gridCell
+	// pending holds cells of earlier rows that span into later ones, by column.
+	pending := map[int]spanningCell{}
+
+	for _, tr := range rows {
+		var row []gridCell
+		col := 0
+		place := func() {
+			for {
+				cell, ok := pending[col]
+				if !ok {
+					return
+				}
+				row = append(row, cell.gridCell)
+				if cell.rows--; cell.rows == 0 {
+					delete(pending, col)
+				} else {
+					pending[col] = cell
+				}
+				col++
+			}
+		}
+
+		for td := tr.FirstChild; td != nil; td = td.NextSibling {
+			if !isCellNode(td) {
+				continue
+			}
+			place()
+			cell := gridCell{text: nodeText(td), header: td.Data == TagTh}
+			rowspan := spanAttr(td, "rowspan", 65534)
+			for range spanAttr(td, "colspan", 1000) {
+				row = append(row, cell)
+				if rowspan > 1 {
+					pending[col] = spanningCell{gridCell: cell, rows: rowspan - 1}
+				}
+				col++
+			}
+		}
+		place()
+		grid = append(grid, row)
+	}
+
+	return grid
+}
+
+func spanAttr(n *html.Node, name string, maxSpan int) int {
+	value, ok := lookupAttr(n, name)
+	if !ok {
+		return 1
+	}
+	span, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || span < 1 {
+		return 1
+	}
+	return min(span, maxSpan)
+}
+
+// cellTexts returns the texts of a grid.
+func cellTexts(grid [][]gridCell) [][]string {
+	texts := make([][]string, 0, len(grid))
+	for _, row := range grid {
+		textRow := make([]string, len(row))
+		for i, cell := range row {
+			textRow[i] = cell.text
+		}
+		texts = append(texts, textRow)
+	}
+	return texts
+}
+
+// isRowHeaderRow reports whether a row starts with a <th> that labels the <td> cells after it.
+func isRowHeaderRow(row []gridCell) bool {
+	if len(row) < 2 || !row[0].header {
+		return false
+	}
+	for _, cell := range row[1:] {
+		if !cell.header {
+			return true
+		}
+	}
+	return false
+}
+
+func isCellNode(n *html.Node) bool {
+	return n.Type == html.ElementNode && (n.Data == TagTd || n.Data == TagTh)
+}
+
+// nodeText returns the normalized text of a node and its descendants, leaving out nested tables. Line breaks count as
+// spaces.
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			sb.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			sb.WriteString(" ")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if !isTable(child) {
+				collect(child)
+			}
+		}
+	}
+	collect(n)
+	return NormalizeSpaces(sb.String())
+}
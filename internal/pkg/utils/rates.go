@@ -0,0 +1,190 @@
+//nolint:revive,nolintlint // I like this package name, leave me alone
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+// RateUnit is the unit a bank publishes a rate in. Parsed rates are always returned as a model.Rate.
+type RateUnit int
+
+const (
+	// UnitPercent is a percentage, e.g. "3,45 %" for 3.45%.
+	UnitPercent RateUnit = iota
+	// UnitBasisPoints is hundredths of a percent, e.g. 345 for 3.45%.
+	UnitBasisPoints
+	// UnitFraction is a plain fraction, e.g. 0.0345 for 3.45%.
+	UnitFraction
+)
+
+const rateNumberPattern = `(\d+(?:[.,]\d+)?)`
+
+var (
+	// ErrEmptyRate means the cell holds no rate, e.g. "", "-" or "n/a". Crawlers usually skip these silently.
+	ErrEmptyRate = errors.New("empty rate")
+	// ErrInvalidRate means the text is not a rate in any of the supported formats.
+	ErrInvalidRate = errors.New("invalid rate")
+	// ErrRateRange means a single rate was expected but the text holds a range like "4,45–9,30 %".
+	ErrRateRange = errors.New("rate is a range")
+
+	rateRegex      = regexp.MustCompile(`^` + rateNumberPattern + `$`)
+	rateRangeRegex = regexp.MustCompile(`^` + rateNumberPattern + ` ?(?:-|–|—|till) ?` + rateNumberPattern + `$`)
+	findRateRegex  = regexp.MustCompile(rateNumberPattern + ` ?%`)
+)
+
+// RateRange is a span of rates, e.g. the "4,45–9,30 %" a specialty lender quotes depending on the borrower.
+type RateRange struct {
+	Min model.Rate
+	Max model.Rate
+}
+
+// ParseRate parses a percentage rate in any of the forms the banks publish: "3,45 %", "3.45%", "3,45&nbsp;%",
+// "3,58 % %", "från 3,45 %" or a bare "3,45". The decimal is parsed exactly, so "3,45" is always model.Rate 3.45%.
+func ParseRate(str string) (model.Rate, error) {
+	return ParseRateIn(str, UnitPercent)
+}
+
+// ParseRateIn parses a rate published in the given unit, e.g. "333" in UnitBasisPoints for 3.33%.
+func ParseRateIn(str string, unit RateUnit) (model.Rate, error) {
+	normalized, err := normalizeRate(str)
+	if err != nil {
+		return 0, err
+	}
+
+	if rateRangeRegex.MatchString(normalized) {
+		return 0, fmt.Errorf("%w: %q", ErrRateRange, str)
+	}
+
+	matches := rateRegex.FindStringSubmatch(normalized)
+	if matches == nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRate, str)
+	}
+
+	return parseRateNumber(matches[1], unit)
+}
+
+// ParseRateRange parses a range of percentage rates like "4,45–9,30 %", "4,45 % - 9,30 %" or "4,45 till 9,30 %".
+// A single rate is returned as a range with equal bounds.
+func ParseRateRange(str string) (RateRange, error) {
+	normalized, err := normalizeRate(str)
+	if err != nil {
+		return RateRange{}, err
+	}
+
+	if matches := rateRegex.FindStringSubmatch(normalized); matches != nil {
+		rate, err := parseRateNumber(matches[1], UnitPercent)
+		if err != nil {
+			return RateRange{}, err
+		}
+		return RateRange{Min: rate, Max: rate}, nil
+	}
+
+	matches := rateRangeRegex.FindStringSubmatch(normalized)
+	if matches == nil {
+		return RateRange{}, fmt.Errorf("%w: %q", ErrInvalidRate, str)
+	}
+
+	lower, err := parseRateNumber(matches[1], UnitPercent)
+	if err != nil {
+		return RateRange{}, err
+	}
+	upper, err := parseRateNumber(matches[2], UnitPercent)
+	if err != nil {
+		return RateRange{}, err
+	}
+	if lower > upper {
+		return RateRange{}, fmt.Errorf("%w: lower bound above upper bound in %q", ErrInvalidRate, str)
+	}
+
+	return RateRange{Min: lower, Max: upper}, nil
+}
+
+// FindRate returns the first percentage in text, e.g. 4.45 in "Bolån från 4,45&nbsp;%" or in "<p>4,45 %</p>". Only
+// numbers followed by a percent sign count, so terms and years in the same text are ignored.
+func FindRate(text string) (model.Rate, error) {
+	text = NormalizeSpaces(html.UnescapeString(text))
+
+	matches := findRateRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return 0, fmt.Errorf("%w: no percentage found in %q", ErrInvalidRate, text)
+	}
+
+	return parseRateNumber(matches[1], UnitPercent)
+}
+
+// ConvertRate converts a numeric rate in the given unit, for sources that publish numbers instead of text. The value
+// is rounded to the nearest model.Rate, which removes float noise like 0.0333 * 100 = 3.3299999999999996.
+func ConvertRate(value float64, unit RateUnit) (model.Rate, error) {
+	switch unit {
+	case UnitPercent:
+		return model.RateFromPercent(value), nil
+	case UnitBasisPoints:
+		return model.RateFromBasisPoints(value), nil
+	case UnitFraction:
+		return model.RateFromPercent(value * 100), nil
+	default:
+		return 0, fmt.Errorf("%w: unknown unit %d", ErrInvalidRate, unit)
+	}
+}
+
+// normalizeRate strips everything around the number: markup entities, odd spaces, percent signs and the "från"
+// prefix banks put in front of their lowest rate.
+func normalizeRate(str string) (string, error) {
+	s := strings.ToLower(NormalizeSpaces(html.UnescapeString(str)))
+	s = strings.TrimPrefix(s, "från")
+	s = strings.TrimPrefix(s, "fr.")
+	s = strings.ReplaceAll(s, "%", "")
+	s = NormalizeSpaces(s)
+
+	switch s {
+	case "", "-", "–", "—", "n/a":
+		return "", fmt.Errorf("%w: %q", ErrEmptyRate, str)
+	}
+
+	return s, nil
+}
+
+// parseRateNumber parses a number like "3,45" exactly. Units are converted by moving the decimal point in the text
+// rather than by float arithmetic.
+func parseRateNumber(number string, unit RateUnit) (model.Rate, error) {
+	number = strings.ReplaceAll(number, ",", ".")
+
+	switch unit {
+	case UnitPercent:
+	case UnitBasisPoints:
+		number = shiftDecimalPoint(number, -2)
+	case UnitFraction:
+		number = shiftDecimalPoint(number, 2)
+	default:
+		return 0, fmt.Errorf("%w: unknown unit %d", ErrInvalidRate, unit)
+	}
+
+	rate, err := model.ParseRate(number)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %w", ErrInvalidRate, number, err)
+	}
+	return rate, nil
+}
+
+// shiftDecimalPoint moves the decimal point of a plain decimal like "333.5" by places, to the left if places is
+// negative: shiftDecimalPoint("333.5", -2) is "3.335".
+func shiftDecimalPoint(number string, places int) string {
+	intPart, fracPart, _ := strings.Cut(number, ".")
+	digits := intPart + fracPart
+
+	point := len(intPart) + places
+	if point < 1 {
+		digits = strings.Repeat("0", 1-point) + digits
+		point = 1
+	}
+	if point >= len(digits) {
+		return digits + strings.Repeat("0", point-len(digits))
+	}
+	return digits[:point] + "." + digits[point:]
+}
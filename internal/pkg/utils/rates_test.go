@@ -0,0 +1,223 @@
+//nolint:revive,nolintlint // package name matches the package being tested
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yama6a/bolan-compare/internal/pkg/model"
+)
+
+func TestParseRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr error
+	}{
+		{name: "comma decimal with spaced percent", input: "3,45 %", want: 3.45},
+		{name: "dot decimal with percent", input: "3.45%", want: 3.45},
+		{name: "bare comma decimal", input: "3,45", want: 3.45},
+		{name: "integer rate", input: "4 %", want: 4},
+		{name: "leading zero in decimals", input: "2,05 %", want: 2.05},
+		{name: "three decimals", input: "3,125%", want: 3.125},
+		{name: "decimals beyond a hundredth of a basis point are rounded", input: "3,33335 %", want: 3.3334},
+		{name: "html non-breaking space entity", input: "3,45&nbsp;%", want: 3.45},
+		{name: "unicode non-breaking space", input: "3,45\u00a0%", want: 3.45},
+		{name: "narrow no-break space", input: "3,45\u202f%", want: 3.45},
+		{name: "double percent sign", input: "3,58 % %", want: 3.58},
+		{name: "surrounding whitespace", input: "  2,59  %  ", want: 2.59},
+		{name: "från prefix", input: "från 3,45 %", want: 3.45},
+		{name: "capitalised från prefix", input: "Från 3,45 %", want: 3.45},
+		{name: "abbreviated från prefix", input: "fr. 3,45 %", want: 3.45},
+		{name: "empty", input: "", wantErr: ErrEmptyRate},
+		{name: "dash", input: "-", wantErr: ErrEmptyRate},
+		{name: "en dash", input: "–", wantErr: ErrEmptyRate},
+		{name: "percent only", input: " % ", wantErr: ErrEmptyRate},
+		{name: "not available", input: "N/A", wantErr: ErrEmptyRate},
+		{name: "range", input: "4,45–9,30 %", wantErr: ErrRateRange},
+		{name: "text", input: "abc", wantErr: ErrInvalidRate},
+		{name: "two decimal separators", input: "3,4,5", wantErr: ErrInvalidRate},
+		{name: "negative rate", input: "-0,5 %", wantErr: ErrInvalidRate},
+		{name: "text after rate", input: "3,45 % rörlig", wantErr: ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRate(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRate(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != model.RateFromPercent(tt.want) {
+				t.Errorf("ParseRate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRateIn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		unit    RateUnit
+		want    float64
+		wantErr error
+	}{
+		{name: "percent", input: "3,33 %", unit: UnitPercent, want: 3.33},
+		{name: "basis points", input: "333", unit: UnitBasisPoints, want: 3.33},
+		{name: "fractional basis points", input: "333.5", unit: UnitBasisPoints, want: 3.335},
+		{name: "fraction", input: "0.0333", unit: UnitFraction, want: 3.33},
+		{name: "fraction with comma", input: "0,0345", unit: UnitFraction, want: 3.45},
+		{name: "fraction below one percent", input: "0.005", unit: UnitFraction, want: 0.5},
+		{name: "fraction of a basis point", input: "0.5", unit: UnitBasisPoints, want: 0.005},
+		{name: "empty basis points", input: "", unit: UnitBasisPoints, wantErr: ErrEmptyRate},
+		{name: "unknown unit", input: "3,33", unit: RateUnit(42), wantErr: ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRateIn(tt.input, tt.unit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRateIn(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != model.RateFromPercent(tt.want) {
+				t.Errorf("ParseRateIn(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRateRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    RateRange
+		wantErr error
+	}{
+		{name: "en dash", input: "4,45–9,30 %", want: rateRange(4.45, 9.30)},
+		{name: "em dash", input: "4,45—9,30 %", want: rateRange(4.45, 9.30)},
+		{name: "hyphen with spaces", input: "4,45 - 9,30%", want: rateRange(4.45, 9.30)},
+		{name: "percent on both bounds", input: "4,45 % – 9,30 %", want: rateRange(4.45, 9.30)},
+		{name: "till", input: "4,45 till 9,30 %", want: rateRange(4.45, 9.30)},
+		{name: "non-breaking spaces", input: "4,45&nbsp;%&nbsp;–&nbsp;9,30&nbsp;%", want: rateRange(4.45, 9.30)},
+		{name: "single rate", input: "5,10 %", want: rateRange(5.10, 5.10)},
+		{name: "från single rate", input: "från 5,10 %", want: rateRange(5.10, 5.10)},
+		{name: "reversed bounds", input: "9,30–4,45 %", wantErr: ErrInvalidRate},
+		{name: "open range", input: "4,45– %", wantErr: ErrInvalidRate},
+		{name: "empty", input: "", wantErr: ErrEmptyRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRateRange(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRateRange(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != tt.want {
+				t.Errorf("ParseRateRange(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "rate in sentence", input: "Bolån från 4,45 % för 3 mån", want: 4.45},
+		{name: "rate with nbsp entity", input: "Bolån från 4,45&nbsp;%", want: 4.45},
+		{name: "rate in markup", input: "<p><strong>3,84 %</strong></p>", want: 3.84},
+		{name: "first rate wins", input: "3,10 % eller 3,20 %", want: 3.10},
+		{name: "numbers without percent ignored", input: "2025 3 år 3,10%", want: 3.10},
+		{name: "no percentage", input: "Bolån 3 år", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindRate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindRate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != model.RateFromPercent(tt.want) {
+				t.Errorf("FindRate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value float64
+		unit  RateUnit
+		want  float64
+	}{
+		{name: "percent unchanged", value: 3.45, unit: UnitPercent, want: 3.45},
+		{name: "basis points", value: 333, unit: UnitBasisPoints, want: 3.33},
+		{name: "fraction without float noise", value: 0.0333, unit: UnitFraction, want: 3.33},
+		{name: "zero", value: 0, unit: UnitFraction, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ConvertRate(tt.value, tt.unit)
+			if err != nil {
+				t.Fatalf("ConvertRate() error = %v", err)
+			}
+			if got != model.RateFromPercent(tt.want) {
+				t.Errorf("ConvertRate(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftDecimalPoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		places int
+		want   string
+	}{
+		{input: "333", places: -2, want: "3.33"},
+		{input: "333.5", places: -2, want: "3.335"},
+		{input: "5", places: -2, want: "0.05"},
+		{input: "0.0333", places: 2, want: "003.33"},
+		{input: "0.05", places: 2, want: "005"},
+		{input: "3", places: 2, want: "300"},
+	}
+
+	for _, tt := range tests {
+		if got := shiftDecimalPoint(tt.input, tt.places); got != tt.want {
+			t.Errorf("shiftDecimalPoint(%q, %d) = %q, want %q", tt.input, tt.places, got, tt.want)
+		}
+	}
+}
+
+func rateRange(minPercent, maxPercent float64) RateRange {
+	return RateRange{Min: model.RateFromPercent(minPercent), Max: model.RateFromPercent(maxPercent)}
+}
@@ -0,0 +1,189 @@
+//nolint:revive,nolintlint // I like this package name, leave me alone
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// compoundSelector is one step of a selector like `div#rates.table[data-kind="list"]`.
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrSelector
+	// child means the element must be a direct child of the element matched by the previous step.
+	child bool
+}
+
+type attrSelector struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// selectTables returns the tables selected by selector: selected tables themselves and the tables inside other selected
+// elements, in document order.
+func selectTables(doc *html.Node, selector string) ([]*html.Node, error) {
+	steps, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*html.Node
+	for _, n := range findAll(doc, func(n *html.Node) bool { return matchesSelector(n, steps) }) {
+		for _, table := range findAll(n, isTable) {
+			if !slices.Contains(tables, table) {
+				tables = append(tables, table)
+			}
+		}
+	}
+	return tables, nil
+}
+
+// matchesSelector matches the last step against n and the earlier steps against its ancestors, right to left.
+func matchesSelector(n *html.Node, steps []compoundSelector) bool {
+	last := steps[len(steps)-1]
+	if !last.matches(n) {
+		return false
+	}
+	if len(steps) == 1 {
+		return true
+	}
+
+	for ancestor := n.Parent; ancestor != nil; ancestor = ancestor.Parent {
+		if matchesSelector(ancestor, steps[:len(steps)-1]) {
+			return true
+		}
+		if last.child {
+			return false
+		}
+	}
+	return false
+}
+
+func (s compoundSelector) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode || (s.tag != "" && s.tag != "*" && s.tag != n.Data) {
+		return false
+	}
+	if s.id != "" && attrValue(n, "id") != s.id {
+		return false
+	}
+	classes := strings.Fields(attrValue(n, "class"))
+	for _, class := range s.classes {
+		if !slices.Contains(classes, class) {
+			return false
+		}
+	}
+	for _, attr := range s.attrs {
+		value, ok := lookupAttr(n, attr.key)
+		if !ok || (attr.hasValue && value != attr.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSelector(selector string) ([]compoundSelector, error) {
+	// Pad the child combinator so that "a>b" splits like "a > b".
+	fields := strings.Fields(strings.ReplaceAll(selector, ">", " > "))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty selector", ErrInvalidSelector)
+	}
+
+	var steps []compoundSelector
+	child := false
+	for _, field := range fields {
+		if field == ">" {
+			if len(steps) == 0 || child {
+				return nil, fmt.Errorf("%w: misplaced '>' in %q", ErrInvalidSelector, selector)
+			}
+			child = true
+			continue
+		}
+
+		step, err := parseCompound(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %q", err, selector)
+		}
+		step.child = child
+		child = false
+		steps = append(steps, step)
+	}
+	if child {
+		return nil, fmt.Errorf("%w: trailing '>' in %q", ErrInvalidSelector, selector)
+	}
+	return steps, nil
+}
+
+func parseCompound(str string) (compoundSelector, error) {
+	var s compoundSelector
+	s.tag, str = readIdent(str)
+	s.tag = strings.ToLower(s.tag)
+	if s.tag == "" && strings.HasPrefix(str, "*") {
+		s.tag, str = "*", str[1:]
+	}
+
+	for str != "" {
+		var name string
+		switch str[0] {
+		case '#':
+			name, str = readIdent(str[1:])
+			s.id = name
+		case '.':
+			name, str = readIdent(str[1:])
+			s.classes = append(s.classes, name)
+		case '[':
+			end := strings.IndexByte(str, ']')
+			if end < 0 {
+				return s, fmt.Errorf("%w: unclosed '['", ErrInvalidSelector)
+			}
+			s.attrs = append(s.attrs, parseAttrSelector(str[1:end]))
+			name, str = str[1:end], str[end+1:]
+		default:
+			return s, fmt.Errorf("%w: unexpected %q", ErrInvalidSelector, str[0])
+		}
+		if name == "" {
+			return s, fmt.Errorf("%w: empty name", ErrInvalidSelector)
+		}
+	}
+	return s, nil
+}
+
+func parseAttrSelector(str string) attrSelector {
+	key, value, hasValue := strings.Cut(str, "=")
+	return attrSelector{
+		key:      strings.TrimSpace(key),
+		value:    strings.Trim(strings.TrimSpace(value), `"'`),
+		hasValue: hasValue,
+	}
+}
+
+// readIdent reads a tag, id or class name from the start of str.
+func readIdent(str string) (string, string) {
+	end := strings.IndexFunc(str, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	})
+	if end < 0 {
+		end = len(str)
+	}
+	return str[:end], str[end:]
+}
+
+func attrValue(n *html.Node, key string) string {
+	value, _ := lookupAttr(n, key)
+	return value
+}
+
+func lookupAttr(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
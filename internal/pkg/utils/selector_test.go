@@ -0,0 +1,71 @@
+//nolint:revive,nolintlint // package name matches the package being tested
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestSelectTables(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><body>
+		<table id="first" class="rates"></table>
+		<section id="snitt">
+			<div data-block="interest"><table id="second" class="rates list"></table></div>
+			<table id="third"></table>
+		</section>
+	</body></html>`
+
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+		wantErr  error
+	}{
+		{name: "type", selector: "table", want: []string{"first", "second", "third"}},
+		{name: "id", selector: "#third", want: []string{"third"}},
+		{name: "classes", selector: "table.rates.list", want: []string{"second"}},
+		{name: "descendant", selector: "section table", want: []string{"second", "third"}},
+		{name: "child", selector: "section > table", want: []string{"third"}},
+		{name: "child without spaces", selector: "div[data-block=interest]>table", want: []string{"second"}},
+		{name: "attribute presence", selector: "[data-block]", want: []string{"second"}},
+		{name: "quoted attribute value", selector: `div[data-block="interest"] table`, want: []string{"second"}},
+		{name: "container selects its tables", selector: "section#snitt", want: []string{"second", "third"}},
+		{name: "universal", selector: "body > * > table", want: []string{"third"}},
+		{name: "no match", selector: "table.missing", want: nil},
+		{name: "empty", selector: " ", wantErr: ErrInvalidSelector},
+		{name: "leading combinator", selector: "> table", wantErr: ErrInvalidSelector},
+		{name: "double combinator", selector: "div > > table", wantErr: ErrInvalidSelector},
+		{name: "unclosed attribute", selector: "div[data-block", wantErr: ErrInvalidSelector},
+		{name: "empty class", selector: "table.", wantErr: ErrInvalidSelector},
+		{name: "unsupported syntax", selector: "table:first-child", wantErr: ErrInvalidSelector},
+	}
+
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tables, err := selectTables(doc, tt.selector)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("selectTables(%q) error = %v, want %v", tt.selector, err, tt.wantErr)
+			}
+
+			var got []string
+			for _, table := range tables {
+				got = append(got, attrValue(table, "id"))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("selectTables(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
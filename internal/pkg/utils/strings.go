@@ -4,12 +4,27 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/yama6a/bolan-compare/internal/pkg/model"
 )
 
-var ErrTermHeader = errors.New("row is a term header row")
+var (
+	ErrTermHeader = errors.New("row is a term header row")
+	// termRegex finds a number of months or years that is not the end of a longer number like a date.
+	termRegex = regexp.MustCompile(`(?:^|\D)(\d+) ?(mån|mo|år|yr|year)`)
+)
+
+// numberWords are the English number words at the index of their number.
+//
+//nolint:gochecknoglobals // constant lookup list
+var numberWords = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+	"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen", "twenty",
+}
 
 func NormalizeSpaces(str string) string {
 	str = strings.ReplaceAll(str, "&nbsp;", " ") // html non-breaking space
@@ -33,40 +48,56 @@ func NormalizeSpaces(str string) string {
 	return str
 }
 
-// nolint: cyclop // it's just one big switch statement, still readable
+// ParseTerm parses a term as written on the banks' pages and in their APIs, e.g. "3 mån", "18 månader", "1 år",
+// "15 yr" or "Rörlig". A number of months or years wins over the word rörlig, as some banks label their 3 months rate
+// "Rörlig (3 mån)".
 func ParseTerm(data string) (model.Term, error) {
-	str := NormalizeSpaces(data)
-	str = strings.ToLower(str)
-	str = strings.ReplaceAll(str, " ", "")
+	str := strings.ToLower(NormalizeSpaces(data))
+
+	if compact := strings.ReplaceAll(str, " ", ""); strings.Contains(compact, "genomsnittlig") ||
+		strings.Contains(compact, "bindningstid") || compact == "månad" || compact == "tot" {
+		return model.Term{}, ErrTermHeader
+	}
+
+	if matches := termRegex.FindStringSubmatch(str); matches != nil {
+		count, err := strconv.Atoi(matches[1])
+		if err != nil || count <= 0 {
+			return model.Term{}, fmt.Errorf("could not parse term: %s", data)
+		}
+		if strings.HasPrefix(matches[2], "m") {
+			return model.FixedTerm(count), nil
+		}
+		return model.FixedTerm(count * 12), nil
+	}
 
-	switch {
-	case strings.Contains(str, "genomsnittlig"), strings.Contains(str, "bindningstid"), str == "månad", str == "tot":
-		return "", ErrTermHeader
-	case strings.Contains(str, "3mån"), strings.Contains(str, "3mo"), strings.Contains(str, "3månader"):
-		return model.Term3months, nil
-	case strings.Contains(str, "6mån"), strings.Contains(str, "6mo"), strings.Contains(str, "6månader"):
-		return model.Term6months, nil
-	case strings.Contains(str, "1år"), strings.Contains(str, "1yr"):
-		return model.Term1year, nil
-	case strings.Contains(str, "2år"), strings.Contains(str, "2yr"):
-		return model.Term2years, nil
-	case strings.Contains(str, "3år"), strings.Contains(str, "3yr"):
-		return model.Term3years, nil
-	case strings.Contains(str, "4år"), strings.Contains(str, "4yr"):
-		return model.Term4years, nil
-	case strings.Contains(str, "5år"), strings.Contains(str, "5yr"):
-		return model.Term5years, nil
-	case strings.Contains(str, "6år"), strings.Contains(str, "6yr"):
-		return model.Term6years, nil
-	case strings.Contains(str, "7år"), strings.Contains(str, "7yr"):
-		return model.Term7years, nil
-	case strings.Contains(str, "8år"), strings.Contains(str, "8yr"):
-		return model.Term8years, nil
-	case strings.Contains(str, "9år"), strings.Contains(str, "9yr"):
-		return model.Term9years, nil
-	case strings.Contains(str, "10år"), strings.Contains(str, "10yr"):
-		return model.Term10years, nil
+	if strings.Contains(str, "rörlig") {
+		return model.TermVariable, nil
 	}
 
-	return "", fmt.Errorf("could not parse term: %s", data)
+	return model.Term{}, fmt.Errorf("could not parse term: %s", data)
+}
+
+// TermFromCount returns the fixed term of count units, where unit is a month or year like "MONTHS", "Year", "M", "y",
+// "mån" or "år".
+func TermFromCount(count int, unit string) (model.Term, error) {
+	if count <= 0 {
+		return model.Term{}, fmt.Errorf("could not parse term: %d %s", count, unit)
+	}
+
+	switch strings.TrimSuffix(strings.ToLower(unit), "s") {
+	case "month", "m", "mån", "månad", "månader":
+		return model.FixedTerm(count), nil
+	case "year", "y", "år":
+		return model.FixedTerm(count * 12), nil
+	default:
+		return model.Term{}, fmt.Errorf("could not parse term: %d %s", count, unit)
+	}
+}
+
+// ParseNumberWord parses an English number word like "three" or "TEN" as used in the term names of some bank APIs.
+func ParseNumberWord(word string) (int, error) {
+	if number := slices.Index(numberWords, strings.ToLower(word)); number > 0 {
+		return number, nil
+	}
+	return 0, fmt.Errorf("unknown number word: %s", word)
 }
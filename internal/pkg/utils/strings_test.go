@@ -177,23 +177,36 @@ func TestParseTerm(t *testing.T) {
 		{name: "10 år", input: "10 år", want: model.Term10years},
 		{name: "10 yr", input: "10 yr", want: model.Term10years},
 
+		// Other binding periods
+		{name: "1 mån", input: "1 mån", want: model.Term1month},
+		{name: "18 månader", input: "18 månader", want: model.FixedTerm(18)},
+		{name: "12 mån is 1 year", input: "12 mån", want: model.Term1year},
+		{name: "15 år", input: "15 år", want: model.Term15years},
+		{name: "after a date", input: "2025-09 3 mån", want: model.Term3months},
+
+		// Variable rate
+		{name: "Rörlig", input: "Rörlig", want: model.TermVariable},
+		{name: "rörligt lowercase", input: "rörligt", want: model.TermVariable},
+		{name: "Rörlig with months", input: "Rörlig (3 mån)", want: model.Term3months},
+
 		// With extra whitespace
 		{name: "with leading/trailing spaces", input: "  3 mån  ", want: model.Term3months},
 		{name: "with non-breaking space", input: "3\u00A0mån", want: model.Term3months},
 
 		// Header rows (should return ErrTermHeader)
-		{name: "header Bindningstid", input: "Bindningstid", want: "", wantErr: ErrTermHeader},
-		{name: "header bindningstid lowercase", input: "bindningstid", want: "", wantErr: ErrTermHeader},
-		{name: "header Genomsnittlig", input: "Genomsnittlig", want: "", wantErr: ErrTermHeader},
-		{name: "header genomsnittlig lowercase", input: "genomsnittlig ränta", want: "", wantErr: ErrTermHeader},
-		{name: "header Månad", input: "Månad", want: "", wantErr: ErrTermHeader},
-		{name: "header månad lowercase", input: "månad", want: "", wantErr: ErrTermHeader},
-		{name: "header tot", input: "tot", want: "", wantErr: ErrTermHeader},
+		{name: "header Bindningstid", input: "Bindningstid", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header bindningstid lowercase", input: "bindningstid", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header Genomsnittlig", input: "Genomsnittlig", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header genomsnittlig lowercase", input: "genomsnittlig ränta", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header Månad", input: "Månad", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header månad lowercase", input: "månad", want: model.Term{}, wantErr: ErrTermHeader},
+		{name: "header tot", input: "tot", want: model.Term{}, wantErr: ErrTermHeader},
 
 		// Invalid terms
-		{name: "invalid empty", input: "", want: "", wantErr: nil},
-		{name: "invalid random text", input: "random", want: "", wantErr: nil},
-		{name: "invalid number only", input: "5", want: "", wantErr: nil},
+		{name: "invalid empty", input: "", want: model.Term{}, wantErr: nil},
+		{name: "invalid random text", input: "random", want: model.Term{}, wantErr: nil},
+		{name: "invalid number only", input: "5", want: model.Term{}, wantErr: nil},
+		{name: "invalid zero months", input: "0 mån", want: model.Term{}, wantErr: nil},
 	}
 
 	for _, tt := range tests {
@@ -209,12 +222,12 @@ func TestParseTerm(t *testing.T) {
 				return
 			}
 
-			if tt.want == "" && err == nil {
+			if tt.want.IsZero() && err == nil {
 				t.Errorf("ParseTerm() expected error for invalid input %q", tt.input)
 				return
 			}
 
-			if tt.want != "" {
+			if !tt.want.IsZero() {
 				if err != nil {
 					t.Errorf("ParseTerm() unexpected error = %v", err)
 					return
@@ -226,3 +239,69 @@ func TestParseTerm(t *testing.T) {
 		})
 	}
 }
+
+func TestTermFromCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		count   int
+		unit    string
+		want    model.Term
+		wantErr bool
+	}{
+		{name: "months", count: 3, unit: "MONTHS", want: model.Term3months},
+		{name: "month", count: 1, unit: "Month", want: model.Term1month},
+		{name: "18 months", count: 18, unit: "M", want: model.FixedTerm(18)},
+		{name: "years", count: 15, unit: "YEARS", want: model.Term15years},
+		{name: "year letter", count: 1, unit: "y", want: model.Term1year},
+		{name: "år", count: 5, unit: "år", want: model.Term5years},
+		{name: "zero", count: 0, unit: "years", wantErr: true},
+		{name: "unknown unit", count: 3, unit: "weeks", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := TermFromCount(tt.count, tt.unit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TermFromCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TermFromCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNumberWord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		word    string
+		want    int
+		wantErr bool
+	}{
+		{word: "one", want: 1},
+		{word: "THREE", want: 3},
+		{word: "Fifteen", want: 15},
+		{word: "zero", wantErr: true},
+		{word: "3", wantErr: true},
+		{word: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseNumberWord(tt.word)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseNumberWord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseNumberWord() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,432 @@
+//nolint:revive,nolintlint // I like this package name, leave me alone
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// ErrTableNotFound means no table in the page matched the TableLocator.
+	ErrTableNotFound = errors.New("table not found")
+	// ErrColumnNotFound means a required TableColumn matched no header, usually because the bank renamed or removed it.
+	ErrColumnNotFound = errors.New("column not found")
+	// ErrInvalidSelector means a TableLocator selector could not be parsed.
+	ErrInvalidSelector = errors.New("invalid selector")
+)
+
+// Orientation tells ExtractTable which way a table's records run.
+type Orientation int
+
+const (
+	// RowRecords is the usual layout: headers on top, one record per row.
+	RowRecords Orientation = iota
+	// ColumnRecords is the pivoted layout: headers in the leftmost column, one record per column. E.g. a table with
+	// one column per term and the rate in the second row.
+	ColumnRecords
+)
+
+// TableSpec describes a table declaratively: where it is in the page, how its header is laid out and which columns a
+// crawler reads from it.
+type TableSpec struct {
+	Locate      TableLocator
+	Orientation Orientation
+	// HeaderRows is the number of header rows (header columns for ColumnRecords), default 1. Stacked headers are joined
+	// per column, e.g. a "Fast ränta" cell spanning three columns above "1 år", "2 år" and "3 år" gives "Fast ränta 1 år".
+	HeaderRows int
+	Columns    []TableColumn
+}
+
+// TableLocator finds a table in a page. All set criteria must match; of the tables that do, Skip are skipped.
+type TableLocator struct {
+	// Caption matches tables whose <caption> contains the text.
+	Caption string
+	// TextBefore matches tables after the first text in the page containing the text, e.g. a heading.
+	TextBefore string
+	// Selector matches tables selected by a CSS selector, or inside an element selected by it. Supported are type, #id,
+	// .class, [attr] and [attr=value] selectors (values without spaces), combined with descendant (space) and child (>)
+	// combinators, e.g. "section#snitt table.rates" or "div[data-block=interest] > table".
+	Selector string
+	Skip     int
+}
+
+// TableColumn selects the column(s) of a table whose header matches. Every column is claimed by the first TableColumn
+// that matches it, in spec order.
+type TableColumn struct {
+	// Name is the key the cells are stored under in a TableRecord.
+	Name string
+	// Match selects columns by their header. If nil, the column at Index is selected whatever its header.
+	Match HeaderMatcher
+	Index int
+	// Optional columns may be missing, otherwise ExtractTable fails with ErrColumnNotFound.
+	Optional bool
+	// Repeated selects every matching column instead of only the first, e.g. one column per term.
+	Repeated bool
+}
+
+// HeaderMatcher reports whether a column header belongs to a TableColumn.
+type HeaderMatcher func(header string) bool
+
+// HeaderContains matches headers that contain all the given texts, ignoring case.
+func HeaderContains(texts ...string) HeaderMatcher {
+	return func(header string) bool {
+		header = strings.ToLower(header)
+		for _, text := range texts {
+			if !strings.Contains(header, strings.ToLower(text)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// HeaderRegexp matches headers matching the regular expression.
+func HeaderRegexp(re *regexp.Regexp) HeaderMatcher {
+	return re.MatchString
+}
+
+// HeaderIsTerm matches headers that name a mortgage term, e.g. "3 mån" or "Fast 5 år".
+func HeaderIsTerm(header string) bool {
+	_, err := ParseTerm(header)
+	return err == nil
+}
+
+// Except matches headers matched by m but not by other, e.g. HeaderContains("ränta").Except(HeaderContains("ändring")).
+func (m HeaderMatcher) Except(other HeaderMatcher) HeaderMatcher {
+	return func(header string) bool {
+		return m(header) && !other(header)
+	}
+}
+
+// TableCell is a cell of a TableRecord together with the header of its column.
+type TableCell struct {
+	Header string
+	Text   string
+}
+
+// TableRecord is one row (or column, for ColumnRecords) of an extracted table.
+type TableRecord struct {
+	// Index is the position of the record below the header, starting at 0.
+	Index int
+	cells map[string][]TableCell
+}
+
+// Get returns the text of the named column, or "" if the record has no such cell.
+func (r TableRecord) Get(name string) string {
+	if cells := r.cells[name]; len(cells) > 0 {
+		return cells[0].Text
+	}
+	return ""
+}
+
+// Cells returns the cells of the named column, one per matched column for Repeated columns.
+func (r TableRecord) Cells(name string) []TableCell {
+	return r.cells[name]
+}
+
+// ExtractedTable is a table extracted by ExtractTable.
+type ExtractedTable struct {
+	// Heading is the table's caption, or else the text of the last heading (h1 to h6) before it, e.g. the name of the
+	// series a table holds when a page has several.
+	Heading string
+	// Header holds the header of every column of the table, whether the spec selects it or not.
+	Header  []string
+	Records []TableRecord
+}
+
+// ExtractTable locates a table in rawHTML and returns its records with the cells of the spec's columns. Cells spanning
+// several rows or columns are repeated in each of them. Records whose cells are all empty are skipped. If a required
+// column is missing, the error wraps ErrColumnNotFound and the returned table still has its Header.
+func ExtractTable(rawHTML string, spec TableSpec) (ExtractedTable, error) {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return ExtractedTable{}, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	table, err := locateTable(doc, spec.Locate)
+	if err != nil {
+		return ExtractedTable{}, err
+	}
+	return extractTable(doc, table, spec)
+}
+
+// ExtractTables extracts every table matching the locator, from the Skip-th on, that has the spec's required columns,
+// e.g. one table per risk class. Tables missing a required column are left out; if all of them do, the error and the
+// Header of the first are returned like by ExtractTable.
+func ExtractTables(rawHTML string, spec TableSpec) ([]ExtractedTable, error) {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	candidates, err := locateTables(doc, spec.Locate)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []ExtractedTable
+	var first ExtractedTable
+	var firstErr error
+	for i, table := range candidates {
+		extracted, err := extractTable(doc, table, spec)
+		if err != nil {
+			if i == 0 {
+				first, firstErr = extracted, err
+			}
+			continue
+		}
+		tables = append(tables, extracted)
+	}
+	if len(tables) == 0 {
+		return []ExtractedTable{first}, firstErr
+	}
+	return tables, nil
+}
+
+// extractTable returns the records of a located table with the cells of the spec's columns.
+func extractTable(doc, table *html.Node, spec TableSpec) (ExtractedTable, error) {
+	head, body := layoutTable(table)
+	grid := cellTexts(append(head, body...))
+	if spec.Orientation == ColumnRecords {
+		grid = transpose(grid)
+	}
+
+	headerRows := max(spec.HeaderRows, 1)
+	extracted := ExtractedTable{Heading: tableHeading(doc, table), Header: joinHeaderRows(grid[:min(headerRows, len(grid))])}
+	columns, err := matchColumns(extracted.Header, spec.Columns)
+	if err != nil {
+		return extracted, err
+	}
+
+	for i, row := range grid[min(headerRows, len(grid)):] {
+		if isEmptyRow(row) {
+			continue
+		}
+
+		record := TableRecord{Index: i, cells: make(map[string][]TableCell, len(columns))}
+		for name, indices := range columns {
+			for _, col := range indices {
+				if col < len(row) {
+					record.cells[name] = append(record.cells[name], TableCell{Header: extracted.Header[col], Text: row[col]})
+				}
+			}
+		}
+		extracted.Records = append(extracted.Records, record)
+	}
+
+	return extracted, nil
+}
+
+// locateTable returns the table matching the locator.
+func locateTable(doc *html.Node, locator TableLocator) (*html.Node, error) {
+	candidates, err := locateTables(doc, locator)
+	if err != nil {
+		return nil, err
+	}
+	return candidates[0], nil
+}
+
+// locateTables returns the tables matching the locator, from the Skip-th on. It fails if there is none.
+func locateTables(doc *html.Node, locator TableLocator) ([]*html.Node, error) {
+	candidates := findAll(doc, isTable)
+
+	if locator.TextBefore != "" {
+		candidates = tablesAfterText(doc, candidates, locator.TextBefore)
+	}
+
+	if locator.Caption != "" {
+		candidates = slices.DeleteFunc(candidates, func(table *html.Node) bool {
+			return !strings.Contains(tableCaption(table), NormalizeSpaces(locator.Caption))
+		})
+	}
+
+	if locator.Selector != "" {
+		selected, err := selectTables(doc, locator.Selector)
+		if err != nil {
+			return nil, err
+		}
+		candidates = slices.DeleteFunc(candidates, func(table *html.Node) bool {
+			return !slices.Contains(selected, table)
+		})
+	}
+
+	if locator.Skip >= len(candidates) {
+		return nil, fmt.Errorf("%w: %s", ErrTableNotFound, locator)
+	}
+	return candidates[locator.Skip:], nil
+}
+
+func (l TableLocator) String() string {
+	var parts []string
+	if l.Caption != "" {
+		parts = append(parts, fmt.Sprintf("caption %q", l.Caption))
+	}
+	if l.TextBefore != "" {
+		parts = append(parts, fmt.Sprintf("after text %q", l.TextBefore))
+	}
+	if l.Selector != "" {
+		parts = append(parts, fmt.Sprintf("selector %q", l.Selector))
+	}
+	if l.Skip > 0 {
+		parts = append(parts, fmt.Sprintf("skipping %d", l.Skip))
+	}
+	if len(parts) == 0 {
+		return "any table"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// tablesAfterText keeps the tables that start after the first text node containing text.
+func tablesAfterText(doc *html.Node, tables []*html.Node, text string) []*html.Node {
+	text = NormalizeSpaces(text)
+	found := false
+	var after []*html.Node
+	walkNodes(doc, func(n *html.Node) {
+		switch {
+		case !found && n.Type == html.TextNode && strings.Contains(NormalizeSpaces(n.Data), text):
+			found = true
+		case found && slices.Contains(tables, n):
+			after = append(after, n)
+		}
+	})
+	return after
+}
+
+// tableHeading returns the caption of the table, or else the text of the last heading before it.
+func tableHeading(doc, table *html.Node) string {
+	if caption := tableCaption(table); caption != "" {
+		return caption
+	}
+
+	heading, done := "", false
+	walkNodes(doc, func(n *html.Node) {
+		switch {
+		case done:
+		case n == table:
+			done = true
+		case n.Type == html.ElementNode && isHeading(n.Data):
+			heading = nodeText(n)
+		}
+	})
+	return heading
+}
+
+func isHeading(tag string) bool {
+	return len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6'
+}
+
+func tableCaption(table *html.Node) string {
+	for child := table.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.Data == "caption" {
+			return nodeText(child)
+		}
+	}
+	return ""
+}
+
+func transpose(grid [][]string) [][]string {
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+
+	transposed := make([][]string, width)
+	for col := range transposed {
+		transposed[col] = make([]string, len(grid))
+		for i, row := range grid {
+			if col < len(row) {
+				transposed[col][i] = row[col]
+			}
+		}
+	}
+	return transposed
+}
+
+// joinHeaderRows joins stacked header rows per column. A text repeated by a rowspan is only used once.
+func joinHeaderRows(rows [][]string) []string {
+	var headers []string
+	for _, row := range rows {
+		for col, text := range row {
+			if col >= len(headers) {
+				headers = append(headers, make([]string, col-len(headers)+1)...)
+			}
+			if text == "" || strings.HasSuffix(headers[col], text) {
+				continue
+			}
+			headers[col] = strings.TrimSpace(headers[col] + " " + text)
+		}
+	}
+	return headers
+}
+
+// matchColumns maps each TableColumn name to the indices of its columns. All missing required columns are reported
+// in one error, together with the header that was found.
+func matchColumns(headers []string, specs []TableColumn) (map[string][]int, error) {
+	columns := make(map[string][]int, len(specs))
+	claimed := make(map[int]bool)
+	var missing []string
+
+	for _, spec := range specs {
+		for col, header := range headers {
+			if claimed[col] || !spec.matches(col, header) {
+				continue
+			}
+			claimed[col] = true
+			columns[spec.Name] = append(columns[spec.Name], col)
+			if !spec.Repeated {
+				break
+			}
+		}
+		if len(columns[spec.Name]) == 0 && !spec.Optional {
+			missing = append(missing, spec.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s in header %q", ErrColumnNotFound, strings.Join(missing, ", "), headers)
+	}
+	return columns, nil
+}
+
+func (c TableColumn) matches(col int, header string) bool {
+	if c.Match == nil {
+		return col == c.Index
+	}
+	return c.Match(header)
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if cell != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isTable(n *html.Node) bool {
+	return n.Type == html.ElementNode && n.Data == TagTable
+}
+
+// walkNodes calls visit for n and all its descendants in document order.
+func walkNodes(n *html.Node, visit func(*html.Node)) {
+	visit(n)
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		walkNodes(child, visit)
+	}
+}
+
+func findAll(n *html.Node, match func(*html.Node) bool) []*html.Node {
+	var found []*html.Node
+	walkNodes(n, func(d *html.Node) {
+		if match(d) {
+			found = append(found, d)
+		}
+	})
+	return found
+}
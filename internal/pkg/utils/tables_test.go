@@ -0,0 +1,304 @@
+//nolint:revive,nolintlint // package name matches the package being tested
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// recordTexts flattens records to the texts of the named columns, for comparison.
+func recordTexts(records []TableRecord, names ...string) [][]string {
+	var texts [][]string
+	for _, record := range records {
+		var row []string
+		for _, name := range names {
+			for _, cell := range record.Cells(name) {
+				row = append(row, cell.Text)
+			}
+		}
+		texts = append(texts, row)
+	}
+	return texts
+}
+
+func TestExtractTable(t *testing.T) {
+	t.Parallel()
+
+	listTable := `<html><body>
+		<h2>Aktuella räntor</h2>
+		<table>
+			<tr><th>Bindningstid</th><th>Ränta</th><th>Ändring</th><th>Datum</th></tr>
+			<tr><td>3 mån</td><td>3,45 %</td><td>-0,10</td><td>2025-01-15</td></tr>
+			<tr><td></td><td></td><td></td><td></td></tr>
+			<tr><td>1 år</td><td>3,10 %</td><td>0,00</td><td>2025-01-15</td></tr>
+		</table>
+	</body></html>`
+
+	tests := []struct {
+		name    string
+		html    string
+		spec    TableSpec
+		names   []string
+		want    [][]string
+		wantErr error
+	}{
+		{
+			name: "columns by header text in spec order",
+			html: listTable,
+			spec: TableSpec{
+				Locate: TableLocator{TextBefore: "Aktuella räntor"},
+				Columns: []TableColumn{
+					{Name: "date", Match: HeaderContains("datum")},
+					{Name: "rate", Match: HeaderContains("ränta").Except(HeaderContains("ändring"))},
+					{Name: "term", Index: 0},
+				},
+			},
+			names: []string{"term", "rate", "date"},
+			want:  [][]string{{"3 mån", "3,45 %", "2025-01-15"}, {"1 år", "3,10 %", "2025-01-15"}},
+		},
+		{
+			name: "missing columns are reported together",
+			html: listTable,
+			spec: TableSpec{
+				Columns: []TableColumn{
+					{Name: "rate", Match: HeaderContains("listränta")},
+					{Name: "term", Index: 0},
+					{Name: "discount", Match: HeaderContains("rabatt")},
+				},
+			},
+			wantErr: ErrColumnNotFound,
+		},
+		{
+			name: "optional column may be missing",
+			html: listTable,
+			spec: TableSpec{
+				Columns: []TableColumn{
+					{Name: "term", Index: 0},
+					{Name: "discount", Match: HeaderContains("rabatt"), Optional: true},
+				},
+			},
+			names: []string{"term", "discount"},
+			want:  [][]string{{"3 mån"}, {"1 år"}},
+		},
+		{
+			name: "repeated term columns",
+			html: `<table>
+				<tr><td>Månad</td><td>3 mån</td><td>Kommentar</td><td>1 år</td></tr>
+				<tr><td>2025-01</td><td>3,45</td><td>x</td><td>3,10</td></tr>
+			</table>`,
+			spec: TableSpec{
+				Columns: []TableColumn{
+					{Name: "month", Index: 0},
+					{Name: "rates", Match: HeaderIsTerm, Repeated: true},
+				},
+			},
+			names: []string{"month", "rates"},
+			want:  [][]string{{"2025-01", "3,45", "3,10"}},
+		},
+		{
+			name: "pivoted table with one record per column",
+			html: `<table>
+				<tr><th>Bindningstid</th><td>3 mån</td><td>1 år</td></tr>
+				<tr><th>Ränta</th><td>4,45 %</td><td>4,10 %</td></tr>
+			</table>`,
+			spec: TableSpec{
+				Orientation: ColumnRecords,
+				Columns: []TableColumn{
+					{Name: "rate", Match: HeaderContains("ränta")},
+					{Name: "term", Index: 0},
+				},
+			},
+			names: []string{"term", "rate"},
+			want:  [][]string{{"3 mån", "4,45 %"}, {"1 år", "4,10 %"}},
+		},
+		{
+			name: "stacked header rows with spans",
+			html: `<table>
+				<tr><th rowspan="2">Månad</th><th colspan="2">Fast ränta</th></tr>
+				<tr><th>1 år</th><th>3 år</th></tr>
+				<tr><td>2025-01</td><td>3,10</td><td>3,30</td></tr>
+			</table>`,
+			spec: TableSpec{
+				HeaderRows: 2,
+				Columns: []TableColumn{
+					{Name: "month", Match: HeaderContains("månad")},
+					{Name: "1y", Match: HeaderRegexp(regexp.MustCompile(`^Fast ränta 1 år$`))},
+					{Name: "3y", Match: HeaderContains("fast", "3 år")},
+				},
+			},
+			names: []string{"month", "1y", "3y"},
+			want:  [][]string{{"2025-01", "3,10", "3,30"}},
+		},
+		{
+			name: "row span repeats the cell in the following rows",
+			html: `<table>
+				<tr><th>Månad</th><th>Bindningstid</th><th>Ränta</th></tr>
+				<tr><td rowspan="2">2025-01</td><td>3 mån</td><td>3,45</td></tr>
+				<tr><td>1 år</td><td>3,10</td></tr>
+			</table>`,
+			spec: TableSpec{
+				Columns: []TableColumn{
+					{Name: "month", Match: HeaderContains("månad")},
+					{Name: "term", Match: HeaderContains("bindningstid")},
+					{Name: "rate", Match: HeaderContains("ränta")},
+				},
+			},
+			names: []string{"month", "term", "rate"},
+			want:  [][]string{{"2025-01", "3 mån", "3,45"}, {"2025-01", "1 år", "3,10"}},
+		},
+		{
+			name: "table by caption",
+			html: `<table><caption>Listräntor</caption><tr><th>A</th></tr><tr><td>1</td></tr></table>
+				<table><caption>Snitträntor</caption><tr><th>A</th></tr><tr><td>2</td></tr></table>`,
+			spec:  TableSpec{Locate: TableLocator{Caption: "Snitträntor"}, Columns: []TableColumn{{Name: "a", Index: 0}}},
+			names: []string{"a"},
+			want:  [][]string{{"2"}},
+		},
+		{
+			name: "table by selector and skip",
+			html: `<table><tr><th>A</th></tr><tr><td>1</td></tr></table>
+				<div class="rates block"><table><tr><th>A</th></tr><tr><td>2</td></tr></table>
+				<table><tr><th>A</th></tr><tr><td>3</td></tr></table></div>`,
+			spec: TableSpec{
+				Locate:  TableLocator{Selector: "div.rates > table", Skip: 1},
+				Columns: []TableColumn{{Name: "a", Index: 0}},
+			},
+			names: []string{"a"},
+			want:  [][]string{{"3"}},
+		},
+		{
+			name: "text before with entity and line break in cell",
+			html: `<h2>Bol&aring;n*</h2><table><tr><th>Fast<br>1 år</th></tr><tr><td>3,<b>10</b></td></tr></table>`,
+			spec: TableSpec{
+				Locate:  TableLocator{TextBefore: "Bolån*"},
+				Columns: []TableColumn{{Name: "rate", Match: HeaderContains("fast 1 år")}},
+			},
+			names: []string{"rate"},
+			want:  [][]string{{"3,10"}},
+		},
+		{
+			name:    "no table after text",
+			html:    `<table><tr><td>1</td></tr></table><p>Snitträntor</p>`,
+			spec:    TableSpec{Locate: TableLocator{TextBefore: "Snitträntor"}},
+			wantErr: ErrTableNotFound,
+		},
+		{
+			name:    "invalid selector",
+			html:    listTable,
+			spec:    TableSpec{Locate: TableLocator{Selector: "div >"}},
+			wantErr: ErrInvalidSelector,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := ExtractTable(tt.html, tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtractTable() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got := recordTexts(table.Records, tt.names...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractTable() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTable_CellHeaders(t *testing.T) {
+	t.Parallel()
+
+	table, err := ExtractTable(`<table>
+		<tr><th>Månad</th><th>3 mån</th><th>1 år</th></tr>
+		<tr><td>2025-01</td><td>3,45</td></tr>
+	</table>`, TableSpec{
+		Columns: []TableColumn{
+			{Name: "month", Index: 0},
+			{Name: "rates", Match: HeaderIsTerm, Repeated: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExtractTable() error = %v", err)
+	}
+	if len(table.Records) != 1 {
+		t.Fatalf("ExtractTable() returned %d records, want 1", len(table.Records))
+	}
+	records := table.Records
+
+	// The short row has no cell for "1 år".
+	want := []TableCell{{Header: "3 mån", Text: "3,45"}}
+	if got := records[0].Cells("rates"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cells() = %+v, want %+v", got, want)
+	}
+	if got := records[0].Get("missing"); got != "" {
+		t.Errorf("Get() of unknown column = %q, want empty", got)
+	}
+}
+
+func TestExtractTable_HeaderOnMissingColumn(t *testing.T) {
+	t.Parallel()
+
+	table, err := ExtractTable(`<table><tr><th>Löptid</th><th>Ränta</th></tr><tr><td>3 mån</td><td>3,45</td></tr></table>`,
+		TableSpec{Columns: []TableColumn{{Name: "term", Match: HeaderContains("bindningstid")}}})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("ExtractTable() error = %v, want %v", err, ErrColumnNotFound)
+	}
+	if want := []string{"Löptid", "Ränta"}; !reflect.DeepEqual(table.Header, want) {
+		t.Errorf("Header = %q, want %q", table.Header, want)
+	}
+	if table.Records != nil {
+		t.Errorf("Records = %v, want none", table.Records)
+	}
+}
+
+func TestExtractTables(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><body>
+		<h2>Genomsnittsräntor</h2>
+		<h3>Riskklass A</h3>
+		<table><tr><th>Månad</th><th>3 mån</th></tr><tr><td>2025 11</td><td>4,10 %</td></tr></table>
+		<p>Räntorna avser nya lån.</p>
+		<table><tr><th>Avgift</th><th>Belopp</th></tr><tr><td>Uppläggning</td><td>0 kr</td></tr></table>
+		<table><caption>Riskklass B</caption><tr><th>Månad</th><th>3 mån</th></tr><tr><td>2025 11</td><td>5,20 %</td></tr></table>
+	</body></html>`
+	spec := TableSpec{
+		Locate:  TableLocator{TextBefore: "Genomsnittsräntor"},
+		Columns: []TableColumn{{Name: "month", Index: 0}, {Name: "rates", Match: HeaderIsTerm, Repeated: true}},
+	}
+
+	tables, err := ExtractTables(page, spec)
+	if err != nil {
+		t.Fatalf("ExtractTables() error = %v", err)
+	}
+	var headings []string
+	var texts [][]string
+	for _, table := range tables {
+		headings = append(headings, table.Heading)
+		texts = append(texts, recordTexts(table.Records, "month", "rates")...)
+	}
+	if want := []string{"Riskklass A", "Riskklass B"}; !reflect.DeepEqual(headings, want) {
+		t.Errorf("headings = %q, want %q", headings, want)
+	}
+	if want := [][]string{{"2025 11", "4,10 %"}, {"2025 11", "5,20 %"}}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("records = %q, want %q", texts, want)
+	}
+
+	tables, err = ExtractTables(page, TableSpec{Columns: []TableColumn{{Name: "rate", Match: HeaderContains("ränta")}}})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("ExtractTables() error = %v, want %v", err, ErrColumnNotFound)
+	}
+	if len(tables) != 1 || !reflect.DeepEqual(tables[0].Header, []string{"Månad", "3 mån"}) {
+		t.Errorf("tables = %+v, want only the header of the first table", tables)
+	}
+
+	if _, err := ExtractTables(page, TableSpec{Locate: TableLocator{Caption: "Riskklass C"}}); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("ExtractTables() error = %v, want %v", err, ErrTableNotFound)
+	}
+}
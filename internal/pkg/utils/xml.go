@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 const (
@@ -128,139 +130,101 @@ func FindTokenizedNthTableByTextBeforeTable(rawHTML, stringToFind string, skip i
 	return nil, fmt.Errorf("failed to find text %q before table: %w", stringToFind, tokenizer.Err())
 }
 
-// ParseTable parses the Table starting from the current position of the tokenizer.
+// ParseTable parses the Table starting from the current position of the tokenizer, either at or right after the
+// <table> start tag. The table is parsed the way browsers do: missing <tr> and closing tags are implied, cells spanning
+// several rows or columns are repeated in each of them, <tfoot> rows come last and nested tables are left out.
+//
+// The Header is the <thead>, stacked header rows joined per column, or else the first row. Tables without a header,
+// where every row is labeled by a <th> in its first cell, have a nil Header. Rows without cells are dropped.
 func ParseTable(tokenizer *html.Tokenizer) (Table, error) {
-	var t Table
-
-	for {
-		tt := tokenizer.Next()
-		switch tt { //nolint: exhaustive // we only care about start tags, end tags, and errors
-		case html.ErrorToken:
-			return t, handleErrToken(tokenizer.Err())
-
-		case html.StartTagToken:
-			var err error
-			row, err := extractTableRow(tokenizer)
-			if len(row) > 0 { // even with an error, there might be a valid row
-				addRowToTable(&t, row)
-			}
-			if errors.Is(err, io.EOF) {
-				return t, nil // reached end of table or document
-			}
-
-		case html.EndTagToken:
-			if isClosingTableTag(tokenizer) {
-				return t, nil
-			}
-
-		default:
-			continue
-		}
+	rawTable, err := readRawTable(tokenizer)
+	if err != nil {
+		return Table{}, err
 	}
-}
 
-func addRowToTable(t *Table, row []string) {
-	if t.Header == nil {
-		t.Header = row
-	} else {
-		t.Rows = append(t.Rows, row)
+	nodes, err := html.ParseFragment(strings.NewReader(rawTable), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return Table{}, fmt.Errorf("failed to parse table: %w", err)
 	}
-}
-
-func isClosingTableTag(tokenizer *html.Tokenizer) bool {
-	tn, _ := tokenizer.TagName()
-	tagName := string(tn)
-	return tagName == TagTable
-}
-
-func extractTableRow(tokenizer *html.Tokenizer) ([]string, error) {
-	tn, _ := tokenizer.TagName()
-	tagName := string(tn)
-	if tagName != TagTr {
-		return nil, nil
-	}
-
-	return parseTableRow(tokenizer)
-}
 
-func handleErrToken(err error) error {
-	if errors.Is(err, io.EOF) {
-		return nil // done, no error
+	for _, node := range nodes {
+		if isTable(node) {
+			return tableFromNode(node), nil
+		}
 	}
-	return err
+	return Table{}, nil
 }
 
-// Helper function to parse a Table row.
-func parseTableRow(tokenizer *html.Tokenizer) ([]string, error) {
-	var row []string
+// readRawTable returns the markup of the table at the tokenizer, up to and including its closing tag or the end of the
+// document.
+func readRawTable(tokenizer *html.Tokenizer) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("<table>")
 
-	for {
+	depth := 1
+	started := false
+	for depth > 0 {
 		tt := tokenizer.Next()
-		switch tt { //nolint: exhaustive // we only care about start tags, end tags, and errors
-		case html.ErrorToken:
-			return row, handleErrToken(tokenizer.Err())
-
-		case html.StartTagToken:
-			if !isTableCell(tokenizer) {
-				continue
+		if tt == html.ErrorToken {
+			if errors.Is(tokenizer.Err(), io.EOF) {
+				break // unclosed table at the end of the document
 			}
+			return "", fmt.Errorf("failed to read table: %w", tokenizer.Err())
+		}
 
-			cellContent, err := extractTextFromTd(tokenizer)
-			row = append(row, cellContent)
-			if err != nil {
-				return row, err
+		raw := tokenizer.Raw()
+		switch tt { //nolint: exhaustive // we only care about table tags
+		case html.StartTagToken:
+			tn, _ := tokenizer.TagName()
+			if string(tn) == TagTable {
+				if !started {
+					// the tokenizer was positioned in front of the <table> start tag
+					started = true
+					continue
+				}
+				depth++
 			}
-
+			started = true
 		case html.EndTagToken:
 			tn, _ := tokenizer.TagName()
-			tagName := string(tn)
-			if tagName == TagTr {
-				return row, nil
-			}
-			if tagName == TagTable {
-				return row, io.EOF
+			if string(tn) == TagTable {
+				depth--
 			}
-		default:
-			continue
+			started = true
+		case html.TextToken:
+			started = started || strings.TrimSpace(string(raw)) != ""
 		}
+		sb.Write(raw)
 	}
-}
 
-func isTableCell(tokenizer *html.Tokenizer) bool {
-	tn, _ := tokenizer.TagName()
-	tagName := string(tn)
-	return tagName == TagTh || tagName == TagTd
+	if depth > 0 {
+		sb.WriteString("</table>")
+	}
+	return sb.String(), nil
 }
 
-// Function to extract text content from within a tag.
-func extractTextFromTd(tokenizer *html.Tokenizer) (string, error) {
-	var sb strings.Builder
-	var err error
-loop:
-	for {
-		tt := tokenizer.Next()
-		switch tt { //nolint: exhaustive // we only care about text tokens, end tags, and errors
-		case html.ErrorToken:
-			err = tokenizer.Err()
-			break loop
-
-		case html.TextToken:
-			sb.Write(tokenizer.Text())
+func tableFromNode(node *html.Node) Table {
+	head, body := layoutTable(node)
+	head = slices.DeleteFunc(head, func(row []gridCell) bool { return len(row) == 0 })
+	body = slices.DeleteFunc(body, func(row []gridCell) bool { return len(row) == 0 })
 
-		case html.EndTagToken:
-			tn, _ := tokenizer.TagName()
-			tag := string(tn)
-			if tag == TagTd || tag == TagTh || tag == TagTr {
-				break loop
-			}
-			if tag == TagTable {
-				err = io.EOF
-				break loop
-			}
-		default:
-			continue
-		}
+	var t Table
+	switch {
+	case len(head) > 0:
+		t.Header = joinHeaderRows(cellTexts(head))
+	case len(body) > 0 && !slices.ContainsFunc(body, func(row []gridCell) bool { return !isRowHeaderRow(row) }):
+		// no header row, e.g. <tr><th>Bindningstid</th><td>3 mån</td></tr><tr><th>Ränta</th><td>3,45 %</td></tr>
+	case len(body) > 0:
+		t.Header = cellTexts(body[:1])[0]
+		body = body[1:]
 	}
 
-	return NormalizeSpaces(sb.String()), err
+	if len(body) > 0 {
+		t.Rows = cellTexts(body)
+	}
+	return t
 }
@@ -434,9 +434,89 @@ func TestParseTable(t *testing.T) {
 			html: `<table>
 				<tr><td>Part1<br/>Part2</td></tr>
 			</table>`,
-			wantHeader: []string{"Part1Part2"},
+			wantHeader: []string{"Part1 Part2"},
 			wantRows:   nil,
 		},
+		{
+			name: "rowspan and colspan",
+			html: `<table>
+				<tr><th>Månad</th><th colspan="2">Fast ränta</th></tr>
+				<tr><td rowspan="2">2025-01</td><td>3,10</td><td>3,30</td></tr>
+				<tr><td>3,15</td><td>3,35</td></tr>
+			</table>`,
+			wantHeader: []string{"Månad", "Fast ränta", "Fast ränta"},
+			wantRows:   [][]string{{"2025-01", "3,10", "3,30"}, {"2025-01", "3,15", "3,35"}},
+		},
+		{
+			name: "stacked thead rows are joined per column",
+			html: `<table>
+				<thead>
+					<tr><th rowspan="2">Månad</th><th colspan="2">Fast ränta</th></tr>
+					<tr><th>1 år</th><th>3 år</th></tr>
+				</thead>
+				<tbody><tr><td>2025-01</td><td>3,10</td><td>3,30</td></tr></tbody>
+			</table>`,
+			wantHeader: []string{"Månad", "Fast ränta 1 år", "Fast ränta 3 år"},
+			wantRows:   [][]string{{"2025-01", "3,10", "3,30"}},
+		},
+		{
+			name: "tfoot rows come last",
+			html: `<table>
+				<thead><tr><th>Term</th></tr></thead>
+				<tfoot><tr><td>Senast ändrad 2025-01-15</td></tr></tfoot>
+				<tbody><tr><td>3 mån</td></tr></tbody>
+			</table>`,
+			wantHeader: []string{"Term"},
+			wantRows:   [][]string{{"3 mån"}, {"Senast ändrad 2025-01-15"}},
+		},
+		{
+			name: "rowspan does not cross sections",
+			html: `<table>
+				<tbody><tr><td rowspan="3">A</td><td>1</td></tr></tbody>
+				<tbody><tr><td>B</td><td>2</td></tr></tbody>
+			</table>`,
+			wantHeader: []string{"A", "1"},
+			wantRows:   [][]string{{"B", "2"}},
+		},
+		{
+			name: "nested table is left out",
+			html: `<table>
+				<tr><th>Term</th><th>Rate</th></tr>
+				<tr><td>3 mån<table><tr><td>Info</td></tr></table></td><td>3,45</td></tr>
+			</table>`,
+			wantHeader: []string{"Term", "Rate"},
+			wantRows:   [][]string{{"3 mån", "3,45"}},
+		},
+		{
+			name: "implied rows and unclosed cells",
+			html: `<table>
+				<tbody>
+					<td>Månad<td>Ränta</tr>
+					<td>2025 11<td>3,58 %</tr>
+					<tr><td>2025 10<td>3,62 %
+				</tbody>
+			</table>`,
+			wantHeader: []string{"Månad", "Ränta"},
+			wantRows:   [][]string{{"2025 11", "3,58 %"}, {"2025 10", "3,62 %"}},
+		},
+		{
+			name: "row headers in th without header row",
+			html: `<table>
+				<tr><th>Bindningstid</th><td>3 mån</td><td>1 år</td></tr>
+				<tr><th>Ränta</th><td>3,45 %</td><td>3,10 %</td></tr>
+			</table>`,
+			wantHeader: nil,
+			wantRows:   [][]string{{"Bindningstid", "3 mån", "1 år"}, {"Ränta", "3,45 %", "3,10 %"}},
+		},
+		{
+			name: "row headers in th below a header row",
+			html: `<table>
+				<tr><th>Månad</th><th>3 mån</th></tr>
+				<tr><th>2025-01</th><td>3,45</td></tr>
+			</table>`,
+			wantHeader: []string{"Månad", "3 mån"},
+			wantRows:   [][]string{{"2025-01", "3,45"}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -473,6 +553,24 @@ func TestParseTable_EndOfDocument(t *testing.T) {
 	}
 }
 
+func TestParseTable_TokenizerBeforeTable(t *testing.T) {
+	t.Parallel()
+
+	// Callers may also pass a tokenizer positioned in front of the <table> start tag.
+	tokenizer := html.NewTokenizer(strings.NewReader(`<table><tr><th>A</th></tr><tr><td>1</td></tr></table><p>After</p>`))
+	table, err := ParseTable(tokenizer)
+	if err != nil {
+		t.Fatalf("ParseTable() error = %v", err)
+	}
+
+	assertTableHeader(t, table.Header, []string{"A"})
+	assertTableRows(t, table.Rows, [][]string{{"1"}})
+
+	if tt := tokenizer.Next(); tt != html.StartTagToken || tokenizer.Token().Data != "p" {
+		t.Errorf("tokenizer not positioned after the table")
+	}
+}
+
 func TestTable_StructFields(t *testing.T) {
 	t.Parallel()
 